@@ -12,7 +12,10 @@ import (
 	"github.com/go-kratos/kratos/v2/registry"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/pkg/errors"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/maintenance"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/notice"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/pkg/security"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net/health"
 	tcp "github.com/vulcan-frame/vulcan-gate/pkg/net/tcp/server"
@@ -32,7 +35,7 @@ func init() {
 }
 
 func newApp(logger log.Logger, ts *tcp.Server, hs *http.Server, gs *grpc.Server, health *health.Server,
-	label *conf.Label, rr registry.Registrar,
+	label *conf.Label, rr registry.Registrar, ns *notice.Scheduler, mk *maintenance.Kicker,
 ) *kratos.App {
 	md := map[string]string{
 		profile.SERVICE: label.Service,
@@ -53,7 +56,7 @@ func newApp(logger log.Logger, ts *tcp.Server, hs *http.Server, gs *grpc.Server,
 		kratos.Version(label.Version),
 		kratos.Metadata(md),
 		kratos.Logger(logger),
-		kratos.Server(health, ts, hs, gs),
+		kratos.Server(health, ts, hs, gs, ns, mk),
 		kratos.Registrar(rr),
 	)
 }
@@ -92,9 +95,10 @@ func main() {
 	if err := c.Scan(&sc); err != nil {
 		panic(err)
 	}
-	if err := security.Init(sc.AesKey, sc.PrivateKey); err != nil {
+	if err := security.Init(&sc); err != nil {
 		panic(err)
 	}
+	watchSecret(c)
 
 	if err := trace.Init(bc.Trace.Endpoint, bc.Label.Service, bc.Label.Profile, bc.Label.Color); err != nil {
 		panic(err)
@@ -103,7 +107,7 @@ func main() {
 	logger := vlog.Init(bc.Log.Type, bc.Log.Level, bc.Label.Profile, bc.Label.Color, bc.Label.Service, bc.Label.Version, bc.Label.Node)
 	metrics.Init(bc.Label.Service)
 
-	app, cleanup, err := initApp(bc.Server, bc.Label, &rc, bc.Data, logger, health.NewServer(bc.Server.Health))
+	app, cleanup, err := initApp(bc.Server, bc.Label, &rc, bc.Data, bc.Auth, bc.Guard, bc.Tunnels, bc.Topics, bc.Offline, bc.Notices, bc.Maintenance, bc.Queue, bc.Admin, logger)
 	if err != nil {
 		panic(err)
 	}
@@ -115,3 +119,27 @@ func main() {
 		panic(err)
 	}
 }
+
+var secretKeys = []string{"private_key", "aes_key", "active_token_key", "token_keys", "active_handshake_key", "handshake_keys"}
+
+// watchSecret reloads the keyring when the secret file changes, the old keyring is kept if the new one is invalid
+func watchSecret(c config.Config) {
+	reload := func(key string, _ config.Value) {
+		var sc conf.Secret
+		if err := c.Scan(&sc); err != nil {
+			log.Errorf("[main] secret scan failed. key=%s %+v", key, err)
+			return
+		}
+		if err := security.Init(&sc); err != nil {
+			log.Errorf("[main] secret reload failed. key=%s %+v", key, err)
+			return
+		}
+		log.Infof("[main] secret reloaded. key=%s", key)
+	}
+
+	for _, k := range secretKeys {
+		if err := c.Watch(k, reload); err != nil && !errors.Is(err, config.ErrNotFound) {
+			log.Errorf("[main] secret watch failed. key=%s %+v", k, err)
+		}
+	}
+}
@@ -14,10 +14,10 @@ import (
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/service"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/server"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/service/admin"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/service/push"
-	"github.com/vulcan-frame/vulcan-gate/pkg/net/health"
 )
 
-func initApp(*conf.Server, *conf.Label, *conf.Registry, *conf.Data, log.Logger, *health.Server) (*kratos.App, func(), error) {
-	panic(wire.Build(server.ProviderSet, data.ProviderSet, service.ProviderSet, push.ProviderSet, client.ProviderSet, newApp))
+func initApp(*conf.Server, *conf.Label, *conf.Registry, *conf.Data, *conf.Auth, *conf.Guard, *conf.Tunnels, *conf.Topics, *conf.Offline, *conf.Notices, *conf.Maintenance, *conf.Queue, *conf.Admin, log.Logger) (*kratos.App, func(), error) {
+	panic(wire.Build(server.ProviderSet, data.ProviderSet, service.ProviderSet, push.ProviderSet, admin.ProviderSet, client.ProviderSet, newApp))
 }
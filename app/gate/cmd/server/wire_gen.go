@@ -10,20 +10,30 @@ import (
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/account"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/backend"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/gate"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/player"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/room"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/authenticator"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/guard"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/maintenance"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/notice"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/offline"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/queue"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/service"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/topic"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/router"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/server"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/service/admin"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/service/push/v1"
-	"github.com/vulcan-frame/vulcan-gate/pkg/net/health"
 )
 
 // Injectors from wire.go:
 
-func initApp(confServer *conf.Server, label *conf.Label, registry *conf.Registry, confData *conf.Data, logger log.Logger, healthServer *health.Server) (*kratos.App, func(), error) {
+func initApp(confServer *conf.Server, label *conf.Label, registry *conf.Registry, confData *conf.Data, auth *conf.Auth, confGuard *conf.Guard, tunnels *conf.Tunnels, topics *conf.Topics, confOffline *conf.Offline, notices *conf.Notices, confMaintenance *conf.Maintenance, confQueue *conf.Queue, confAdmin *conf.Admin, logger log.Logger) (*kratos.App, func(), error) {
 	dataData, cleanup, err := data.NewData(confData)
 	if err != nil {
 		return nil, nil, err
@@ -48,22 +58,122 @@ func initApp(confServer *conf.Server, label *conf.Label, registry *conf.Registry
 		return nil, nil, err
 	}
 	intrav1TunnelServiceClient := room.NewClient(roomConn)
-	serviceService := service.NewTCPService(logger, label, playerRouteTable, tunnelServiceClient, roomRouteTable, intrav1TunnelServiceClient)
-	tcpServer, err := server.NewTCPServer(confServer, logger, routeTable, serviceService)
+	accountConn, cleanup2, err := account.NewConn(logger, discovery)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
-	pushServiceServer := v1.NewPushService(logger, tcpServer)
-	httpServer := server.NewHTTPServer(confServer, logger, pushServiceServer)
+	accountInterfaceClient := account.NewClient(accountConn)
+	authenticatorAuthenticator, err := authenticator.NewAuthenticator(auth, logger, accountInterfaceClient)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	backends, cleanup3, err := backend.NewBackends(tunnels, logger, dataData, discovery)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	clients, cleanup4, err := gate.NewClients(logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	store := offline.NewStore(confOffline, logger, dataData)
+	maintenanceMaintenance, cleanup5, err := maintenance.NewMaintenance(confMaintenance, logger, dataData)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	serviceService := service.NewTCPService(logger, label, authenticatorAuthenticator, playerRouteTable, tunnelServiceClient, roomRouteTable, intrav1TunnelServiceClient, backends, routeTable, clients, tunnels, topics, store, maintenanceMaintenance)
+	guardGuard, cleanup6, err := guard.NewGuard(confGuard, logger, dataData)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	queueQueue, cleanup7, err := queue.NewQueue(confQueue, label, logger, dataData)
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	tcpServer, err := server.NewTCPServer(confServer, logger, routeTable, serviceService, guardGuard, queueQueue)
+	if err != nil {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	hub, cleanup8, err := topic.NewHub(topics, logger, dataData, tcpServer)
+	if err != nil {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	pushServiceServer := v1.NewPushService(logger, tcpServer, hub, store, routeTable)
+	adminService := admin.NewAdminService(confAdmin, logger, guardGuard, maintenanceMaintenance)
+	httpServer := server.NewHTTPServer(confServer, logger, pushServiceServer, adminService)
 	grpcServer := server.NewGRPCServer(confServer, logger, pushServiceServer)
-	registrar, err := server.NewRegistrar(registry)
+	registrar, err := server.NewRegistrar(registry, confServer, logger, tcpServer)
+	if err != nil {
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	healthServer := server.NewHealthServer(confServer, dataData, registrar, discovery, backends, tcpServer)
+	noticeInterfaceClient := account.NewNoticeClient(accountConn)
+	scheduler, err := notice.NewScheduler(notices, label, logger, dataData, tcpServer, noticeInterfaceClient)
 	if err != nil {
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	app := newApp(logger, tcpServer, httpServer, grpcServer, healthServer, label, registrar)
+	kicker := maintenance.NewKicker(logger, maintenanceMaintenance, tcpServer)
+	app := newApp(logger, tcpServer, httpServer, grpcServer, healthServer, label, registrar, scheduler, kicker)
 	return app, func() {
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 	}, nil
 }
@@ -0,0 +1,54 @@
+package account
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/metadata"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
+	"github.com/go-kratos/kratos/v2/registry"
+	kgrpc "github.com/go-kratos/kratos/v2/transport/grpc"
+	"github.com/pkg/errors"
+	accountv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/account/interface/v1"
+	"google.golang.org/grpc"
+)
+
+const (
+	serviceName = "vulcan.account.interface"
+)
+
+type Conn struct {
+	*grpc.ClientConn
+}
+
+// NewConn dials the account service through discovery, the account service is stateless so no route table is needed
+func NewConn(logger log.Logger, r registry.Discovery) (*Conn, func(), error) {
+	conn, err := kgrpc.DialInsecure(context.Background(),
+		kgrpc.WithEndpoint("discovery:///"+serviceName),
+		kgrpc.WithDiscovery(r),
+		kgrpc.WithMiddleware(
+			recovery.Recovery(),
+			metadata.Client(),
+			tracing.Client(),
+		),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "dial account service failed. service=%s", serviceName)
+	}
+
+	cleanup := func() {
+		if err := conn.Close(); err != nil {
+			log.NewHelper(logger).Errorf("[account.Conn] close failed. %+v", err)
+		}
+	}
+	return &Conn{ClientConn: conn}, cleanup, nil
+}
+
+func NewClient(conn *Conn) accountv1.AccountInterfaceClient {
+	return accountv1.NewAccountInterfaceClient(conn.ClientConn)
+}
+
+func NewNoticeClient(conn *Conn) accountv1.NoticeInterfaceClient {
+	return accountv1.NewNoticeInterfaceClient(conn.ClientConn)
+}
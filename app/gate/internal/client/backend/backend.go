@@ -0,0 +1,189 @@
+package backend
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/player"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/room"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/mux"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
+	"github.com/vulcan-frame/vulcan-pkg-app/router/balancer"
+	"github.com/vulcan-frame/vulcan-pkg-app/router/conn"
+	"github.com/vulcan-frame/vulcan-pkg-app/router/routetable"
+	"github.com/vulcan-frame/vulcan-pkg-app/router/routetable/redis"
+)
+
+// Backend is a tunnel backend declared in the config, the gate talks to it through the generic tunnel
+type Backend struct {
+	Type       tunnels.TunnelType
+	Name       string
+	Service    string
+	Main       bool
+	Method     string
+	RouteTable routetable.RouteTable
+	Conn       *conn.Conn
+
+	// Mux is not nil in the multiplexed mode, the sessions share the streams of the backend node
+	Mux *mux.Mux
+}
+
+// Backends maps the client modules to the tunnel types.
+// the modules not declared are forwarded to the player tunnel
+type Backends struct {
+	backends map[tunnels.TunnelType]*Backend
+	modules  map[int32]tunnels.TunnelType
+
+	// builtin are the muxes of the built-in player and room tunnels in the multiplexed mode
+	builtin map[tunnels.TunnelType]*mux.Mux
+}
+
+// NewBackends builds the backends declared in the config.
+// a backend can take over the room module from the built-in room tunnel, e.g. to serve the rooms in the multiplexed mode
+func NewBackends(c *conf.Tunnels, logger log.Logger, d *data.Data, r registry.Discovery) (*Backends, func(), error) {
+	b := &Backends{
+		backends: make(map[tunnels.TunnelType]*Backend),
+		modules:  make(map[int32]tunnels.TunnelType),
+		builtin:  make(map[tunnels.TunnelType]*mux.Mux),
+	}
+	if c != nil {
+		if err := b.init(c, logger, d, r); err != nil {
+			b.close()
+			return nil, nil, err
+		}
+	}
+	if _, ok := b.modules[int32(climod.ModuleID_Room)]; !ok {
+		b.modules[int32(climod.ModuleID_Room)] = tunnels.RoomTunnelType
+	}
+	return b, b.close, nil
+}
+
+func (b *Backends) init(c *conf.Tunnels, logger log.Logger, d *data.Data, r registry.Discovery) error {
+	if c.PlayerMuxStreams > 0 {
+		b.builtin[tunnels.PlayerTunnelType] = mux.NewMux("player", player.ServiceName, int(c.PlayerMuxStreams), player.NewRouteTable(d).RouteTable, r, logger)
+	}
+	if c.RoomMuxStreams > 0 {
+		b.builtin[tunnels.RoomTunnelType] = mux.NewMux("room", room.ServiceName, int(c.RoomMuxStreams), room.NewRouteTable(d).RouteTable, r, logger)
+	}
+
+	for _, bc := range c.Backends {
+		backend, err := newBackend(bc, logger, d, r)
+		if err != nil {
+			return err
+		}
+		if _, ok := b.backends[backend.Type]; ok {
+			return errors.Errorf("tunnel backend type duplicated. type=%d name=%s", backend.Type, backend.Name)
+		}
+		b.backends[backend.Type] = backend
+		for _, mod := range bc.Modules {
+			if tp, ok := b.modules[mod]; ok {
+				return errors.Errorf("module is already forwarded to another tunnel. mod=%d type=%d name=%s", mod, tp, backend.Name)
+			}
+			b.modules[mod] = backend.Type
+		}
+	}
+	return nil
+}
+
+// MainServices returns the services of the main backends, the gate is not ready without them
+func (b *Backends) MainServices() []string {
+	services := make([]string, 0, len(b.backends))
+	for _, backend := range b.backends {
+		if backend.Main {
+			services = append(services, backend.Service)
+		}
+	}
+	slices.Sort(services)
+	return slices.Compact(services)
+}
+
+// BuiltinMux returns the mux of the built-in tunnel type, or nil if it has its own stream per session
+func (b *Backends) BuiltinMux(tp tunnels.TunnelType) *mux.Mux {
+	return b.builtin[tp]
+}
+
+func (b *Backends) close() {
+	for _, m := range b.builtin {
+		m.Close()
+	}
+	for _, backend := range b.backends {
+		if backend.Mux != nil {
+			backend.Mux.Close()
+		}
+	}
+}
+
+func newBackend(c *conf.Tunnels_Backend, logger log.Logger, d *data.Data, r registry.Discovery) (*Backend, error) {
+	tp := tunnels.TunnelType(c.Type)
+	if tp == tunnels.PlayerTunnelType || tp == tunnels.RoomTunnelType {
+		return nil, errors.Errorf("tunnel type is built in. type=%d name=%s", tp, c.Name)
+	}
+	if len(c.Name) == 0 || len(c.Service) == 0 {
+		return nil, errors.Errorf("tunnel backend name and service are required. type=%d", tp)
+	}
+
+	bt, err := parseBalancer(c.Balancer)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "name=%s", c.Name)
+	}
+
+	method := c.Method
+	if len(method) == 0 {
+		method = fmt.Sprintf("/%s.intra.v1.TunnelService/Tunnel", c.Name)
+	}
+	rtName := c.RouteTable
+	if len(rtName) == 0 {
+		rtName = c.Name
+	}
+
+	rt := routetable.NewRouteTable(rtName, redis.NewRouteTable(d.Rdb))
+	backend := &Backend{
+		Type:       tp,
+		Name:       c.Name,
+		Service:    c.Service,
+		Main:       c.Main,
+		Method:     method,
+		RouteTable: rt,
+	}
+
+	if c.MuxStreams > 0 {
+		backend.Mux = mux.NewMux(c.Name, c.Service, int(c.MuxStreams), rt, r, logger)
+		return backend, nil
+	}
+
+	if backend.Conn, err = conn.NewConn(c.Service, bt, logger, rt, r); err != nil {
+		return nil, errors.WithMessagef(err, "tunnel backend conn create failed. name=%s service=%s", c.Name, c.Service)
+	}
+	return backend, nil
+}
+
+func parseBalancer(s string) (balancer.BalancerType, error) {
+	switch strings.ToLower(s) {
+	case "", "master":
+		return balancer.BalancerTypeMaster, nil
+	case "random":
+		return balancer.BalancerTypeRandom, nil
+	default:
+		return balancer.BalancerTypeMaster, errors.Errorf("balancer invalid. balancer=%s", s)
+	}
+}
+
+// TunnelType returns the tunnel type of the module, the player tunnel is the default
+func (b *Backends) TunnelType(mod int32) tunnels.TunnelType {
+	if tp, ok := b.modules[mod]; ok {
+		return tp
+	}
+	return tunnels.PlayerTunnelType
+}
+
+func (b *Backends) Get(tp tunnels.TunnelType) (*Backend, bool) {
+	backend, ok := b.backends[tp]
+	return backend, ok
+}
@@ -5,6 +5,9 @@ import (
 	"github.com/go-kratos/kratos/v2/registry"
 	"github.com/google/wire"
 	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/account"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/backend"
+	gateclient "github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/gate"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/player"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/room"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
@@ -16,7 +19,9 @@ var ProviderSet = wire.NewSet(
 	NewDiscovery,
 	player.NewRouteTable, player.NewConn, player.NewClient,
 	room.NewRouteTable, room.NewConn, room.NewClient,
-	gate.NewRouteTable,
+	account.NewConn, account.NewClient, account.NewNoticeClient,
+	backend.NewBackends,
+	gate.NewRouteTable, gateclient.NewClients,
 )
 
 func NewDiscovery(conf *conf.Registry) (registry.Discovery, error) {
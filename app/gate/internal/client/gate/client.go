@@ -0,0 +1,126 @@
+package gate
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/metadata"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
+	kgrpc "github.com/go-kratos/kratos/v2/transport/grpc"
+	"github.com/pkg/errors"
+	pushv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	"google.golang.org/grpc"
+)
+
+const (
+	// idleTimeout is how long a conn is kept without any push, the gate may be gone
+	idleTimeout   = 5 * time.Minute
+	sweepInterval = time.Minute
+)
+
+// Clients keeps the PushService clients to the other gates, the gate of a user is resolved by the gate route table.
+// the conns are dialed on demand by the address, and closed when the gate is unavailable or the conn is idle
+type Clients struct {
+	log *log.Helper
+
+	mu        sync.Mutex
+	conns     map[string]*client
+	lastSweep time.Time
+}
+
+type client struct {
+	conn     *grpc.ClientConn
+	lastUsed time.Time
+}
+
+func NewClients(logger log.Logger) (*Clients, func(), error) {
+	c := &Clients{
+		log:       log.NewHelper(log.With(logger, "module", "gate/client/gate")),
+		conns:     make(map[string]*client),
+		lastSweep: time.Now(),
+	}
+	return c, c.close, nil
+}
+
+// PushClient returns the PushService client of the gate on the address which is the grpc endpoint in the gate route table
+func (c *Clients) PushClient(addr string) (pushv1.PushServiceClient, error) {
+	addr = trimScheme(addr)
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.lastSweep) >= sweepInterval {
+		c.sweep(now)
+	}
+
+	cli, ok := c.conns[addr]
+	if !ok {
+		conn, err := kgrpc.DialInsecure(context.Background(),
+			kgrpc.WithEndpoint(addr),
+			kgrpc.WithMiddleware(
+				recovery.Recovery(),
+				metadata.Client(),
+				tracing.Client(),
+			),
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "dial gate failed. addr=%s", addr)
+		}
+		cli = &client{conn: conn}
+		c.conns[addr] = cli
+	}
+	cli.lastUsed = now
+	return pushv1.NewPushServiceClient(cli.conn), nil
+}
+
+// Evict closes the conn of the gate, it is called when the gate is unavailable
+func (c *Clients) Evict(addr string) {
+	addr = trimScheme(addr)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cli, ok := c.conns[addr]; ok {
+		delete(c.conns, addr)
+		c.closeConn(addr, cli.conn)
+	}
+}
+
+// sweep closes the conns idle for longer than the idle timeout
+func (c *Clients) sweep(now time.Time) {
+	c.lastSweep = now
+	for addr, cli := range c.conns {
+		if now.Sub(cli.lastUsed) >= idleTimeout {
+			delete(c.conns, addr)
+			c.closeConn(addr, cli.conn)
+		}
+	}
+}
+
+func (c *Clients) closeConn(addr string, conn *grpc.ClientConn) {
+	if err := conn.Close(); err != nil {
+		c.log.Errorf("[gate.Clients] conn close failed. addr=%s %+v", addr, err)
+	}
+}
+
+func (c *Clients) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for addr, cli := range c.conns {
+		c.closeConn(addr, cli.conn)
+	}
+	c.conns = make(map[string]*client)
+}
+
+func trimScheme(addr string) string {
+	if i := strings.Index(addr, "://"); i >= 0 {
+		return addr[i+3:]
+	}
+	return addr
+}
@@ -0,0 +1,46 @@
+package gate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientsEvict(t *testing.T) {
+	c, cleanup, err := NewClients(log.DefaultLogger)
+	require.NoError(t, err)
+	defer cleanup()
+
+	_, err = c.PushClient("grpc://127.0.0.1:19001")
+	require.NoError(t, err)
+	_, err = c.PushClient("127.0.0.1:19001")
+	require.NoError(t, err)
+	assert.Len(t, c.conns, 1, "the conn is shared by the address without the scheme")
+
+	c.Evict("grpc://127.0.0.1:19001")
+	assert.Empty(t, c.conns)
+}
+
+func TestClientsSweep(t *testing.T) {
+	c, cleanup, err := NewClients(log.DefaultLogger)
+	require.NoError(t, err)
+	defer cleanup()
+
+	_, err = c.PushClient("127.0.0.1:19001")
+	require.NoError(t, err)
+	_, err = c.PushClient("127.0.0.1:19002")
+	require.NoError(t, err)
+
+	// the first gate has been idle for the idle timeout, and the sweep is due
+	c.conns["127.0.0.1:19001"].lastUsed = time.Now().Add(-idleTimeout)
+	c.lastSweep = time.Now().Add(-sweepInterval)
+
+	_, err = c.PushClient("127.0.0.1:19003")
+	require.NoError(t, err)
+	assert.NotContains(t, c.conns, "127.0.0.1:19001")
+	assert.Contains(t, c.conns, "127.0.0.1:19002")
+	assert.Contains(t, c.conns, "127.0.0.1:19003")
+}
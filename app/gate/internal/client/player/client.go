@@ -9,7 +9,7 @@ import (
 )
 
 const (
-	serviceName = "vulcan.player.service"
+	ServiceName = "vulcan.player.service"
 )
 
 type Conn struct {
@@ -17,7 +17,7 @@ type Conn struct {
 }
 
 func NewConn(logger log.Logger, rt *RouteTable, r registry.Discovery) (*Conn, error) {
-	conn, err := conn.NewConn(serviceName, balancer.BalancerTypeMaster, logger, rt, r)
+	conn, err := conn.NewConn(ServiceName, balancer.BalancerTypeMaster, logger, rt, r)
 	if err != nil {
 		return nil, err
 	}
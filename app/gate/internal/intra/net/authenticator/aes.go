@@ -0,0 +1,58 @@
+package authenticator
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/pkg/security"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/intra/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ Authenticator = (*aesAuthenticator)(nil)
+
+// aesAuthenticator decrypts the base64 AES token with the keyring and decodes the proto AuthToken
+type aesAuthenticator struct{}
+
+func newAESAuthenticator() *aesAuthenticator {
+	return &aesAuthenticator{}
+}
+
+func (a *aesAuthenticator) Type() Type {
+	return TypeAES
+}
+
+func (a *aesAuthenticator) Authenticate(ctx context.Context, cred *Credential) (*Claims, error) {
+	auth, err := decryptAccountToken(cred.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Claims{
+		UID:     auth.AccountId,
+		Color:   auth.Color,
+		Status:  int64(auth.Status),
+		Timeout: time.Unix(auth.Timeout, 0),
+	}, nil
+}
+
+func decryptAccountToken(token string) (auth *intrav1.AuthToken, err error) {
+	if len(token) <= 0 {
+		err = errors.New("token is empty")
+		return
+	}
+
+	auth = &intrav1.AuthToken{}
+
+	bytes, err := security.DecryptToken(token)
+	if err != nil {
+		err = errors.Wrap(err, "token decrypt failed")
+		return
+	}
+
+	if err = proto.Unmarshal(bytes, auth); err != nil {
+		err = errors.Wrap(err, "AuthToken proto decode failed")
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package authenticator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/pkg/security"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/intra/v1"
+	"github.com/vulcan-frame/vulcan-pkg-tool/security/aes"
+	"google.golang.org/protobuf/proto"
+)
+
+const testAesKey = "XmTsvEVncDFSaEP0yGKBNvm8t4PNysnV"
+
+func initSecurity(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(pk)
+	require.NoError(t, err)
+	require.NoError(t, security.Init(&conf.Secret{AesKey: testAesKey, PrivateKey: base64.URLEncoding.EncodeToString(der)}))
+}
+
+// issueToken encrypts the AuthToken the way the account service does
+func issueToken(t *testing.T, auth *intrav1.AuthToken) string {
+	bytes, err := proto.Marshal(auth)
+	require.NoError(t, err)
+	block, err := aes.NewBlock([]byte(testAesKey))
+	require.NoError(t, err)
+	ser, err := aes.Encrypt([]byte(testAesKey), block, bytes)
+	require.NoError(t, err)
+	return base64.URLEncoding.EncodeToString(ser)
+}
+
+func TestAESAuthenticator(t *testing.T) {
+	initSecurity(t)
+	a := newAESAuthenticator()
+
+	timeout := time.Now().Add(time.Hour).Unix()
+	token := issueToken(t, &intrav1.AuthToken{AccountId: 10001, Timeout: timeout, Color: "blue", Status: intrav1.OnlineStatus(1)})
+
+	c, err := a.Authenticate(context.Background(), &Credential{Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10001), c.UID)
+	assert.Equal(t, "blue", c.Color)
+	assert.Equal(t, int64(1), c.Status)
+	assert.Equal(t, timeout, c.Timeout.Unix())
+	assert.False(t, c.Expired(time.Now()))
+
+	_, err = a.Authenticate(context.Background(), &Credential{})
+	assert.Error(t, err)
+
+	_, err = a.Authenticate(context.Background(), &Credential{Token: "k9.body"})
+	assert.Error(t, err)
+
+	_, err = a.Authenticate(context.Background(), &Credential{Token: "!not-base64!"})
+	assert.Error(t, err)
+}
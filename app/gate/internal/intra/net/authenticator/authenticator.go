@@ -0,0 +1,66 @@
+package authenticator
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	accountv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/account/interface/v1"
+)
+
+var ProviderSet = wire.NewSet(NewAuthenticator)
+
+type Type string
+
+const (
+	TypeAES    Type = "aes"    // AES encrypted AuthToken issued by the account service
+	TypeJWT    Type = "jwt"    // Ed25519 signed JWT verified offline
+	TypeRemote Type = "remote" // account session verified by AccountInterface.Token
+)
+
+// Authenticator verifies the credential of the handshake and returns the claims of the user
+type Authenticator interface {
+	Type() Type
+	Authenticate(ctx context.Context, cred *Credential) (*Claims, error)
+}
+
+// Credential is what the client presents in the handshake.
+// the aes and jwt authenticators verify the Token, the remote authenticator verifies the AccountID and Session
+type Credential struct {
+	Token     string
+	AccountID string
+	Session   string
+}
+
+// Claims is the result of the authentication, it maps onto net.NewSession
+type Claims struct {
+	UID     int64
+	Color   string
+	Status  int64
+	Timeout time.Time
+}
+
+func (c *Claims) Expired(now time.Time) bool {
+	return now.After(c.Timeout)
+}
+
+func NewAuthenticator(c *conf.Auth, logger log.Logger, accountClient accountv1.AccountInterfaceClient) (Authenticator, error) {
+	tp := TypeAES
+	if c != nil && len(c.Type) > 0 {
+		tp = Type(c.Type)
+	}
+
+	switch tp {
+	case TypeAES:
+		return newAESAuthenticator(), nil
+	case TypeJWT:
+		return newJWTAuthenticator(c.Jwt)
+	case TypeRemote:
+		return newRemoteAuthenticator(c.Remote, accountClient, logger), nil
+	default:
+		return nil, errors.Errorf("authenticator type invalid. type=%s", tp)
+	}
+}
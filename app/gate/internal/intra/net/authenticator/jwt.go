@@ -0,0 +1,137 @@
+package authenticator
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+)
+
+const jwtAlgEdDSA = "EdDSA"
+
+var _ Authenticator = (*jwtAuthenticator)(nil)
+
+// jwtAuthenticator verifies the compact JWS signed with Ed25519 offline.
+// the key is chosen by the kid of the header, so the signing key can be rotated by publishing a new one first
+type jwtAuthenticator struct {
+	issuer string
+	keys   map[string]ed25519.PublicKey
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Iss    string `json:"iss"`
+	Sub    string `json:"sub"`
+	Exp    int64  `json:"exp"`
+	Color  string `json:"color"`
+	Status int64  `json:"status"`
+}
+
+func newJWTAuthenticator(c *conf.Auth_JWT) (*jwtAuthenticator, error) {
+	if c == nil || len(c.Keys) == 0 {
+		return nil, errors.New("jwt public key is not configured")
+	}
+
+	a := &jwtAuthenticator{
+		issuer: c.Issuer,
+		keys:   make(map[string]ed25519.PublicKey, len(c.Keys)),
+	}
+	for _, k := range c.Keys {
+		pub, err := parseEd25519PublicKey(k.PublicKey)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "kid=%s", k.Id)
+		}
+		a.keys[k.Id] = pub
+	}
+	return a, nil
+}
+
+func parseEd25519PublicKey(key string) (ed25519.PublicKey, error) {
+	der, err := base64.URLEncoding.DecodeString(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "base64 DecodeString failed.")
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, errors.Wrapf(err, "x509 ParsePKIXPublicKey failed.")
+	}
+	edPub, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not Ed25519")
+	}
+	return edPub, nil
+}
+
+func (a *jwtAuthenticator) Type() Type {
+	return TypeJWT
+}
+
+func (a *jwtAuthenticator) Authenticate(ctx context.Context, cred *Credential) (*Claims, error) {
+	parts := strings.Split(cred.Token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("jwt format invalid")
+	}
+
+	header := &jwtHeader{}
+	if err := decodeJWTPart(parts[0], header); err != nil {
+		return nil, errors.WithMessage(err, "jwt header decode failed")
+	}
+	if header.Alg != jwtAlgEdDSA {
+		return nil, errors.Errorf("jwt alg invalid. alg=%s", header.Alg)
+	}
+
+	pub, ok := a.keys[header.Kid]
+	if !ok {
+		return nil, errors.Errorf("jwt key not found. kid=%s", header.Kid)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "jwt signature decode failed")
+	}
+	if !ed25519.Verify(pub, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, errors.Errorf("jwt signature invalid. kid=%s", header.Kid)
+	}
+
+	claims := &jwtClaims{}
+	if err = decodeJWTPart(parts[1], claims); err != nil {
+		return nil, errors.WithMessage(err, "jwt claims decode failed")
+	}
+	if len(a.issuer) > 0 && claims.Iss != a.issuer {
+		return nil, errors.Errorf("jwt issuer invalid. iss=%s", claims.Iss)
+	}
+
+	uid, err := strconv.ParseInt(claims.Sub, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "jwt sub must be int64. sub=%s", claims.Sub)
+	}
+
+	return &Claims{
+		UID:     uid,
+		Color:   claims.Color,
+		Status:  claims.Status,
+		Timeout: time.Unix(claims.Exp, 0),
+	}, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	bytes, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errors.Wrap(err, "base64 DecodeString failed")
+	}
+	if err = json.Unmarshal(bytes, v); err != nil {
+		return errors.Wrap(err, "json Unmarshal failed")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package authenticator
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+)
+
+func signJWT(t *testing.T, pri ed25519.PrivateKey, kid string, claims *jwtClaims) string {
+	header, err := json.Marshal(&jwtHeader{Alg: jwtAlgEdDSA, Kid: kid})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signing := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signing + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(pri, []byte(signing)))
+}
+
+func newTestKey(t *testing.T) (ed25519.PrivateKey, string) {
+	pub, pri, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	return pri, base64.URLEncoding.EncodeToString(der)
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	pri1, pub1 := newTestKey(t)
+	pri2, pub2 := newTestKey(t)
+	priUnknown, _ := newTestKey(t)
+
+	a, err := newJWTAuthenticator(&conf.Auth_JWT{
+		Issuer: "vulcan.account",
+		Keys: []*conf.Auth_JWT_Key{
+			{Id: "k1", PublicKey: pub1},
+			{Id: "k2", PublicKey: pub2},
+		},
+	})
+	require.NoError(t, err)
+
+	exp := time.Now().Add(time.Hour).Unix()
+	claims := &jwtClaims{Iss: "vulcan.account", Sub: "10001", Exp: exp, Color: "blue", Status: 1}
+
+	for _, kid := range []string{"k1", "k2"} {
+		pri := pri1
+		if kid == "k2" {
+			pri = pri2
+		}
+		c, err := a.Authenticate(context.Background(), &Credential{Token: signJWT(t, pri, kid, claims)})
+		require.NoError(t, err)
+		assert.Equal(t, int64(10001), c.UID)
+		assert.Equal(t, "blue", c.Color)
+		assert.Equal(t, int64(1), c.Status)
+		assert.Equal(t, exp, c.Timeout.Unix())
+	}
+
+	_, err = a.Authenticate(context.Background(), &Credential{Token: signJWT(t, priUnknown, "k1", claims)})
+	assert.Error(t, err)
+
+	_, err = a.Authenticate(context.Background(), &Credential{Token: signJWT(t, pri1, "k3", claims)})
+	assert.Error(t, err)
+
+	_, err = a.Authenticate(context.Background(), &Credential{Token: signJWT(t, pri1, "k1", &jwtClaims{Iss: "other", Sub: "10001", Exp: exp})})
+	assert.Error(t, err)
+
+	_, err = a.Authenticate(context.Background(), &Credential{Token: "a.b"})
+	assert.Error(t, err)
+}
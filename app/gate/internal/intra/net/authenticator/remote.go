@@ -0,0 +1,181 @@
+package authenticator
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	accountv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/account/interface/v1"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultRemoteTimeout   = time.Second * 2
+	defaultRemoteCacheTTL  = time.Minute
+	defaultRemoteCacheSize = 100_000
+)
+
+var _ Authenticator = (*remoteAuthenticator)(nil)
+
+// remoteAuthenticator verifies the account id and session of the handshake by AccountInterface.Token.
+// the token issued by the account service carries the claims, so it is decoded by the AES authenticator.
+// the results are kept in a LRU cache until the cache ttl or the token timeout, whichever comes first
+type remoteAuthenticator struct {
+	log    *log.Helper
+	client accountv1.AccountInterfaceClient
+	issued Authenticator
+
+	timeout time.Duration
+	group   singleflight.Group
+	cache   *claimsCache
+}
+
+func newRemoteAuthenticator(c *conf.Auth_Remote, client accountv1.AccountInterfaceClient, logger log.Logger) *remoteAuthenticator {
+	ttl, size := defaultRemoteCacheTTL, defaultRemoteCacheSize
+	a := &remoteAuthenticator{
+		log:     log.NewHelper(log.With(logger, "module", "gate/authenticator/remote")),
+		client:  client,
+		issued:  newAESAuthenticator(),
+		timeout: defaultRemoteTimeout,
+	}
+	if c != nil {
+		if c.Timeout != nil {
+			a.timeout = c.Timeout.AsDuration()
+		}
+		if c.CacheTtl != nil {
+			ttl = c.CacheTtl.AsDuration()
+		}
+		if c.CacheSize > 0 {
+			size = int(c.CacheSize)
+		}
+	}
+	a.cache = newClaimsCache(size, ttl)
+	return a
+}
+
+func (a *remoteAuthenticator) Type() Type {
+	return TypeRemote
+}
+
+func (a *remoteAuthenticator) Authenticate(ctx context.Context, cred *Credential) (*Claims, error) {
+	if len(cred.AccountID) == 0 || len(cred.Session) == 0 {
+		return nil, errors.New("account id or session is empty")
+	}
+
+	key := cred.AccountID + "/" + cred.Session
+	now := time.Now()
+	if claims := a.cache.get(key, now); claims != nil {
+		return claims, nil
+	}
+
+	// the call is shared by the callers of the same key, so it runs detached from the first caller with its own timeout,
+	// and each caller stops waiting when its own context is done
+	ch := a.group.DoChan(key, func() (interface{}, error) {
+		return a.verify(context.WithoutCancel(ctx), cred)
+	})
+	var res singleflight.Result
+	select {
+	case res = <-ch:
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), "remote authentication wait failed. account=%s", cred.AccountID)
+	}
+	if res.Err != nil {
+		return nil, res.Err
+	}
+
+	claims := res.Val.(*Claims)
+	a.cache.put(key, claims, now)
+	return claims, nil
+}
+
+func (a *remoteAuthenticator) verify(ctx context.Context, cred *Credential) (*Claims, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	reply, err := a.client.Token(ctx, &accountv1.TokenRequest{
+		AccountId: cred.AccountID,
+		Session:   cred.Session,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "AccountInterface.Token failed. account=%s", cred.AccountID)
+	}
+	if reply.Code != accountv1.TokenResponse_CODE_SUCCESS {
+		return nil, errors.Errorf("AccountInterface.Token rejected. account=%s code=%s", cred.AccountID, reply.Code)
+	}
+
+	return a.issued.Authenticate(ctx, &Credential{Token: reply.Token})
+}
+
+// claimsCache is a LRU of the verified claims, every entry expires at its own deadline
+type claimsCache struct {
+	size int
+	ttl  time.Duration
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key    string
+	claims *Claims
+	expiry time.Time
+}
+
+func newClaimsCache(size int, ttl time.Duration) *claimsCache {
+	return &claimsCache{
+		size:  size,
+		ttl:   ttl,
+		ll:    list.New(),
+		items: make(map[string]*list.Element, 1024),
+	}
+}
+
+func (c *claimsCache) get(key string, now time.Time) *Claims {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	e := el.Value.(*cacheEntry)
+	if now.After(e.expiry) {
+		c.remove(el)
+		return nil
+	}
+	c.ll.MoveToFront(el)
+	return e.claims
+}
+
+// put caches the claims until the ttl or the token timeout, the least recently used entry is evicted when the cache is full
+func (c *claimsCache) put(key string, claims *Claims, now time.Time) {
+	expiry := now.Add(c.ttl)
+	if claims.Timeout.Before(expiry) {
+		expiry = claims.Timeout
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*cacheEntry)
+		e.claims, e.expiry = claims, expiry
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&cacheEntry{key: key, claims: claims, expiry: expiry})
+	for c.ll.Len() > c.size {
+		c.remove(c.ll.Back())
+	}
+}
+
+func (c *claimsCache) remove(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*cacheEntry).key)
+}
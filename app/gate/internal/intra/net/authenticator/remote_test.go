@@ -0,0 +1,131 @@
+package authenticator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	accountv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/account/interface/v1"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/intra/v1"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type fakeAccountClient struct {
+	accountv1.AccountInterfaceClient
+
+	sessions map[string]string // account id -> session
+	tokens   map[string]string // account id -> issued token
+	calls    atomic.Int32
+	block    chan struct{} // the call waits for it to be closed if it is set
+}
+
+func (c *fakeAccountClient) Token(ctx context.Context, in *accountv1.TokenRequest, _ ...grpc.CallOption) (*accountv1.TokenResponse, error) {
+	c.calls.Inc()
+	if c.block != nil {
+		select {
+		case <-c.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if c.sessions[in.AccountId] != in.Session {
+		return &accountv1.TokenResponse{Code: accountv1.TokenResponse_CODE_ERR_SESSION_ILLEGAL}, nil
+	}
+	return &accountv1.TokenResponse{Code: accountv1.TokenResponse_CODE_SUCCESS, Token: c.tokens[in.AccountId]}, nil
+}
+
+func TestRemoteAuthenticator(t *testing.T) {
+	initSecurity(t)
+
+	timeout := time.Now().Add(time.Hour).Unix()
+	client := &fakeAccountClient{
+		sessions: map[string]string{"10001": "s1"},
+		tokens:   map[string]string{"10001": issueToken(t, &intrav1.AuthToken{AccountId: 10001, Timeout: timeout, Color: "blue"})},
+	}
+	a := newRemoteAuthenticator(&conf.Auth_Remote{CacheTtl: durationpb.New(time.Minute), CacheSize: 2}, client, log.DefaultLogger)
+
+	for i := 0; i < 2; i++ {
+		c, err := a.Authenticate(context.Background(), &Credential{AccountID: "10001", Session: "s1"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(10001), c.UID)
+		assert.Equal(t, "blue", c.Color)
+		assert.Equal(t, timeout, c.Timeout.Unix())
+	}
+	assert.Equal(t, int32(1), client.calls.Load(), "the second authentication is served by the cache")
+
+	_, err := a.Authenticate(context.Background(), &Credential{AccountID: "10001", Session: "s2"})
+	assert.Error(t, err, "session rejected")
+	assert.Equal(t, int32(2), client.calls.Load())
+
+	_, err = a.Authenticate(context.Background(), &Credential{Token: client.tokens["10001"]})
+	assert.Error(t, err, "the token alone is not accepted")
+	_, err = a.Authenticate(context.Background(), &Credential{AccountID: "10001"})
+	assert.Error(t, err, "session is empty")
+	assert.Equal(t, int32(2), client.calls.Load())
+}
+
+func TestRemoteAuthenticatorShared(t *testing.T) {
+	initSecurity(t)
+
+	client := &fakeAccountClient{
+		sessions: map[string]string{"10001": "s1"},
+		tokens:   map[string]string{"10001": issueToken(t, &intrav1.AuthToken{AccountId: 10001, Timeout: time.Now().Add(time.Hour).Unix()})},
+		block:    make(chan struct{}),
+	}
+	a := newRemoteAuthenticator(&conf.Auth_Remote{CacheTtl: durationpb.New(time.Minute), CacheSize: 2}, client, log.DefaultLogger)
+	cred := &Credential{AccountID: "10001", Session: "s1"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := a.Authenticate(ctx, cred)
+		first <- err
+	}()
+	assert.Eventually(t, func() bool { return client.calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	second := make(chan error, 1)
+	go func() {
+		_, err := a.Authenticate(context.Background(), cred)
+		second <- err
+	}()
+
+	// the first caller leaves, the shared call goes on for the second one
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, second, "the second caller waits for the shared call")
+	close(client.block)
+	assert.NoError(t, <-second)
+	assert.Equal(t, int32(1), client.calls.Load(), "the call is shared")
+}
+
+func TestClaimsCache(t *testing.T) {
+	now := time.Now()
+	c := newClaimsCache(2, time.Minute)
+
+	c.put("a", &Claims{UID: 1, Timeout: now.Add(time.Hour)}, now)
+	c.put("b", &Claims{UID: 2, Timeout: now.Add(time.Hour)}, now)
+	assert.NotNil(t, c.get("a", now))
+
+	// b is the least recently used one
+	c.put("c", &Claims{UID: 3, Timeout: now.Add(time.Hour)}, now)
+	assert.Equal(t, 2, c.ll.Len())
+	assert.Nil(t, c.get("b", now))
+	assert.Equal(t, int64(1), c.get("a", now).UID)
+	assert.Equal(t, int64(3), c.get("c", now).UID)
+
+	// expires at the ttl
+	assert.Nil(t, c.get("a", now.Add(time.Minute+time.Second)))
+	assert.Equal(t, 1, c.ll.Len())
+
+	// expires at the token timeout when it comes first
+	c.put("d", &Claims{UID: 4, Timeout: now.Add(time.Second)}, now)
+	assert.NotNil(t, c.get("d", now))
+	assert.Nil(t, c.get("d", now.Add(2*time.Second)))
+}
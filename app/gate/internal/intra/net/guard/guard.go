@@ -0,0 +1,378 @@
+package guard
+
+import (
+	"context"
+	"io"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+)
+
+var ProviderSet = wire.NewSet(NewGuard)
+
+var _ xnet.Guard = (*Guard)(nil)
+
+const (
+	defaultWindow       = time.Minute
+	defaultBanDuration  = time.Minute * 10
+	defaultSyncInterval = time.Second * 5
+)
+
+type ListType string
+
+const (
+	ListAllow ListType = "allow"
+	ListDeny  ListType = "deny"
+)
+
+// Guard bans the ips that fail the handshake too many times in the sliding window.
+// the allow list is never banned or denied, the deny list is always rejected.
+// the bans and the managed lists are shared by all gates through redis if it is enabled
+type Guard struct {
+	log *log.Helper
+
+	maxFailures  int
+	window       time.Duration
+	banDuration  time.Duration
+	syncInterval time.Duration
+	store        *store
+
+	confAllow []string
+	confDeny  []string
+
+	mu       sync.RWMutex
+	failures map[netip.Addr][]time.Time
+	bans     map[netip.Addr]time.Time
+	managed  map[ListType]*prefixList
+	allow    *prefixList
+	deny     *prefixList
+
+	stop chan struct{}
+}
+
+func NewGuard(c *conf.Guard, logger log.Logger, d *data.Data) (*Guard, func(), error) {
+	if c == nil {
+		c = &conf.Guard{}
+	}
+
+	g := &Guard{
+		log:          log.NewHelper(log.With(logger, "module", "gate/guard")),
+		maxFailures:  int(c.MaxFailures),
+		window:       defaultWindow,
+		banDuration:  defaultBanDuration,
+		syncInterval: defaultSyncInterval,
+		confAllow:    c.Allow,
+		confDeny:     c.Deny,
+		failures:     make(map[netip.Addr][]time.Time),
+		bans:         make(map[netip.Addr]time.Time),
+		managed:      map[ListType]*prefixList{ListAllow: {}, ListDeny: {}},
+		stop:         make(chan struct{}),
+	}
+	if c.Window != nil {
+		g.window = c.Window.AsDuration()
+	}
+	if c.BanDuration != nil {
+		g.banDuration = c.BanDuration.AsDuration()
+	}
+	if c.SyncInterval != nil {
+		g.syncInterval = c.SyncInterval.AsDuration()
+	}
+	if c.Shared {
+		g.store = newStore(d.Rdb)
+	}
+	if err := g.rebuildLists(); err != nil {
+		return nil, nil, err
+	}
+
+	g.sync(context.Background())
+	xsync.GoSafe("gate.guard.loop", func() error {
+		return g.loop()
+	})
+
+	cleanup := func() {
+		close(g.stop)
+	}
+	return g, cleanup, nil
+}
+
+func (g *Guard) Allow(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return true
+	}
+	addr = addr.Unmap()
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.allow.contains(addr) {
+		return true
+	}
+	if g.deny.contains(addr) {
+		return false
+	}
+	if expiry, ok := g.bans[addr]; ok && time.Now().Before(expiry) {
+		return false
+	}
+	return true
+}
+
+// OnHandshakeFailed counts the failure in the sliding window and bans the ip when it reaches the max failures.
+// the connection closed without any data is not counted, it costs the gate nothing, neither is the valid handshake rejected by the gate
+func (g *Guard) OnHandshakeFailed(ip string, err error) {
+	if g.maxFailures <= 0 || errors.Is(err, io.EOF) || errors.Is(err, xnet.ErrHandshakeRejected) {
+		return
+	}
+	addr, err0 := netip.ParseAddr(ip)
+	if err0 != nil {
+		return
+	}
+	addr = addr.Unmap()
+
+	now := time.Now()
+
+	g.mu.Lock()
+	if g.allow.contains(addr) {
+		g.mu.Unlock()
+		return
+	}
+	failures := append(prune(g.failures[addr], now.Add(-g.window)), now)
+	if len(failures) < g.maxFailures {
+		g.failures[addr] = failures
+		g.mu.Unlock()
+		return
+	}
+	delete(g.failures, addr)
+	g.mu.Unlock()
+
+	g.log.Infof("[guard.Guard] ip banned. ip=%s failures=%d window=%s duration=%s %+v", addr, len(failures), g.window, g.banDuration, err)
+	if err = g.Ban(context.Background(), addr.String(), g.banDuration); err != nil {
+		g.log.Errorf("[guard.Guard] ban failed. ip=%s %+v", addr, err)
+	}
+}
+
+func (g *Guard) Ban(ctx context.Context, ip string, d time.Duration) error {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return errors.Wrapf(err, "ip invalid. ip=%s", ip)
+	}
+	if d <= 0 {
+		d = g.banDuration
+	}
+	addr = addr.Unmap()
+	expiry := time.Now().Add(d)
+
+	g.mu.Lock()
+	g.bans[addr] = expiry
+	g.mu.Unlock()
+
+	if g.store != nil {
+		return g.store.ban(ctx, addr.String(), expiry)
+	}
+	return nil
+}
+
+func (g *Guard) Unban(ctx context.Context, ip string) error {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return errors.Wrapf(err, "ip invalid. ip=%s", ip)
+	}
+	addr = addr.Unmap()
+
+	g.mu.Lock()
+	delete(g.bans, addr)
+	delete(g.failures, addr)
+	g.mu.Unlock()
+
+	if g.store != nil {
+		return g.store.unban(ctx, addr.String())
+	}
+	return nil
+}
+
+// Bans returns the active bans with their expiry time
+func (g *Guard) Bans() map[string]time.Time {
+	now := time.Now()
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	ret := make(map[string]time.Time, len(g.bans))
+	for addr, expiry := range g.bans {
+		if now.Before(expiry) {
+			ret[addr.String()] = expiry
+		}
+	}
+	return ret
+}
+
+// AddToList adds the ip or CIDR to the managed list, the lists in the config can not be changed
+func (g *Guard) AddToList(ctx context.Context, tp ListType, cidr string) error {
+	p, err := parsePrefix(cidr)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	l, ok := g.managed[tp]
+	if ok {
+		g.managed[tp] = l.with(p)
+		err = g.rebuildLists()
+	}
+	g.mu.Unlock()
+
+	if !ok {
+		return errors.Errorf("list type invalid. type=%s", tp)
+	}
+	if err != nil {
+		return err
+	}
+	if g.store != nil {
+		return g.store.add(ctx, tp, p.String())
+	}
+	return nil
+}
+
+func (g *Guard) RemoveFromList(ctx context.Context, tp ListType, cidr string) error {
+	p, err := parsePrefix(cidr)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	l, ok := g.managed[tp]
+	if ok {
+		g.managed[tp] = l.without(p)
+		err = g.rebuildLists()
+	}
+	g.mu.Unlock()
+
+	if !ok {
+		return errors.Errorf("list type invalid. type=%s", tp)
+	}
+	if err != nil {
+		return err
+	}
+	if g.store != nil {
+		return g.store.remove(ctx, tp, p.String())
+	}
+	return nil
+}
+
+// List returns the effective list, including the config entries
+func (g *Guard) List(tp ListType) []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	switch tp {
+	case ListAllow:
+		return g.allow.list()
+	case ListDeny:
+		return g.deny.list()
+	default:
+		return nil
+	}
+}
+
+// rebuildLists merges the config lists and the managed lists, it must be called with the lock held
+func (g *Guard) rebuildLists() (err error) {
+	var allow, deny *prefixList
+	if allow, err = newPrefixList(g.confAllow, g.managed[ListAllow].list()); err != nil {
+		return err
+	}
+	if deny, err = newPrefixList(g.confDeny, g.managed[ListDeny].list()); err != nil {
+		return err
+	}
+	g.allow, g.deny = allow, deny
+	return nil
+}
+
+func (g *Guard) loop() error {
+	ticker := time.NewTicker(g.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.stop:
+			return nil
+		case <-ticker.C:
+			g.sweep(time.Now())
+			g.sync(context.Background())
+		}
+	}
+}
+
+// sweep removes the expired bans and the failures out of the window
+func (g *Guard) sweep(now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for addr, expiry := range g.bans {
+		if !now.Before(expiry) {
+			delete(g.bans, addr)
+		}
+	}
+	for addr, failures := range g.failures {
+		if failures = prune(failures, now.Add(-g.window)); len(failures) == 0 {
+			delete(g.failures, addr)
+		} else {
+			g.failures[addr] = failures
+		}
+	}
+}
+
+// sync replaces the local bans and managed lists with the shared ones
+func (g *Guard) sync(ctx context.Context) {
+	if g.store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, g.syncInterval)
+	defer cancel()
+
+	bans, err := g.store.bans(ctx, time.Now())
+	if err != nil {
+		g.log.Errorf("[guard.Guard] bans sync failed. %+v", err)
+		return
+	}
+
+	managed := make(map[ListType]*prefixList, 2)
+	for _, tp := range []ListType{ListAllow, ListDeny} {
+		members, err := g.store.members(ctx, tp)
+		if err != nil {
+			g.log.Errorf("[guard.Guard] list sync failed. type=%s %+v", tp, err)
+			return
+		}
+		if managed[tp], err = newPrefixList(members); err != nil {
+			g.log.Errorf("[guard.Guard] list sync failed. type=%s %+v", tp, err)
+			return
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.bans = bans
+	g.managed = managed
+	if err = g.rebuildLists(); err != nil {
+		g.log.Errorf("[guard.Guard] list rebuild failed. %+v", err)
+	}
+}
+
+func prune(failures []time.Time, since time.Time) []time.Time {
+	i := 0
+	for ; i < len(failures); i++ {
+		if failures[i].After(since) {
+			break
+		}
+	}
+	return failures[i:]
+}
@@ -0,0 +1,46 @@
+package guard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+)
+
+func TestGuard(t *testing.T) {
+	g, cleanup, err := NewGuard(&conf.Guard{
+		MaxFailures: 3,
+		Allow:       []string{"10.0.0.0/8"},
+		Deny:        []string{"192.168.1.1"},
+	}, log.DefaultLogger, &data.Data{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	failed := errors.New("token expired")
+	for i := 0; i < 2; i++ {
+		g.OnHandshakeFailed("1.1.1.1", failed)
+	}
+	assert.True(t, g.Allow("1.1.1.1"))
+	g.OnHandshakeFailed("1.1.1.1", failed)
+	assert.False(t, g.Allow("1.1.1.1"))
+	assert.Contains(t, g.Bans(), "1.1.1.1")
+
+	assert.Nil(t, g.Unban(context.Background(), "1.1.1.1"))
+	assert.True(t, g.Allow("1.1.1.1"))
+
+	for i := 0; i < 3; i++ {
+		g.OnHandshakeFailed("10.1.1.1", failed)
+	}
+	assert.True(t, g.Allow("10.1.1.1"))
+	assert.False(t, g.Allow("192.168.1.1"))
+
+	assert.Nil(t, g.AddToList(context.Background(), ListDeny, "172.16.0.0/12"))
+	assert.False(t, g.Allow("172.16.3.4"))
+	assert.Nil(t, g.RemoveFromList(context.Background(), ListDeny, "172.16.0.0/12"))
+	assert.True(t, g.Allow("172.16.3.4"))
+	assert.NotNil(t, g.AddToList(context.Background(), ListType("other"), "172.16.0.0/12"))
+}
@@ -0,0 +1,82 @@
+package guard
+
+import (
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// prefixList is an immutable list of CIDR, a single ip is stored as a full-length prefix
+type prefixList struct {
+	prefixes map[string]netip.Prefix
+}
+
+func newPrefixList(cidrs ...[]string) (*prefixList, error) {
+	l := &prefixList{prefixes: make(map[string]netip.Prefix)}
+	for _, list := range cidrs {
+		for _, c := range list {
+			p, err := parsePrefix(c)
+			if err != nil {
+				return nil, err
+			}
+			l.prefixes[p.String()] = p
+		}
+	}
+	return l, nil
+}
+
+func parsePrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, errors.Wrapf(err, "cidr invalid. cidr=%s", s)
+		}
+		return p.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, errors.Wrapf(err, "ip invalid. ip=%s", s)
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func (l *prefixList) contains(addr netip.Addr) bool {
+	for _, p := range l.prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *prefixList) with(p netip.Prefix) *prefixList {
+	n := &prefixList{prefixes: make(map[string]netip.Prefix, len(l.prefixes)+1)}
+	for k, v := range l.prefixes {
+		n.prefixes[k] = v
+	}
+	n.prefixes[p.String()] = p
+	return n
+}
+
+func (l *prefixList) without(p netip.Prefix) *prefixList {
+	n := &prefixList{prefixes: make(map[string]netip.Prefix, len(l.prefixes))}
+	for k, v := range l.prefixes {
+		if k != p.String() {
+			n.prefixes[k] = v
+		}
+	}
+	return n
+}
+
+func (l *prefixList) list() []string {
+	ret := make([]string, 0, len(l.prefixes))
+	for k := range l.prefixes {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
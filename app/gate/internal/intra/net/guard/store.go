@@ -0,0 +1,87 @@
+package guard
+
+import (
+	"context"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const bansKey = "gate:guard:bans"
+
+// store shares the bans and the managed lists through redis.
+// the bans are a sorted set scored by the expiry time in milliseconds, the lists are sets
+type store struct {
+	rdb redis.Cmdable
+}
+
+func newStore(rdb redis.Cmdable) *store {
+	return &store{rdb: rdb}
+}
+
+func listKey(tp ListType) string {
+	return "gate:guard:" + string(tp)
+}
+
+func (s *store) ban(ctx context.Context, ip string, expiry time.Time) error {
+	if err := s.rdb.ZAdd(ctx, bansKey, redis.Z{Score: float64(expiry.UnixMilli()), Member: ip}).Err(); err != nil {
+		return errors.Wrapf(err, "redis ZAdd failed. ip=%s", ip)
+	}
+	return nil
+}
+
+func (s *store) unban(ctx context.Context, ip string) error {
+	if err := s.rdb.ZRem(ctx, bansKey, ip).Err(); err != nil {
+		return errors.Wrapf(err, "redis ZRem failed. ip=%s", ip)
+	}
+	return nil
+}
+
+// bans removes the expired bans and returns the active ones
+func (s *store) bans(ctx context.Context, now time.Time) (map[netip.Addr]time.Time, error) {
+	min := strconv.FormatInt(now.UnixMilli(), 10)
+	if err := s.rdb.ZRemRangeByScore(ctx, bansKey, "-inf", "("+min).Err(); err != nil {
+		return nil, errors.Wrap(err, "redis ZRemRangeByScore failed")
+	}
+
+	zs, err := s.rdb.ZRangeByScoreWithScores(ctx, bansKey, &redis.ZRangeBy{Min: min, Max: "+inf"}).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "redis ZRangeByScoreWithScores failed")
+	}
+
+	bans := make(map[netip.Addr]time.Time, len(zs))
+	for _, z := range zs {
+		ip, _ := z.Member.(string)
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			continue
+		}
+		bans[addr] = time.UnixMilli(int64(z.Score))
+	}
+	return bans, nil
+}
+
+func (s *store) add(ctx context.Context, tp ListType, cidr string) error {
+	if err := s.rdb.SAdd(ctx, listKey(tp), cidr).Err(); err != nil {
+		return errors.Wrapf(err, "redis SAdd failed. type=%s cidr=%s", tp, cidr)
+	}
+	return nil
+}
+
+func (s *store) remove(ctx context.Context, tp ListType, cidr string) error {
+	if err := s.rdb.SRem(ctx, listKey(tp), cidr).Err(); err != nil {
+		return errors.Wrapf(err, "redis SRem failed. type=%s cidr=%s", tp, cidr)
+	}
+	return nil
+}
+
+func (s *store) members(ctx context.Context, tp ListType) ([]string, error) {
+	members, err := s.rdb.SMembers(ctx, listKey(tp)).Result()
+	if err != nil {
+		return nil, errors.Wrapf(err, "redis SMembers failed. type=%s", tp)
+	}
+	return members, nil
+}
@@ -0,0 +1,174 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
+	cliseq "github.com/vulcan-frame/vulcan-gate/gen/api/client/sequence"
+	pushv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	tcp "github.com/vulcan-frame/vulcan-gate/pkg/net/tcp/server"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ transport.Server = (*Kicker)(nil)
+
+const tickInterval = time.Second
+
+var kickedCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "vulcan",
+	Subsystem: "gate",
+	Name:      "maintenance_kicked_total",
+	Help:      "sessions on this gate logged out by the maintenance",
+})
+
+// countdown is the kick of a maintenance, the maintenance begun again with another kick time is announced again
+type countdown struct {
+	sid    int64
+	kickAt int64
+}
+
+// sessions is implemented by the tcp server
+type sessions interface {
+	BroadcastWith(ctx context.Context, filter xnet.SessionFilter, pack func(ss xnet.Session) ([]byte, error), opts xnet.PushOptions) (matched, delivered int, err error)
+	Logout(ctx context.Context, filter xnet.SessionFilter, code xnet.LogoutCode) int
+}
+
+// Kicker logs out the connected sessions not allowed during the maintenance at its kick time.
+// the sessions are told with the maintenance notice once the kick time is set, the client shows the countdown to the kick time
+type Kicker struct {
+	log    *log.Helper
+	m      *Maintenance
+	server sessions
+
+	// owned by the loop
+	announced map[countdown]struct{}
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewKicker(logger log.Logger, m *Maintenance, ts *tcp.Server) *Kicker {
+	return &Kicker{
+		log:       log.NewHelper(log.With(logger, "module", "gate/maintenance/kicker")),
+		m:         m,
+		server:    ts,
+		announced: make(map[countdown]struct{}),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+func (k *Kicker) Start(ctx context.Context) error {
+	xsync.GoSafe("gate.maintenance.kicker", func() error {
+		return k.loop()
+	})
+	return nil
+}
+
+func (k *Kicker) Stop(ctx context.Context) error {
+	close(k.stop)
+	select {
+	case <-k.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (k *Kicker) loop() error {
+	defer close(k.done)
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-k.stop:
+			return nil
+		case <-ticker.C:
+			k.tick(context.Background())
+		}
+	}
+}
+
+func (k *Kicker) tick(ctx context.Context) {
+	now := time.Now().Unix()
+	active := make(map[countdown]struct{})
+	kick := false
+
+	for _, st := range k.m.States() {
+		if st.KickAt <= 0 {
+			continue
+		}
+		c := countdown{sid: st.SID, kickAt: st.KickAt}
+		active[c] = struct{}{}
+
+		if now >= st.KickAt {
+			kick = true
+			continue
+		}
+		if _, ok := k.announced[c]; !ok {
+			k.announce(ctx, st)
+			k.announced[c] = struct{}{}
+		}
+	}
+
+	// the ended maintenances are forgotten
+	for c := range k.announced {
+		if _, ok := active[c]; !ok {
+			delete(k.announced, c)
+		}
+	}
+
+	if !kick {
+		return
+	}
+	n := k.server.Logout(ctx, xnet.SessionFilterFunc(func(ss xnet.Session) bool {
+		return k.kicked(ss, now)
+	}), xnet.LogoutCodeWaiting)
+	if n > 0 {
+		kickedCounter.Add(float64(n))
+		k.log.Infof("[maintenance.Kicker] sessions kicked. count=%d", n)
+	}
+}
+
+// kicked reports whether the session is under the maintenance past its kick time and not allowed to stay
+func (k *Kicker) kicked(ss xnet.Session, now int64) bool {
+	st, ok := k.m.Active(ss.SID())
+	if !ok || st.KickAt <= 0 || now < st.KickAt {
+		return false
+	}
+	return !k.m.Allowed(ss.UID(), ss.ClientIP(), ss.Status())
+}
+
+// announce pushes the maintenance notice ending at the kick time to the sessions which will be kicked
+func (k *Kicker) announce(ctx context.Context, st State) {
+	data, err := proto.Marshal(&climsg.SCNotice{Kind: climsg.SCNotice_Maintenance, Content: st.Message, EndTime: st.KickAt})
+	if err != nil {
+		k.log.Errorf("[maintenance.Kicker] SCNotice encode failed. sid=%d %+v", st.SID, err)
+		return
+	}
+	body := &pushv1.PushBody{Mod: int32(climod.ModuleID_System), Seq: int32(cliseq.SystemSeq_Notice), Data: data}
+
+	filter := xnet.SessionFilterFunc(func(ss xnet.Session) bool {
+		active, ok := k.m.Active(ss.SID())
+		return ok && active == st && !k.m.Allowed(ss.UID(), ss.ClientIP(), ss.Status())
+	})
+	opts := xnet.PushOptions{Priority: xnet.PriorityHigh, ExpireAt: time.Unix(st.KickAt, 0)}
+	matched, delivered, err := k.server.BroadcastWith(ctx, filter, func(ss xnet.Session) ([]byte, error) {
+		return tunnels.Pack(body)
+	}, opts)
+	if err != nil {
+		k.log.Errorf("[maintenance.Kicker] countdown push failed. sid=%d kick-at=%d %+v", st.SID, st.KickAt, err)
+	}
+	k.log.Infof("[maintenance.Kicker] countdown pushed. sid=%d kick-at=%d matched=%d delivered=%d", st.SID, st.KickAt, matched, delivered)
+}
@@ -0,0 +1,126 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/intra/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"google.golang.org/protobuf/proto"
+)
+
+type logout struct {
+	uids []int64
+	code xnet.LogoutCode
+}
+
+type fakeSessions struct {
+	sessions  []xnet.Session
+	announced [][]int64
+	notices   []*climsg.SCNotice
+	logouts   []logout
+}
+
+func (f *fakeSessions) match(filter xnet.SessionFilter) (matched []xnet.Session) {
+	for _, ss := range f.sessions {
+		if filter.Match(ss) {
+			matched = append(matched, ss)
+		}
+	}
+	return
+}
+
+func uids(sessions []xnet.Session) []int64 {
+	out := make([]int64, 0, len(sessions))
+	for _, ss := range sessions {
+		out = append(out, ss.UID())
+	}
+	return out
+}
+
+func (f *fakeSessions) BroadcastWith(ctx context.Context, filter xnet.SessionFilter, pack func(ss xnet.Session) ([]byte, error), opts xnet.PushOptions) (int, int, error) {
+	matched := f.match(filter)
+	for _, ss := range matched {
+		out, err := pack(ss)
+		if err != nil {
+			return len(matched), 0, err
+		}
+		p := &clipkt.Packet{}
+		if err = proto.Unmarshal(out, p); err != nil {
+			return len(matched), 0, err
+		}
+		sc := &climsg.SCNotice{}
+		if err = proto.Unmarshal(p.Data, sc); err != nil {
+			return len(matched), 0, err
+		}
+		f.notices = append(f.notices, sc)
+	}
+	f.announced = append(f.announced, uids(matched))
+	return len(matched), len(matched), nil
+}
+
+func (f *fakeSessions) Logout(ctx context.Context, filter xnet.SessionFilter, code xnet.LogoutCode) int {
+	matched := f.match(filter)
+	f.logouts = append(f.logouts, logout{uids: uids(matched), code: code})
+	return len(matched)
+}
+
+func TestKicker(t *testing.T) {
+	m, cleanup, err := NewMaintenance(&conf.Maintenance{WhitelistUids: []int64{100}}, log.DefaultLogger, &data.Data{})
+	require.NoError(t, err)
+	defer cleanup()
+
+	gate := int64(intrav1.OnlineStatus_ONLINE_STATUS_GATE)
+	now := time.Now().Unix()
+	f := &fakeSessions{sessions: []xnet.Session{
+		xnet.NewSession(1, 7, now, nil, nil, false, "", gate),
+		xnet.NewSession(2, 8, now, nil, nil, false, "", gate),
+		xnet.NewSession(100, 7, now, nil, nil, false, "", gate),
+		xnet.NewSession(3, 7, now, nil, nil, false, "", int64(intrav1.OnlineStatus_ONLINE_STATUS_ADMIN)),
+	}}
+	k := NewKicker(log.DefaultLogger, m, nil)
+	k.server = f
+	ctx := context.Background()
+
+	// the maintenance without the kick time keeps the sessions
+	require.NoError(t, m.Begin(ctx, State{SID: 7, Message: "upgrade"}))
+	k.tick(ctx)
+	assert.Empty(t, f.announced)
+
+	// the sessions to be kicked are told once per kick time
+	kickAt := now + 60
+	require.NoError(t, m.Begin(ctx, State{SID: 7, Message: "upgrade", KickAt: kickAt}))
+	k.tick(ctx)
+	k.tick(ctx)
+	require.Equal(t, [][]int64{{1}}, f.announced)
+	assert.Equal(t, climsg.SCNotice_Maintenance, f.notices[0].Kind)
+	assert.Equal(t, "upgrade", f.notices[0].Content)
+	assert.Equal(t, kickAt, f.notices[0].EndTime)
+	assert.Empty(t, f.logouts)
+
+	require.NoError(t, m.Begin(ctx, State{SID: 7, Message: "upgrade", KickAt: kickAt + 60}))
+	k.tick(ctx)
+	assert.Len(t, f.announced, 2, "the new kick time is announced again")
+
+	// the sessions not allowed are kicked with the Waiting logout at the kick time
+	require.NoError(t, m.Begin(ctx, State{SID: 7, Message: "upgrade", KickAt: now - 1}))
+	k.tick(ctx)
+	assert.Len(t, f.announced, 2)
+	require.Len(t, f.logouts, 1)
+	assert.Equal(t, []int64{1}, f.logouts[0].uids)
+	assert.Equal(t, xnet.LogoutCodeWaiting, f.logouts[0].code)
+
+	// the ended maintenance is forgotten
+	require.NoError(t, m.End(ctx, 7))
+	k.tick(ctx)
+	assert.Empty(t, k.announced)
+	assert.Len(t, f.logouts, 1)
+}
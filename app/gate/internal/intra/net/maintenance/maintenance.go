@@ -0,0 +1,376 @@
+package maintenance
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/intra/v1"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+)
+
+var ProviderSet = wire.NewSet(NewMaintenance, NewKicker)
+
+const (
+	defaultSyncInterval = time.Second * 5
+
+	statesKey = "gate:maintenance"
+	uidsKey   = "gate:maintenance:uids"
+	ipsKey    = "gate:maintenance:ips"
+)
+
+// GlobalSID is the SID of the maintenance which closes all servers
+const GlobalSID = 0
+
+// State is the maintenance of a server ID
+type State struct {
+	SID     int64  `json:"sid"`
+	Message string `json:"message"`           // shown to the rejected and the kicked users
+	ETA     int64  `json:"eta,omitempty"`     // unix seconds when the servers are expected back, 0 if unknown
+	KickAt  int64  `json:"kick_at,omitempty"` // unix seconds when the connected users not on the whitelist are kicked, 0 keeps them
+}
+
+// Maintenance keeps the maintenance switches and the whitelist. they are shared by all gates through redis if it is enabled,
+// the admin API of any gate writes them and every gate reloads them in the sync interval
+type Maintenance struct {
+	log          *log.Helper
+	rdb          redis.Cmdable
+	syncInterval time.Duration
+
+	confUIDs map[int64]struct{}
+	confIPs  []netip.Prefix
+
+	mu     sync.RWMutex
+	states map[int64]State
+	uids   map[int64]struct{} // managed by the admin API
+	ips    []netip.Prefix     // managed by the admin API
+
+	stop chan struct{}
+}
+
+func NewMaintenance(c *conf.Maintenance, logger log.Logger, d *data.Data) (*Maintenance, func(), error) {
+	if c == nil {
+		c = &conf.Maintenance{}
+	}
+
+	m := &Maintenance{
+		log:          log.NewHelper(log.With(logger, "module", "gate/maintenance")),
+		rdb:          d.Rdb,
+		syncInterval: defaultSyncInterval,
+		confUIDs:     make(map[int64]struct{}, len(c.WhitelistUids)),
+		states:       make(map[int64]State),
+		uids:         make(map[int64]struct{}),
+		stop:         make(chan struct{}),
+	}
+	if c.SyncInterval != nil && c.SyncInterval.AsDuration() > 0 {
+		m.syncInterval = c.SyncInterval.AsDuration()
+	}
+	for _, uid := range c.WhitelistUids {
+		m.confUIDs[uid] = struct{}{}
+	}
+	for _, cidr := range c.WhitelistIps {
+		p, err := parsePrefix(cidr)
+		if err != nil {
+			return nil, nil, err
+		}
+		m.confIPs = append(m.confIPs, p)
+	}
+
+	if m.rdb != nil {
+		m.sync(context.Background())
+		xsync.GoSafe("gate.maintenance.loop", func() error {
+			return m.loop()
+		})
+	}
+
+	cleanup := func() {
+		close(m.stop)
+	}
+	return m, cleanup, nil
+}
+
+// Active returns the maintenance of the sid, the global one applies to every sid
+func (m *Maintenance) Active(sid int64) (State, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if st, ok := m.states[sid]; ok {
+		return st, true
+	}
+	st, ok := m.states[GlobalSID]
+	return st, ok
+}
+
+// States returns the maintenances in the order of the sid
+func (m *Maintenance) States() []State {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ret := make([]State, 0, len(m.states))
+	for _, st := range m.states {
+		ret = append(ret, st)
+	}
+	slices.SortFunc(ret, func(a, b State) int { return cmp.Compare(a.SID, b.SID) })
+	return ret
+}
+
+// Allowed reports whether the user can log in during the maintenance, the ip may have the port
+func (m *Maintenance) Allowed(uid int64, ip string, status int64) bool {
+	if status == int64(intrav1.OnlineStatus_ONLINE_STATUS_ADMIN) || status == int64(intrav1.OnlineStatus_ONLINE_STATUS_DEV) {
+		return true
+	}
+	if _, ok := m.confUIDs[uid]; ok {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		ap, err := netip.ParseAddrPort(ip)
+		if err != nil {
+			addr = netip.Addr{}
+		} else {
+			addr = ap.Addr()
+		}
+	}
+	addr = addr.Unmap()
+	if contains(m.confIPs, addr) {
+		return true
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.uids[uid]; ok {
+		return true
+	}
+	return contains(m.ips, addr)
+}
+
+func contains(prefixes []netip.Prefix, addr netip.Addr) bool {
+	if !addr.IsValid() {
+		return false
+	}
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Begin turns on the maintenance of the sid, the one already on is replaced
+func (m *Maintenance) Begin(ctx context.Context, st State) error {
+	if st.SID < 0 {
+		return errors.Errorf("sid invalid. sid=%d", st.SID)
+	}
+	if m.rdb != nil {
+		data, err := json.Marshal(st)
+		if err != nil {
+			return errors.Wrapf(err, "maintenance encode failed. sid=%d", st.SID)
+		}
+		if err = m.rdb.HSet(ctx, statesKey, strconv.FormatInt(st.SID, 10), data).Err(); err != nil {
+			return errors.Wrapf(err, "redis HSet failed. sid=%d", st.SID)
+		}
+	}
+
+	m.mu.Lock()
+	m.states[st.SID] = st
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Maintenance) End(ctx context.Context, sid int64) error {
+	if m.rdb != nil {
+		if err := m.rdb.HDel(ctx, statesKey, strconv.FormatInt(sid, 10)).Err(); err != nil {
+			return errors.Wrapf(err, "redis HDel failed. sid=%d", sid)
+		}
+	}
+
+	m.mu.Lock()
+	delete(m.states, sid)
+	m.mu.Unlock()
+	return nil
+}
+
+// Whitelist returns the effective whitelist, including the config entries
+func (m *Maintenance) Whitelist() (uids []int64, ips []string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, set := range []map[int64]struct{}{m.confUIDs, m.uids} {
+		for uid := range set {
+			if !slices.Contains(uids, uid) {
+				uids = append(uids, uid)
+			}
+		}
+	}
+	slices.Sort(uids)
+	for _, list := range [][]netip.Prefix{m.confIPs, m.ips} {
+		for _, p := range list {
+			if !slices.Contains(ips, p.String()) {
+				ips = append(ips, p.String())
+			}
+		}
+	}
+	slices.Sort(ips)
+	return
+}
+
+func (m *Maintenance) AddUID(ctx context.Context, uid int64) error {
+	if m.rdb != nil {
+		if err := m.rdb.SAdd(ctx, uidsKey, uid).Err(); err != nil {
+			return errors.Wrapf(err, "redis SAdd failed. uid=%d", uid)
+		}
+	}
+
+	m.mu.Lock()
+	m.uids[uid] = struct{}{}
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Maintenance) RemoveUID(ctx context.Context, uid int64) error {
+	if m.rdb != nil {
+		if err := m.rdb.SRem(ctx, uidsKey, uid).Err(); err != nil {
+			return errors.Wrapf(err, "redis SRem failed. uid=%d", uid)
+		}
+	}
+
+	m.mu.Lock()
+	delete(m.uids, uid)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Maintenance) AddIP(ctx context.Context, cidr string) error {
+	p, err := parsePrefix(cidr)
+	if err != nil {
+		return err
+	}
+	if m.rdb != nil {
+		if err = m.rdb.SAdd(ctx, ipsKey, p.String()).Err(); err != nil {
+			return errors.Wrapf(err, "redis SAdd failed. cidr=%s", cidr)
+		}
+	}
+
+	m.mu.Lock()
+	if !slices.Contains(m.ips, p) {
+		m.ips = append(m.ips, p)
+	}
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Maintenance) RemoveIP(ctx context.Context, cidr string) error {
+	p, err := parsePrefix(cidr)
+	if err != nil {
+		return err
+	}
+	if m.rdb != nil {
+		if err = m.rdb.SRem(ctx, ipsKey, p.String()).Err(); err != nil {
+			return errors.Wrapf(err, "redis SRem failed. cidr=%s", cidr)
+		}
+	}
+
+	m.mu.Lock()
+	m.ips = slices.DeleteFunc(m.ips, func(x netip.Prefix) bool { return x == p })
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Maintenance) loop() error {
+	ticker := time.NewTicker(m.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return nil
+		case <-ticker.C:
+			m.sync(context.Background())
+		}
+	}
+}
+
+// sync replaces the local switches and the managed whitelist with the shared ones, the local ones are kept if redis fails
+func (m *Maintenance) sync(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, m.syncInterval)
+	defer cancel()
+
+	values, err := m.rdb.HGetAll(ctx, statesKey).Result()
+	if err != nil {
+		m.log.Errorf("[maintenance.Maintenance] sync failed. %+v", errors.Wrap(err, "redis HGetAll failed"))
+		return
+	}
+	uidMembers, err := m.rdb.SMembers(ctx, uidsKey).Result()
+	if err != nil {
+		m.log.Errorf("[maintenance.Maintenance] sync failed. %+v", errors.Wrap(err, "redis SMembers failed"))
+		return
+	}
+	ipMembers, err := m.rdb.SMembers(ctx, ipsKey).Result()
+	if err != nil {
+		m.log.Errorf("[maintenance.Maintenance] sync failed. %+v", errors.Wrap(err, "redis SMembers failed"))
+		return
+	}
+
+	states := make(map[int64]State, len(values))
+	for field, v := range values {
+		var st State
+		if err = json.Unmarshal([]byte(v), &st); err != nil {
+			m.log.Errorf("[maintenance.Maintenance] maintenance decode failed. sid=%s %+v", field, err)
+			continue
+		}
+		states[st.SID] = st
+	}
+	uids := make(map[int64]struct{}, len(uidMembers))
+	for _, s := range uidMembers {
+		uid, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			continue
+		}
+		uids[uid] = struct{}{}
+	}
+	ips := make([]netip.Prefix, 0, len(ipMembers))
+	for _, s := range ipMembers {
+		p, err := parsePrefix(s)
+		if err != nil {
+			continue
+		}
+		ips = append(ips, p)
+	}
+
+	m.mu.Lock()
+	m.states, m.uids, m.ips = states, uids, ips
+	m.mu.Unlock()
+}
+
+// parsePrefix parses the ip or CIDR, a single ip is a full-length prefix
+func parsePrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, errors.Wrapf(err, "cidr invalid. cidr=%s", s)
+		}
+		return p.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, errors.Wrapf(err, "ip invalid. ip=%s", s)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
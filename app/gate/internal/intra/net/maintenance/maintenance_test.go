@@ -0,0 +1,67 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/intra/v1"
+)
+
+func TestMaintenance(t *testing.T) {
+	m, cleanup, err := NewMaintenance(&conf.Maintenance{
+		WhitelistUids: []int64{100},
+		WhitelistIps:  []string{"10.0.0.0/8"},
+	}, log.DefaultLogger, &data.Data{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, ok := m.Active(7)
+	assert.False(t, ok)
+
+	assert.Nil(t, m.Begin(ctx, State{SID: 7, Message: "sid 7", ETA: 1000}))
+	st, ok := m.Active(7)
+	assert.True(t, ok)
+	assert.Equal(t, "sid 7", st.Message)
+	_, ok = m.Active(8)
+	assert.False(t, ok)
+
+	assert.Nil(t, m.Begin(ctx, State{SID: GlobalSID, Message: "all"}))
+	st, _ = m.Active(8)
+	assert.Equal(t, "all", st.Message)
+	st, _ = m.Active(7)
+	assert.Equal(t, "sid 7", st.Message)
+	assert.Len(t, m.States(), 2)
+
+	assert.Nil(t, m.End(ctx, 7))
+	st, _ = m.Active(7)
+	assert.Equal(t, "all", st.Message)
+	assert.NotNil(t, m.Begin(ctx, State{SID: -1}))
+
+	gate := int64(intrav1.OnlineStatus_ONLINE_STATUS_GATE)
+	assert.True(t, m.Allowed(100, "1.1.1.1", gate))
+	assert.True(t, m.Allowed(1, "10.1.2.3", gate))
+	assert.True(t, m.Allowed(1, "10.1.2.3:5000", gate))
+	assert.True(t, m.Allowed(1, "1.1.1.1", int64(intrav1.OnlineStatus_ONLINE_STATUS_ADMIN)))
+	assert.True(t, m.Allowed(1, "1.1.1.1", int64(intrav1.OnlineStatus_ONLINE_STATUS_DEV)))
+	assert.False(t, m.Allowed(1, "1.1.1.1", gate))
+	assert.False(t, m.Allowed(1, "", gate))
+
+	assert.Nil(t, m.AddUID(ctx, 1))
+	assert.Nil(t, m.AddIP(ctx, "192.168.1.1"))
+	assert.True(t, m.Allowed(1, "1.1.1.1", gate))
+	assert.True(t, m.Allowed(2, "192.168.1.1", gate))
+	uids, ips := m.Whitelist()
+	assert.Equal(t, []int64{1, 100}, uids)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.1/32"}, ips)
+
+	assert.Nil(t, m.RemoveUID(ctx, 1))
+	assert.Nil(t, m.RemoveIP(ctx, "192.168.1.1"))
+	assert.False(t, m.Allowed(1, "1.1.1.1", gate))
+	assert.False(t, m.Allowed(2, "192.168.1.1", gate))
+	assert.NotNil(t, m.AddIP(ctx, "bad"))
+}
@@ -0,0 +1,350 @@
+package notice
+
+import (
+	"context"
+	"hash/fnv"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/google/wire"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
+	cliseq "github.com/vulcan-frame/vulcan-gate/gen/api/client/sequence"
+	accountv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/account/interface/v1"
+	pushv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	tcp "github.com/vulcan-frame/vulcan-gate/pkg/net/tcp/server"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	"google.golang.org/protobuf/proto"
+)
+
+var ProviderSet = wire.NewSet(NewScheduler)
+
+var _ transport.Server = (*Scheduler)(nil)
+
+const (
+	defaultPollInterval = time.Second * 30
+	tickInterval        = time.Second
+	requestTimeout      = time.Second * 5
+	// the state is refreshed on every push, so it outlives the notices still listed
+	stateTTL = time.Hour * 24 * 30
+	// seenKey keeps the time every notice is first listed, it is shared by all gates so they push the rounds at the same time
+	seenKey = "gate:notice:seen"
+)
+
+var pushedCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "vulcan",
+	Subsystem: "gate",
+	Name:      "notice_pushed_total",
+	Help:      "notices pushed to the sessions on this gate, one per session",
+})
+
+func stateKey(node string) string {
+	return "gate:notice:" + node
+}
+
+// noticeID identifies the notice by its title and content, the list carries no ID. the edited notice is a new one
+func noticeID(n *accountv1.Notice) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(n.Title))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(n.Content))
+	return int64(h.Sum64() >> 1)
+}
+
+// due returns the round of the notice first listed at start, the notice without the repeat interval has the only round 0
+func due(start, now int64, interval time.Duration) int64 {
+	if sec := int64(interval.Seconds()); sec > 0 && now > start {
+		return (now - start) / sec
+	}
+	return 0
+}
+
+type entry struct {
+	notice *accountv1.Notice
+	start  int64 // the unix seconds when the notice is first listed
+}
+
+// broadcaster is implemented by the tcp server
+type broadcaster interface {
+	BroadcastWith(ctx context.Context, filter xnet.SessionFilter, pack func(ss xnet.Session) ([]byte, error), opts xnet.PushOptions) (matched, delivered int, err error)
+}
+
+// Scheduler pushes the notices listed by NoticeInterface.NoticeList of the account service to the sessions on this gate as the marquee.
+// a notice is pushed once it is listed and then at every repeat interval until it is taken off the list. every gate pushes to its own sessions,
+// so the round pushed last is kept in redis by the node of this gate. a restarted gate does not push the round again,
+// and it pushes the current round only instead of the ones missed while it was down.
+// the maintenance announcements are pushed by the maintenance kicker
+type Scheduler struct {
+	log    *log.Helper
+	client accountv1.NoticeInterfaceClient
+	server broadcaster
+	rdb    redis.Cmdable
+	key    string
+
+	disabled       bool
+	pollInterval   time.Duration
+	repeatInterval time.Duration
+	filter         xnet.SessionFilter
+
+	// owned by the loop
+	notices map[int64]*entry
+	pushed  map[int64]int64 // the rounds pushed by this gate
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewScheduler(c *conf.Notices, label *conf.Label, logger log.Logger, d *data.Data, ts *tcp.Server, client accountv1.NoticeInterfaceClient) (*Scheduler, error) {
+	return newScheduler(c, label, logger, d, ts, client)
+}
+
+func newScheduler(c *conf.Notices, label *conf.Label, logger log.Logger, d *data.Data, b broadcaster, client accountv1.NoticeInterfaceClient) (*Scheduler, error) {
+	if c == nil {
+		c = &conf.Notices{}
+	}
+
+	node := label.Node
+	if node == "" {
+		// the hostname is kept across the restarts by the stateful deployments
+		h, err := os.Hostname()
+		if err != nil {
+			return nil, errors.Wrap(err, "hostname failed, the label node is required by the notice state")
+		}
+		node = h
+	}
+
+	filter, err := tunnels.ParseFilter(c.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scheduler{
+		log:          log.NewHelper(log.With(logger, "module", "gate/notice")),
+		client:       client,
+		server:       b,
+		rdb:          d.Rdb,
+		key:          stateKey(node),
+		disabled:     c.Disabled || (len(c.Zones) > 0 && !slices.Contains(c.Zones, label.Zone)),
+		pollInterval: defaultPollInterval,
+		filter:       filter,
+		notices:      make(map[int64]*entry),
+		pushed:       make(map[int64]int64),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	if c.PollInterval != nil && c.PollInterval.AsDuration() > 0 {
+		s.pollInterval = c.PollInterval.AsDuration()
+	}
+	if c.RepeatInterval != nil {
+		s.repeatInterval = c.RepeatInterval.AsDuration()
+	}
+	return s, nil
+}
+
+func (s *Scheduler) Start(ctx context.Context) error {
+	if s.disabled {
+		close(s.done)
+		return nil
+	}
+
+	xsync.GoSafe("gate.notice.loop", func() error {
+		return s.loop()
+	})
+	return nil
+}
+
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.disabled {
+		return nil
+	}
+
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (s *Scheduler) loop() error {
+	defer close(s.done)
+
+	ctx := context.Background()
+	if err := s.load(ctx); err != nil {
+		s.log.Errorf("[notice.Scheduler] state load failed. key=%s %+v", s.key, err)
+	}
+	s.poll(ctx)
+	s.tick(ctx)
+
+	pollTicker := time.NewTicker(s.pollInterval)
+	defer pollTicker.Stop()
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return nil
+		case <-pollTicker.C:
+			s.poll(ctx)
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+// load reads the rounds pushed before the restart
+func (s *Scheduler) load(ctx context.Context) error {
+	values, err := s.rdb.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return errors.Wrapf(err, "redis HGetAll failed. key=%s", s.key)
+	}
+
+	for field, v := range values {
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			s.log.Errorf("[notice.Scheduler] notice round invalid. id=%d round=%s", id, v)
+			continue
+		}
+		s.pushed[id] = n
+	}
+	return nil
+}
+
+// poll reloads the listed notices, the previous ones are kept if the account service fails
+func (s *Scheduler) poll(ctx context.Context) {
+	list, err := s.list(ctx)
+	if err != nil {
+		s.log.Errorf("[notice.Scheduler] notice list failed. %+v", err)
+		return
+	}
+
+	now := time.Now().Unix()
+	notices := make(map[int64]*entry, len(list))
+	for _, n := range list {
+		id := noticeID(n)
+		if e, ok := s.notices[id]; ok {
+			notices[id] = e
+			continue
+		}
+		notices[id] = &entry{notice: n, start: s.seen(ctx, id, now)}
+	}
+	s.notices = notices
+
+	// the notices taken off the list are forgotten
+	var gone []string
+	for id := range s.pushed {
+		if _, ok := notices[id]; !ok {
+			delete(s.pushed, id)
+			gone = append(gone, strconv.FormatInt(id, 10))
+		}
+	}
+	if len(gone) > 0 {
+		if _, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HDel(ctx, s.key, gone...)
+			pipe.HDel(ctx, seenKey, gone...)
+			return nil
+		}); err != nil {
+			s.log.Errorf("[notice.Scheduler] redis HDel failed. key=%s ids=%v %+v", s.key, gone, err)
+		}
+	}
+}
+
+// seen returns the time the notice is first listed by any gate, or now if redis fails
+func (s *Scheduler) seen(ctx context.Context, id int64, now int64) int64 {
+	field := strconv.FormatInt(id, 10)
+	var get *redis.StringCmd
+	if _, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSetNX(ctx, seenKey, field, now)
+		pipe.Expire(ctx, seenKey, stateTTL)
+		get = pipe.HGet(ctx, seenKey, field)
+		return nil
+	}); err != nil {
+		s.log.Errorf("[notice.Scheduler] notice seen failed. id=%d %+v", id, err)
+		return now
+	}
+
+	start, err := get.Int64()
+	if err != nil {
+		return now
+	}
+	return start
+}
+
+func (s *Scheduler) list(ctx context.Context) ([]*accountv1.Notice, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.NoticeList(ctx, &accountv1.NoticeListRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "NoticeList failed")
+	}
+	if resp.Code != accountv1.NoticeListResponse_CODE_SUCCESS {
+		return nil, errors.Errorf("NoticeList failed. code=%s", resp.Code)
+	}
+	return resp.List, nil
+}
+
+// tick pushes the notices whose current round is not pushed yet
+func (s *Scheduler) tick(ctx context.Context) {
+	now := time.Now().Unix()
+	for id, e := range s.notices {
+		n := due(e.start, now, s.repeatInterval)
+		if last, ok := s.pushed[id]; ok && last >= n {
+			continue
+		}
+
+		s.push(ctx, id, e, n)
+		s.pushed[id] = n
+
+		field := strconv.FormatInt(id, 10)
+		if _, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, s.key, field, n)
+			pipe.Expire(ctx, s.key, stateTTL)
+			return nil
+		}); err != nil {
+			s.log.Errorf("[notice.Scheduler] redis HSet failed. key=%s id=%d %+v", s.key, id, err)
+		}
+	}
+}
+
+func (s *Scheduler) push(ctx context.Context, id int64, e *entry, n int64) {
+	data, err := proto.Marshal(&climsg.SCNotice{Id: id, Kind: climsg.SCNotice_Marquee, Title: e.notice.Title, Content: e.notice.Content})
+	if err != nil {
+		s.log.Errorf("[notice.Scheduler] SCNotice encode failed. id=%d %+v", id, err)
+		return
+	}
+	body := &pushv1.PushBody{Mod: int32(climod.ModuleID_System), Seq: int32(cliseq.SystemSeq_Notice), Data: data}
+
+	opts := xnet.PushOptions{}
+	if s.repeatInterval > 0 {
+		// the round not written before the next one is stale
+		opts.ExpireAt = time.Now().Add(s.repeatInterval)
+	}
+
+	matched, delivered, err := s.server.BroadcastWith(ctx, s.filter, func(ss xnet.Session) ([]byte, error) {
+		return tunnels.Pack(body)
+	}, opts)
+	if err != nil {
+		s.log.Errorf("[notice.Scheduler] push failed. id=%d round=%d %+v", id, n, err)
+	}
+	pushedCounter.Add(float64(delivered))
+	s.log.Infof("[notice.Scheduler] notice pushed. id=%d round=%d matched=%d delivered=%d", id, n, matched, delivered)
+}
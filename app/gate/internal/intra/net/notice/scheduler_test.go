@@ -0,0 +1,170 @@
+package notice
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+	accountv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/account/interface/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type fakeNoticeClient struct {
+	list []*accountv1.Notice
+	err  error
+}
+
+func (c *fakeNoticeClient) NoticeList(ctx context.Context, in *accountv1.NoticeListRequest, opts ...grpc.CallOption) (*accountv1.NoticeListResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &accountv1.NoticeListResponse{Code: accountv1.NoticeListResponse_CODE_SUCCESS, List: c.list}, nil
+}
+
+type fakeBroadcaster struct {
+	ss     xnet.Session
+	pushed []*climsg.SCNotice
+}
+
+func (b *fakeBroadcaster) BroadcastWith(ctx context.Context, filter xnet.SessionFilter, pack func(ss xnet.Session) ([]byte, error), opts xnet.PushOptions) (int, int, error) {
+	if !filter.Match(b.ss) {
+		return 0, 0, nil
+	}
+	out, err := pack(b.ss)
+	if err != nil {
+		return 1, 0, err
+	}
+	p := &clipkt.Packet{}
+	if err = proto.Unmarshal(out, p); err != nil {
+		return 1, 0, err
+	}
+	sc := &climsg.SCNotice{}
+	if err = proto.Unmarshal(p.Data, sc); err != nil {
+		return 1, 0, err
+	}
+	b.pushed = append(b.pushed, sc)
+	return 1, 1, nil
+}
+
+func (b *fakeBroadcaster) titles() []string {
+	titles := make([]string, 0, len(b.pushed))
+	for _, sc := range b.pushed {
+		titles = append(titles, sc.Title)
+	}
+	b.pushed = nil
+	return titles
+}
+
+type testEnv struct {
+	rdb    redis.Cmdable
+	client *fakeNoticeClient
+	server *fakeBroadcaster
+}
+
+func newTestEnv(t *testing.T) (*testEnv, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return &testEnv{
+		rdb:    rdb,
+		client: &fakeNoticeClient{},
+		server: &fakeBroadcaster{ss: xnet.NewSession(10001, 7, time.Now().Unix(), nil, nil, false, "", 1)},
+	}, mr
+}
+
+func (e *testEnv) scheduler(t *testing.T, c *conf.Notices, label *conf.Label) *Scheduler {
+	s, err := newScheduler(c, label, log.DefaultLogger, &data.Data{Rdb: e.rdb}, e.server, e.client)
+	require.NoError(t, err)
+	return s
+}
+
+func TestDue(t *testing.T) {
+	assert.Equal(t, int64(0), due(1000, 100000, 0))
+	assert.Equal(t, int64(0), due(1000, 999, time.Minute))
+	assert.Equal(t, int64(0), due(1000, 1299, 5*time.Minute))
+	assert.Equal(t, int64(1), due(1000, 1300, 5*time.Minute))
+	assert.Equal(t, int64(330), due(1000, 100000, 5*time.Minute))
+}
+
+func TestNoticeID(t *testing.T) {
+	a := noticeID(&accountv1.Notice{Title: "a", Content: "bc"})
+	assert.Equal(t, a, noticeID(&accountv1.Notice{Title: "a", Content: "bc"}))
+	assert.NotEqual(t, a, noticeID(&accountv1.Notice{Title: "ab", Content: "c"}))
+	assert.Positive(t, a)
+}
+
+func TestSchedulerPoll(t *testing.T) {
+	env, mr := newTestEnv(t)
+	ctx := context.Background()
+	c := &conf.Notices{RepeatInterval: durationpb.New(5 * time.Minute)}
+	label := &conf.Label{Node: "gate-1"}
+	env.client.list = []*accountv1.Notice{{Title: "event", Content: "starts"}, {Title: "patch", Content: "notes"}}
+
+	s := env.scheduler(t, c, label)
+	require.NoError(t, s.load(ctx))
+	s.poll(ctx)
+	s.tick(ctx)
+	assert.ElementsMatch(t, []string{"event", "patch"}, env.server.titles())
+	s.tick(ctx)
+	assert.Empty(t, env.server.titles(), "the round is pushed once")
+
+	// the restarted gate does not push the round again
+	s = env.scheduler(t, c, label)
+	require.NoError(t, s.load(ctx))
+	s.poll(ctx)
+	s.tick(ctx)
+	assert.Empty(t, env.server.titles())
+
+	// the notice listed 5 minutes ago by any gate is due for the next round
+	event := strconv.FormatInt(noticeID(env.client.list[0]), 10)
+	mr.HSet(seenKey, event, strconv.FormatInt(time.Now().Add(-5*time.Minute).Unix(), 10))
+	s = env.scheduler(t, c, label)
+	require.NoError(t, s.load(ctx))
+	s.poll(ctx)
+	s.tick(ctx)
+	assert.Equal(t, []string{"event"}, env.server.titles())
+
+	// the notices are kept while the account service fails
+	env.client.err = errors.New("unavailable")
+	s.poll(ctx)
+	assert.Len(t, s.notices, 2)
+
+	// the notice taken off the list is forgotten by all gates
+	env.client.err = nil
+	env.client.list = env.client.list[1:]
+	s.poll(ctx)
+	assert.Len(t, s.notices, 1)
+	assert.Equal(t, "", mr.HGet(seenKey, event))
+	assert.Equal(t, "", mr.HGet(stateKey("gate-1"), event))
+}
+
+func TestSchedulerFilter(t *testing.T) {
+	env, _ := newTestEnv(t)
+	ctx := context.Background()
+	env.client.list = []*accountv1.Notice{{Title: "event", Content: "starts"}}
+
+	s := env.scheduler(t, &conf.Notices{Filter: "sid in (1, 2)"}, &conf.Label{Node: "gate-1"})
+	s.poll(ctx)
+	s.tick(ctx)
+	assert.Empty(t, env.server.titles(), "the session of the other sid is filtered out")
+
+	s = env.scheduler(t, &conf.Notices{Zones: []uint32{2}}, &conf.Label{Node: "gate-2", Zone: 1})
+	assert.True(t, s.disabled, "the gates of the other zones do not push")
+
+	_, err := newScheduler(&conf.Notices{Filter: "sid =="}, &conf.Label{Node: "gate-3"}, log.DefaultLogger, &data.Data{Rdb: env.rdb}, env.server, env.client)
+	assert.Error(t, err)
+}
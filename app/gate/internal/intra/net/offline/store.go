@@ -0,0 +1,199 @@
+package offline
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	servicev1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"google.golang.org/protobuf/proto"
+)
+
+var ProviderSet = wire.NewSet(NewStore)
+
+const (
+	defaultTTL    = time.Hour * 72
+	defaultMaxLen = 100
+)
+
+const (
+	stageStored    = "stored"
+	stageDelivered = "delivered"
+	stageAcked     = "acked"
+)
+
+var messageCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "vulcan",
+	Subsystem: "gate",
+	Name:      "offline_message_total",
+	Help:      "offline messages by stage(stored, delivered, acked)",
+}, []string{"stage"})
+
+// the messages of a uid are kept in a sorted set scored by the sequence of the uid, the keys share the hash tag for the cluster
+func key(uid int64) string {
+	return "gate:offline:{" + strconv.FormatInt(uid, 10) + "}"
+}
+
+func seqKey(uid int64) string {
+	return "gate:offline:seq:{" + strconv.FormatInt(uid, 10) + "}"
+}
+
+// saveScript adds the message with the next sequence of the uid and drops the oldest ones beyond the max length.
+// the sequence prefixes the member so the same messages are kept apart
+var saveScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[2])
+redis.call('ZADD', KEYS[1], seq, seq .. ':' .. ARGV[1])
+redis.call('ZREMRANGEBYRANK', KEYS[1], 0, -tonumber(ARGV[2]) - 1)
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+redis.call('PEXPIRE', KEYS[2], ARGV[3])
+return seq
+`)
+
+// delivery is a message delivered but not acknowledged
+type delivery struct {
+	index int64 // the sc index of the packet in the session
+	seq   int64 // the sequence of the message in the store
+}
+
+// Store keeps the messages pushed to the offline users in redis per uid.
+// the messages are delivered in order after the handshake and deleted by their sequences when the client acknowledges them by the sc index,
+// so the acknowledged messages are never delivered again even if the messages are dropped or added in the meantime
+type Store struct {
+	log    *log.Helper
+	rdb    redis.Cmdable
+	ttl    time.Duration
+	maxLen int64
+
+	mu        sync.Mutex
+	delivered map[xnet.Session][]delivery // in the sc index order
+}
+
+func NewStore(c *conf.Offline, logger log.Logger, d *data.Data) *Store {
+	s := &Store{
+		log:       log.NewHelper(log.With(logger, "module", "gate/offline")),
+		rdb:       d.Rdb,
+		ttl:       defaultTTL,
+		maxLen:    defaultMaxLen,
+		delivered: make(map[xnet.Session][]delivery),
+	}
+	if ttl := c.GetTtl(); ttl != nil && ttl.AsDuration() > 0 {
+		s.ttl = ttl.AsDuration()
+	}
+	if n := c.GetMaxLen(); n > 0 {
+		s.maxLen = int64(n)
+	}
+	return s
+}
+
+// Save adds the message to the store of the uid, the oldest messages are dropped beyond the max length
+func (s *Store) Save(ctx context.Context, uid int64, body *servicev1.PushBody) error {
+	data, err := proto.Marshal(body)
+	if err != nil {
+		return errors.Wrapf(err, "PushBody encode failed. uid=%d", uid)
+	}
+
+	if err = saveScript.Run(ctx, s.rdb, []string{key(uid), seqKey(uid)}, data, s.maxLen, s.ttl.Milliseconds()).Err(); err != nil {
+		return errors.Wrapf(err, "offline message save failed. uid=%d", uid)
+	}
+
+	messageCounter.WithLabelValues(stageStored).Inc()
+	return nil
+}
+
+// Deliver pushes the stored messages of the session in order, it is called once the session is served and its writer runs,
+// and before the packets of the client are read, so the messages are written ahead of the tunnel traffic.
+// each message is recorded with its sc index when it is written, so the client may acknowledge the messages while they are being delivered
+func (s *Store) Deliver(ctx context.Context, ss xnet.Session, p xnet.Pusher) error {
+	values, err := s.rdb.ZRangeWithScores(ctx, key(ss.UID()), 0, s.maxLen-1).Result()
+	if err != nil {
+		return errors.Wrapf(err, "redis ZRange failed. uid=%d", ss.UID())
+	}
+
+	delivered := 0
+	for _, v := range values {
+		seq := int64(v.Score)
+		member, _ := v.Member.(string)
+
+		body := &servicev1.PushBody{}
+		if i := strings.IndexByte(member, ':'); i < 0 {
+			err = errors.New("sequence not found")
+		} else {
+			err = proto.Unmarshal([]byte(member[i+1:]), body)
+		}
+		if err != nil {
+			s.log.WithContext(ctx).Errorf("[offline.Store] message decode failed, deleted. uid=%d seq=%d %+v", ss.UID(), seq, err)
+			if err = s.rdb.ZRem(ctx, key(ss.UID()), member).Err(); err != nil {
+				return errors.Wrapf(err, "redis ZRem failed. uid=%d seq=%d", ss.UID(), seq)
+			}
+			continue
+		}
+
+		out, err := tunnels.Pack(body)
+		if err != nil {
+			return errors.WithMessagef(err, "uid=%d seq=%d mod=%d", ss.UID(), seq, body.Mod)
+		}
+		opts := xnet.PushOptions{Indexed: func(index int64) {
+			s.record(ss, delivery{index: index, seq: seq})
+		}}
+		if err = p.PushWith(ctx, out, opts); err != nil {
+			return errors.WithMessagef(err, "uid=%d seq=%d mod=%d", ss.UID(), seq, body.Mod)
+		}
+		delivered++
+	}
+
+	messageCounter.WithLabelValues(stageDelivered).Add(float64(delivered))
+	return nil
+}
+
+func (s *Store) record(ss xnet.Session, d delivery) {
+	s.mu.Lock()
+	s.delivered[ss] = append(s.delivered[ss], d)
+	s.mu.Unlock()
+}
+
+// Ack deletes the delivered messages up to the sc index from the store
+func (s *Store) Ack(ctx context.Context, ss xnet.Session, index int64) error {
+	s.mu.Lock()
+	deliveries := s.delivered[ss]
+	n := 0
+	for n < len(deliveries) && deliveries[n].index <= index {
+		n++
+	}
+	if n == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	seq := deliveries[n-1].seq
+	if n == len(deliveries) {
+		delete(s.delivered, ss)
+	} else {
+		s.delivered[ss] = deliveries[n:]
+	}
+	s.mu.Unlock()
+
+	if err := s.rdb.ZRemRangeByScore(ctx, key(ss.UID()), "-inf", strconv.FormatInt(seq, 10)).Err(); err != nil {
+		return errors.Wrapf(err, "redis ZRemRangeByScore failed. uid=%d seq=%d", ss.UID(), seq)
+	}
+
+	messageCounter.WithLabelValues(stageAcked).Add(float64(n))
+	return nil
+}
+
+// Forget drops the delivery state of the session, the messages not acknowledged are delivered again on the next login
+func (s *Store) Forget(ss xnet.Session) {
+	s.mu.Lock()
+	delete(s.delivered, ss)
+	s.mu.Unlock()
+}
@@ -0,0 +1,135 @@
+package offline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+	servicev1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"google.golang.org/protobuf/proto"
+)
+
+const uid = 10001
+
+// fakePusher writes the packets at once, the sc index is taken on the write as the worker does
+type fakePusher struct {
+	index   int64
+	packets []*clipkt.Packet
+	onPush  func(p *clipkt.Packet)
+}
+
+func (p *fakePusher) PushWith(ctx context.Context, out []byte, opts xnet.PushOptions) error {
+	p.index++
+	out = tunnels.IndexPack(out, p.index)
+	opts.Index(p.index)
+
+	pkt := &clipkt.Packet{}
+	if err := proto.Unmarshal(out, pkt); err != nil {
+		return err
+	}
+	p.packets = append(p.packets, pkt)
+	if p.onPush != nil {
+		p.onPush(pkt)
+	}
+	return nil
+}
+
+func newTestStore(t *testing.T, maxLen int32) (*Store, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return NewStore(&conf.Offline{MaxLen: maxLen}, log.DefaultLogger, &data.Data{Rdb: rdb}), mr
+}
+
+func newSession() xnet.Session {
+	return xnet.NewSession(uid, 1, time.Now().Unix(), nil, nil, false, "", 0)
+}
+
+func save(t *testing.T, s *Store, seqs ...int32) {
+	for _, seq := range seqs {
+		require.NoError(t, s.Save(context.Background(), uid, &servicev1.PushBody{Mod: 1, Seq: seq, Persist: true}))
+	}
+}
+
+func seqs(packets []*clipkt.Packet) []int32 {
+	out := make([]int32, 0, len(packets))
+	for _, p := range packets {
+		out = append(out, p.Seq)
+	}
+	return out
+}
+
+func TestStoreDeliverAck(t *testing.T) {
+	s, mr := newTestStore(t, 3)
+	ctx := context.Background()
+	save(t, s, 1, 2, 3, 4)
+	assert.InDelta(t, defaultTTL.Seconds(), mr.TTL(key(uid)).Seconds(), 1)
+
+	ss := newSession()
+	p := &fakePusher{}
+	require.NoError(t, s.Deliver(ctx, ss, p))
+	assert.Equal(t, []int32{2, 3, 4}, seqs(p.packets), "the oldest message is dropped beyond the max length")
+
+	require.NoError(t, s.Ack(ctx, ss, int64(p.packets[0].Index)))
+	// the messages stored in the meantime are not deleted by the acknowledgement of the delivered ones
+	save(t, s, 5)
+	require.NoError(t, s.Ack(ctx, ss, int64(p.packets[2].Index)))
+	s.Forget(ss)
+
+	ss = newSession()
+	p = &fakePusher{}
+	require.NoError(t, s.Deliver(ctx, ss, p))
+	assert.Equal(t, []int32{5}, seqs(p.packets))
+}
+
+func TestStoreAckWhileDelivering(t *testing.T) {
+	s, _ := newTestStore(t, 10)
+	ctx := context.Background()
+	save(t, s, 1, 2, 3)
+
+	ss := newSession()
+	p := &fakePusher{}
+	p.onPush = func(pkt *clipkt.Packet) {
+		if pkt.Seq == 2 {
+			assert.NoError(t, s.Ack(ctx, ss, int64(pkt.Index)))
+		}
+	}
+	require.NoError(t, s.Deliver(ctx, ss, p))
+	assert.Len(t, p.packets, 3)
+
+	// the session is broken before the last message is acknowledged
+	s.Forget(ss)
+	p = &fakePusher{}
+	require.NoError(t, s.Deliver(ctx, newSession(), p))
+	assert.Equal(t, []int32{3}, seqs(p.packets), "the acknowledged messages are not delivered again")
+}
+
+func TestStoreBrokenMessage(t *testing.T) {
+	s, mr := newTestStore(t, 10)
+	ctx := context.Background()
+	save(t, s, 1)
+	_, err := mr.ZAdd(key(uid), 2, "broken")
+	require.NoError(t, err)
+	save(t, s, 3)
+
+	ss := newSession()
+	p := &fakePusher{}
+	require.NoError(t, s.Deliver(ctx, ss, p))
+	assert.Equal(t, []int32{1, 3}, seqs(p.packets))
+	members, err := mr.ZMembers(key(uid))
+	require.NoError(t, err)
+	assert.Len(t, members, 2, "the broken message is deleted on the delivery")
+
+	require.NoError(t, s.Ack(ctx, ss, int64(p.packets[1].Index)))
+	assert.False(t, mr.Exists(key(uid)))
+}
@@ -0,0 +1,534 @@
+package queue
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
+	cliseq "github.com/vulcan-frame/vulcan-gate/gen/api/client/sequence"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/intra/v1"
+	pushv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	"google.golang.org/protobuf/proto"
+)
+
+var ProviderSet = wire.NewSet(NewQueue)
+
+var _ xnet.Admission = (*Queue)(nil)
+
+// ErrTicketReplaced is returned to the waiting client when the same user waits in the queue again on this gate
+var ErrTicketReplaced = errors.New("queue ticket replaced by the same user")
+
+const (
+	defaultNotifyInterval = time.Second * 5
+	tickInterval          = time.Second
+	requestTimeout        = time.Second * 3
+	// the tickets and the online counts not refreshed in it are dropped, e.g. the client left or the gate crashed
+	staleTimeout = time.Second * 10
+)
+
+var (
+	waitingGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vulcan",
+		Subsystem: "gate",
+		Name:      "queue_waiting",
+		Help:      "clients waiting in the login queue on this gate",
+	})
+	admittedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vulcan",
+		Subsystem: "gate",
+		Name:      "queue_admitted_total",
+		Help:      "logins admitted on this gate by path(direct, queued)",
+	}, []string{"path"})
+)
+
+// the keys of a server ID share the hash tag, so the script runs on one slot of the redis cluster
+func keys(sid int64, now time.Time) []string {
+	prefix := "gate:queue:{" + strconv.FormatInt(sid, 10) + "}"
+	rate := prefix + ":rate:" + strconv.FormatInt(now.Unix(), 10)
+	return []string{
+		prefix,             // the tickets scored by the enqueue order
+		prefix + ":alive",  // the tickets scored by the last refresh in milliseconds
+		prefix + ":seq",    // the enqueue order
+		rate,               // the admissions in the current second
+		prefix + ":online", // the online count of each gate
+		prefix + ":seen",   // the last refresh of each gate in milliseconds
+	}
+}
+
+// admitScript refreshes the online count of the gate and the tickets of its waiting clients, drops the stale ones,
+// then admits the tickets in the head of the queue within the budget of the rate and the max online.
+// it returns the position of each ticket, 0 if it is admitted, followed by the length of the queue
+var admitScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local stale = now - tonumber(ARGV[2])
+local rate = tonumber(ARGV[3])
+local max = tonumber(ARGV[4])
+local node = ARGV[5]
+
+redis.call('HSET', KEYS[5], node, ARGV[6])
+redis.call('HSET', KEYS[6], node, now)
+local online = 0
+local seen = redis.call('HGETALL', KEYS[6])
+for i = 1, #seen, 2 do
+	if tonumber(seen[i + 1]) < stale then
+		redis.call('HDEL', KEYS[5], seen[i])
+		redis.call('HDEL', KEYS[6], seen[i])
+	else
+		online = online + tonumber(redis.call('HGET', KEYS[5], seen[i]) or '0')
+	end
+end
+
+local gone = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', '(' .. stale, 'LIMIT', 0, 1000)
+if #gone > 0 then
+	redis.call('ZREM', KEYS[1], unpack(gone))
+	redis.call('ZREM', KEYS[2], unpack(gone))
+end
+
+local budget = nil
+if rate > 0 then
+	budget = rate - tonumber(redis.call('GET', KEYS[4]) or '0')
+end
+if max > 0 and (budget == nil or max - online < budget) then
+	budget = max - online
+end
+
+local ret = {}
+local admitted = 0
+for i = 7, #ARGV do
+	local t = ARGV[i]
+	if not redis.call('ZSCORE', KEYS[1], t) then
+		redis.call('ZADD', KEYS[1], redis.call('INCR', KEYS[3]), t)
+	end
+	local rank = redis.call('ZRANK', KEYS[1], t)
+	if rank < budget - admitted then
+		redis.call('ZREM', KEYS[1], t)
+		redis.call('ZREM', KEYS[2], t)
+		admitted = admitted + 1
+		ret[#ret + 1] = 0
+	else
+		redis.call('ZADD', KEYS[2], now, t)
+		ret[#ret + 1] = rank + 1
+	end
+end
+
+if admitted > 0 then
+	redis.call('INCRBY', KEYS[4], admitted)
+	redis.call('EXPIRE', KEYS[4], 2)
+	redis.call('HINCRBY', KEYS[5], node, admitted)
+end
+ret[#ret + 1] = redis.call('ZCARD', KEYS[1])
+return ret
+`)
+
+type limit struct {
+	rate      int64
+	maxOnline int64
+}
+
+func (l limit) enabled() bool {
+	return l.rate > 0 || l.maxOnline > 0
+}
+
+type update struct {
+	admitted bool
+	position int64
+	total    int64
+}
+
+type ticket struct {
+	id       string // the UID
+	sid      int64
+	admitted bool // set by the loop with the lock held
+	updates  chan update
+	replaced chan struct{} // closed when the same UID waits again on this gate
+}
+
+// notify keeps the latest update only, it is called by the loop alone
+func (t *ticket) notify(u update) {
+	select {
+	case <-t.updates:
+	default:
+	}
+	t.updates <- u
+}
+
+// Queue is the FIFO login queue of each server ID shared by all gates. the handshaked clients over the rate or the max online
+// of their server ID wait in the queue with the connection kept, and are admitted in the order they are queued on any gate.
+// every gate refreshes the tickets of its waiting clients and its online count in the tick, the ones not refreshed are dropped
+type Queue struct {
+	log            *log.Helper
+	rdb            redis.Cmdable
+	node           string
+	defaults       limit
+	limits         map[int64]limit
+	notifyInterval time.Duration
+
+	mu      sync.Mutex
+	waiting map[int64][]*ticket // by the server ID in the enqueue order
+	online  map[int64]int64     // the admitted sessions on this gate by the server ID
+	left    map[int64]struct{}  // the server IDs of which all the sessions on this gate left, the next tick refreshes their zero online count
+
+	stop chan struct{}
+}
+
+func NewQueue(c *conf.Queue, label *conf.Label, logger log.Logger, d *data.Data) (*Queue, func(), error) {
+	if c == nil {
+		c = &conf.Queue{}
+	}
+
+	node := label.Node
+	if node == "" {
+		h, err := os.Hostname()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "hostname failed, the label node is required by the login queue")
+		}
+		node = h
+	}
+
+	q := &Queue{
+		log:            log.NewHelper(log.With(logger, "module", "gate/queue")),
+		rdb:            d.Rdb,
+		node:           node,
+		defaults:       limit{rate: c.Rate, maxOnline: c.MaxOnline},
+		limits:         make(map[int64]limit, len(c.Limits)),
+		notifyInterval: defaultNotifyInterval,
+		waiting:        make(map[int64][]*ticket),
+		online:         make(map[int64]int64),
+		left:           make(map[int64]struct{}),
+		stop:           make(chan struct{}),
+	}
+	if c.NotifyInterval != nil && c.NotifyInterval.AsDuration() > 0 {
+		q.notifyInterval = c.NotifyInterval.AsDuration()
+	}
+	for _, l := range c.Limits {
+		q.limits[l.Sid] = limit{rate: l.Rate, maxOnline: l.MaxOnline}
+	}
+
+	if q.rdb != nil && q.enabled() {
+		xsync.GoSafe("gate.queue.loop", func() error {
+			return q.loop()
+		})
+	}
+
+	cleanup := func() {
+		close(q.stop)
+	}
+	return q, cleanup, nil
+}
+
+func (q *Queue) enabled() bool {
+	if q.defaults.enabled() {
+		return true
+	}
+	for _, l := range q.limits {
+		if l.enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *Queue) limit(sid int64) limit {
+	if l, ok := q.limits[sid]; ok {
+		return l
+	}
+	return q.defaults
+}
+
+// bypass lets the admin and dev sessions in without the queue, they are still counted online
+func bypass(status int64) bool {
+	return status == int64(intrav1.OnlineStatus_ONLINE_STATUS_ADMIN) || status == int64(intrav1.OnlineStatus_ONLINE_STATUS_DEV)
+}
+
+// eta estimates the wait of the position in seconds by the admission rate
+func eta(position, rate int64) int64 {
+	if rate <= 0 || position <= 0 {
+		return 0
+	}
+	return (position + rate - 1) / rate
+}
+
+// Admit queues the handshaked session, the admin and dev sessions are admitted directly
+func (q *Queue) Admit(ctx context.Context, ss xnet.Session, push func(out []byte) error) (func(), error) {
+	if bypass(ss.Status()) {
+		return q.admitted(ss.SID(), "direct"), nil
+	}
+	return q.wait(ctx, ss.SID(), ss.UID(), func(u update, eta int64) error {
+		data, err := proto.Marshal(&climsg.SCQueue{Position: u.position, Total: u.total, Eta: eta})
+		if err != nil {
+			return errors.Wrap(err, "SCQueue encode failed")
+		}
+		out, err := tunnels.Pack(&pushv1.PushBody{Mod: int32(climod.ModuleID_System), Seq: int32(cliseq.SystemSeq_Queue), Data: data})
+		if err != nil {
+			return err
+		}
+		return push(out)
+	})
+}
+
+// wait blocks until the ticket is admitted by the tick, the position is pushed when it changes or every notify interval.
+// the ticket is the authenticated UID, so a user holds one place in the queue of the server ID however many times it connects,
+// the one reconnected takes the place over and the older waiting one on this gate is replaced
+func (q *Queue) wait(ctx context.Context, sid, uid int64, push func(u update, eta int64) error) (func(), error) {
+	l := q.limit(sid)
+	if q.rdb == nil || !l.enabled() {
+		return q.admitted(sid, "direct"), nil
+	}
+
+	t := &ticket{
+		id:       strconv.FormatInt(uid, 10),
+		sid:      sid,
+		updates:  make(chan update, 1),
+		replaced: make(chan struct{}),
+	}
+
+	q.mu.Lock()
+	q.replace(t)
+	online := q.online[sid]
+	q.mu.Unlock()
+
+	ret, err := q.run(ctx, sid, l, online, []*ticket{t}, time.Now())
+	if err != nil {
+		// the queue is shared by redis, the logins are not blocked when it fails
+		q.log.WithContext(ctx).Errorf("[queue.Queue] admission failed, admitted without the queue. sid=%d %+v", sid, err)
+		return q.admitted(sid, "direct"), nil
+	}
+	if ret[0] == 0 {
+		return q.admitted(sid, "direct"), nil
+	}
+
+	q.mu.Lock()
+	q.waiting[sid] = append(q.waiting[sid], t)
+	q.mu.Unlock()
+	waitingGauge.Inc()
+
+	last := update{position: ret[0], total: ret[1]}
+	if err = push(last, eta(last.position, l.rate)); err != nil {
+		q.leave(t)
+		return nil, err
+	}
+	lastPush := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.leave(t)
+			return nil, ctx.Err()
+		case <-t.replaced:
+			waitingGauge.Dec()
+			return nil, errors.Wrapf(ErrTicketReplaced, "sid=%d uid=%d", sid, uid)
+		case u := <-t.updates:
+			if u.admitted {
+				waitingGauge.Dec()
+				admittedCounter.WithLabelValues("queued").Inc()
+				return q.release(sid), nil
+			}
+			if u == last && time.Since(lastPush) < q.notifyInterval {
+				continue
+			}
+			if err = push(u, eta(u.position, l.rate)); err != nil {
+				q.leave(t)
+				return nil, err
+			}
+			last, lastPush = u, time.Now()
+		}
+	}
+}
+
+// replace removes the waiting ticket of the same UID on this gate, it is called with the lock held.
+// the ticket stays in redis for the new one, so the user keeps the place
+func (q *Queue) replace(t *ticket) {
+	q.waiting[t.sid] = slices.DeleteFunc(q.waiting[t.sid], func(x *ticket) bool {
+		if x.id != t.id {
+			return false
+		}
+		close(x.replaced)
+		return true
+	})
+	if len(q.waiting[t.sid]) == 0 {
+		delete(q.waiting, t.sid)
+	}
+}
+
+// admitted counts the session admitted without the queue online
+func (q *Queue) admitted(sid int64, path string) func() {
+	q.mu.Lock()
+	q.online[sid]++
+	q.mu.Unlock()
+
+	admittedCounter.WithLabelValues(path).Inc()
+	return q.release(sid)
+}
+
+// release returns the func called when the admitted session is disconnected, the loop counted it online when it is admitted
+func (q *Queue) release(sid int64) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+
+			q.offline(sid)
+		})
+	}
+}
+
+// offline counts the session of the server ID offline, it is called with the lock held
+func (q *Queue) offline(sid int64) {
+	if q.online[sid]--; q.online[sid] <= 0 {
+		delete(q.online, sid)
+		q.left[sid] = struct{}{}
+	}
+}
+
+// leave removes the ticket of the client gone from the queue, the one admitted meanwhile is not online.
+// the ticket replaced by the same user is kept in redis
+func (q *Queue) leave(t *ticket) {
+	waitingGauge.Dec()
+
+	q.mu.Lock()
+	if t.admitted {
+		q.offline(t.sid)
+		q.mu.Unlock()
+		return
+	}
+	select {
+	case <-t.replaced:
+		// the ticket in redis is the place of the one which replaced it
+		q.mu.Unlock()
+		return
+	default:
+	}
+	q.waiting[t.sid] = slices.DeleteFunc(q.waiting[t.sid], func(x *ticket) bool { return x == t })
+	if len(q.waiting[t.sid]) == 0 {
+		delete(q.waiting, t.sid)
+	}
+	q.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	k := keys(t.sid, time.Now())
+	if _, err := q.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, k[0], t.id)
+		pipe.ZRem(ctx, k[1], t.id)
+		return nil
+	}); err != nil {
+		q.log.Errorf("[queue.Queue] ticket remove failed. sid=%d ticket=%s %+v", t.sid, t.id, err)
+	}
+}
+
+// run admits the tickets in the order of the queue, it returns the position of each ticket, 0 if admitted, followed by the queue length
+func (q *Queue) run(ctx context.Context, sid int64, l limit, online int64, tickets []*ticket, now time.Time) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	args := make([]any, 0, 6+len(tickets))
+	args = append(args, now.UnixMilli(), staleTimeout.Milliseconds(), l.rate, l.maxOnline, q.node, online)
+	for _, t := range tickets {
+		args = append(args, t.id)
+	}
+
+	ret, err := admitScript.Run(ctx, q.rdb, keys(sid, now), args...).Int64Slice()
+	if err != nil {
+		return nil, errors.Wrapf(err, "redis admit script failed. sid=%d", sid)
+	}
+	if len(ret) != len(tickets)+1 {
+		return nil, errors.Errorf("redis admit script returned %d values. sid=%d tickets=%d", len(ret), sid, len(tickets))
+	}
+	return ret, nil
+}
+
+func (q *Queue) loop() error {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.stop:
+			return nil
+		case now := <-ticker.C:
+			q.tick(context.Background(), now)
+		}
+	}
+}
+
+// tick refreshes the server IDs with the waiting clients, the online sessions or the sessions just left on this gate, and notifies the waiting clients
+func (q *Queue) tick(ctx context.Context, now time.Time) {
+	q.mu.Lock()
+	sids := make(map[int64][]*ticket, len(q.waiting)+len(q.online)+len(q.left))
+	online := make(map[int64]int64, len(q.online))
+	for sid := range q.left {
+		sids[sid] = nil
+	}
+	clear(q.left)
+	for sid, n := range q.online {
+		sids[sid] = nil
+		online[sid] = n
+	}
+	for sid, ts := range q.waiting {
+		sids[sid] = slices.Clone(ts)
+	}
+	q.mu.Unlock()
+
+	for sid, tickets := range sids {
+		l := q.limit(sid)
+		if !l.enabled() {
+			continue
+		}
+
+		ret, err := q.run(ctx, sid, l, online[sid], tickets, now)
+		if err != nil {
+			q.log.Errorf("[queue.Queue] tick failed. sid=%d waiting=%d %+v", sid, len(tickets), err)
+			continue
+		}
+
+		total := ret[len(ret)-1]
+		for i, t := range tickets {
+			if ret[i] != 0 {
+				t.notify(update{position: ret[i], total: total})
+				continue
+			}
+			if x := q.admit(t); x != nil {
+				x.notify(update{admitted: true})
+			}
+		}
+	}
+}
+
+// admit moves the waiting ticket of the UID from the waiting ones to the online ones, it returns the ticket admitted,
+// the one which replaced t if the same user waits again, or nil if the client has left
+func (q *Queue) admit(t *ticket) *ticket {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ts := q.waiting[t.sid]
+	i := slices.IndexFunc(ts, func(x *ticket) bool { return x.id == t.id })
+	if i < 0 {
+		return nil
+	}
+	x := ts[i]
+	if q.waiting[t.sid] = slices.Delete(ts, i, i+1); len(q.waiting[t.sid]) == 0 {
+		delete(q.waiting, t.sid)
+	}
+
+	x.admitted = true
+	q.online[t.sid]++
+	return x
+}
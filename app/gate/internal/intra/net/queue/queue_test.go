@@ -0,0 +1,318 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/intra/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+)
+
+func TestEta(t *testing.T) {
+	assert.Equal(t, int64(0), eta(10, 0))
+	assert.Equal(t, int64(0), eta(0, 100))
+	assert.Equal(t, int64(1), eta(1, 100))
+	assert.Equal(t, int64(1), eta(100, 100))
+	assert.Equal(t, int64(2), eta(101, 100))
+}
+
+func TestLimit(t *testing.T) {
+	q, cleanup, err := NewQueue(&conf.Queue{
+		Rate:   100,
+		Limits: []*conf.Queue_Limit{{Sid: 2, MaxOnline: 50}, {Sid: 3}},
+	}, &conf.Label{Node: "gate-0"}, log.DefaultLogger, &data.Data{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	assert.Equal(t, limit{rate: 100}, q.limit(1))
+	assert.Equal(t, limit{maxOnline: 50}, q.limit(2))
+	assert.False(t, q.limit(3).enabled())
+	assert.True(t, q.enabled())
+}
+
+func TestAdmitDirect(t *testing.T) {
+	q, cleanup, err := NewQueue(&conf.Queue{Limits: []*conf.Queue_Limit{{Sid: 2, Rate: 1}}},
+		&conf.Label{Node: "gate-0"}, log.DefaultLogger, &data.Data{})
+	assert.Nil(t, err)
+	defer cleanup()
+
+	push := func(out []byte) error {
+		t.Fatal("the admitted session is not queued")
+		return nil
+	}
+	gate := int64(intrav1.OnlineStatus_ONLINE_STATUS_GATE)
+	ss := xnet.NewSession(100, 1, 0, nil, nil, false, "", gate)
+	release, err := q.Admit(context.Background(), ss, push)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), q.online[1])
+
+	admin := xnet.NewSession(101, 2, 0, nil, nil, false, "", int64(intrav1.OnlineStatus_ONLINE_STATUS_ADMIN))
+	release2, err := q.Admit(context.Background(), admin, push)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), q.online[2])
+
+	release()
+	release()
+	release2()
+	assert.Empty(t, q.online)
+}
+
+// newTestQueues returns the queues of the gates sharing one redis, their loops are not started so the tests tick them
+func newTestQueues(t *testing.T, c *conf.Queue, nodes ...string) ([]*Queue, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	qs := make([]*Queue, 0, len(nodes))
+	for _, node := range nodes {
+		q, cleanup, err := NewQueue(c, &conf.Label{Node: node}, log.DefaultLogger, &data.Data{})
+		require.Nil(t, err)
+		t.Cleanup(cleanup)
+		q.rdb = rdb
+		qs = append(qs, q)
+	}
+	return qs, mr
+}
+
+func newSession(uid, sid int64) xnet.Session {
+	return xnet.NewSession(uid, sid, 0, nil, nil, false, "", int64(intrav1.OnlineStatus_ONLINE_STATUS_GATE))
+}
+
+func tickets(ids ...string) []*ticket {
+	ts := make([]*ticket, 0, len(ids))
+	for _, id := range ids {
+		ts = append(ts, &ticket{id: id, sid: 1})
+	}
+	return ts
+}
+
+func TestRunRate(t *testing.T) {
+	qs, _ := newTestQueues(t, &conf.Queue{}, "gate-0")
+	q, ctx, l := qs[0], context.Background(), limit{rate: 2}
+	now := time.Unix(1700000000, 0)
+
+	ret, err := q.run(ctx, 1, l, 0, tickets("a", "b", "c"), now)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{0, 0, 1, 1}, ret, "the rate of the second is used up by a and b")
+
+	ret, err = q.run(ctx, 1, l, 0, tickets("c"), now.Add(500*time.Millisecond))
+	require.Nil(t, err)
+	assert.Equal(t, []int64{1, 1}, ret)
+
+	ret, err = q.run(ctx, 1, l, 0, tickets("c", "d"), now.Add(time.Second))
+	require.Nil(t, err)
+	assert.Equal(t, []int64{0, 0, 0}, ret, "the rate is renewed in the next second")
+}
+
+func TestRunFIFO(t *testing.T) {
+	qs, _ := newTestQueues(t, &conf.Queue{}, "gate-0", "gate-1")
+	ctx, l := context.Background(), limit{maxOnline: 1}
+	now := time.Now()
+
+	// gate-0 is full, a is queued on gate-1 before b on gate-0
+	ret, err := qs[0].run(ctx, 1, l, 1, nil, now)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{0}, ret)
+	ret, err = qs[1].run(ctx, 1, l, 0, tickets("a"), now)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{1, 1}, ret)
+	ret, err = qs[0].run(ctx, 1, l, 1, tickets("b"), now)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{2, 2}, ret)
+
+	// the session on gate-0 left, b is not admitted ahead of a though gate-0 refreshes first
+	ret, err = qs[0].run(ctx, 1, l, 0, tickets("b"), now)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{2, 2}, ret)
+
+	ret, err = qs[1].run(ctx, 1, l, 0, tickets("a"), now)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{0, 1}, ret)
+
+	// a is counted online on gate-1 once admitted
+	ret, err = qs[0].run(ctx, 1, l, 0, tickets("b"), now)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{1, 1}, ret)
+}
+
+func TestRunMaxOnline(t *testing.T) {
+	qs, _ := newTestQueues(t, &conf.Queue{}, "gate-0", "gate-1")
+	ctx, l := context.Background(), limit{rate: 10, maxOnline: 3}
+	now := time.Now()
+
+	ret, err := qs[0].run(ctx, 1, l, 2, nil, now)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{0}, ret)
+
+	// the max online is shared by the gates, the lower budget of the rate and the max online is used
+	ret, err = qs[1].run(ctx, 1, l, 0, tickets("a", "b"), now)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{0, 1, 1}, ret)
+}
+
+func TestRunStale(t *testing.T) {
+	qs, mr := newTestQueues(t, &conf.Queue{}, "gate-0", "gate-1")
+	ctx, l := context.Background(), limit{maxOnline: 1}
+	now := time.Now()
+
+	// gate-1 is full and has a waiting, then it crashes
+	ret, err := qs[1].run(ctx, 1, l, 1, tickets("a"), now)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{1, 1}, ret)
+
+	ret, err = qs[0].run(ctx, 1, l, 0, tickets("b"), now.Add(staleTimeout/2))
+	require.Nil(t, err)
+	assert.Equal(t, []int64{2, 2}, ret)
+
+	// the ticket and the online count not refreshed are dropped
+	ret, err = qs[0].run(ctx, 1, l, 0, tickets("b"), now.Add(staleTimeout+time.Millisecond))
+	require.Nil(t, err)
+	assert.Equal(t, []int64{0, 0}, ret)
+
+	k := keys(1, now)
+	assert.False(t, mr.Exists(k[1]), "the refreshes of the dropped tickets are removed")
+	online, err := mr.HKeys(k[4])
+	require.Nil(t, err)
+	assert.Equal(t, []string{"gate-0"}, online)
+}
+
+func TestQueueAdmitted(t *testing.T) {
+	qs, _ := newTestQueues(t, &conf.Queue{MaxOnline: 1}, "gate-0", "gate-1")
+	ctx := context.Background()
+
+	release, err := qs[0].Admit(ctx, newSession(100, 1), func(out []byte) error {
+		t.Fatal("the first client is not queued")
+		return nil
+	})
+	require.Nil(t, err)
+
+	var (
+		mu    sync.Mutex
+		count int
+		done  = make(chan func(), 1)
+	)
+	go func() {
+		release, err := qs[1].Admit(ctx, newSession(101, 1), func(out []byte) error {
+			mu.Lock()
+			count++
+			mu.Unlock()
+			return nil
+		})
+		assert.Nil(t, err)
+		done <- release
+	}()
+	assert.Eventually(t, func() bool {
+		qs[1].mu.Lock()
+		defer qs[1].mu.Unlock()
+		return len(qs[1].waiting[1]) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	release()
+	qs[0].tick(ctx, time.Now())
+	assert.Empty(t, done, "the ticket is admitted by the tick of its gate")
+	qs[1].tick(ctx, time.Now())
+
+	select {
+	case release := <-done:
+		assert.Equal(t, int64(1), qs[1].online[1])
+		release()
+		assert.Empty(t, qs[1].online)
+	case <-time.After(time.Second):
+		t.Fatal("the queued client is not admitted")
+	}
+	mu.Lock()
+	assert.Equal(t, 1, count, "the position is pushed once")
+	mu.Unlock()
+}
+
+func TestQueueLeave(t *testing.T) {
+	qs, mr := newTestQueues(t, &conf.Queue{MaxOnline: 1}, "gate-0")
+	q := qs[0]
+
+	release, err := q.Admit(context.Background(), newSession(100, 1), func(out []byte) error { return nil })
+	require.Nil(t, err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := q.Admit(ctx, newSession(101, 1), func(out []byte) error { return nil })
+		done <- err
+	}()
+	k := keys(1, time.Now())
+	assert.Eventually(t, func() bool { return mr.Exists(k[0]) }, time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("the client gone is still queued")
+	}
+	assert.False(t, mr.Exists(k[0]), "the ticket of the client gone is removed")
+	assert.Empty(t, q.waiting)
+}
+
+func TestQueueReplaced(t *testing.T) {
+	qs, mr := newTestQueues(t, &conf.Queue{MaxOnline: 1}, "gate-0")
+	q, ctx := qs[0], context.Background()
+
+	release, err := q.Admit(ctx, newSession(100, 1), func(out []byte) error { return nil })
+	require.Nil(t, err)
+
+	admit := func(uid int64) chan error {
+		done := make(chan error, 1)
+		go func() {
+			release, err := q.Admit(ctx, newSession(uid, 1), func(out []byte) error { return nil })
+			if err == nil {
+				defer release()
+			}
+			done <- err
+		}()
+		return done
+	}
+	waiting := func(n int) func() bool {
+		return func() bool {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			return len(q.waiting[1]) == n
+		}
+	}
+
+	first := admit(101)
+	assert.Eventually(t, waiting(1), time.Second, 10*time.Millisecond)
+	second := admit(102)
+	assert.Eventually(t, waiting(2), time.Second, 10*time.Millisecond)
+
+	// the user reconnected takes the place over, the queue is not longer
+	again := admit(101)
+	select {
+	case err := <-first:
+		assert.ErrorIs(t, err, ErrTicketReplaced)
+	case <-time.After(time.Second):
+		t.Fatal("the older ticket of the user is not replaced")
+	}
+	assert.Eventually(t, waiting(2), time.Second, 10*time.Millisecond)
+	members, err := mr.ZMembers(keys(1, time.Now())[0])
+	require.Nil(t, err)
+	assert.Equal(t, []string{"101", "102"}, members, "the tickets are the UIDs in the enqueue order")
+
+	release()
+	q.tick(ctx, time.Now())
+	select {
+	case err := <-again:
+		assert.Nil(t, err, "the reconnected user keeps the place ahead of the later one")
+	case <-time.After(time.Second):
+		t.Fatal("the reconnected user is not admitted")
+	}
+	assert.Empty(t, second)
+}
@@ -6,25 +6,33 @@ import (
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/authenticator"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/pkg/pool"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/pkg/security"
 	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
 	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
 	cliseq "github.com/vulcan-frame/vulcan-gate/gen/api/client/sequence"
-	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/intra/v1"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	rctx "github.com/vulcan-frame/vulcan-gate/pkg/net/context"
 	"github.com/vulcan-frame/vulcan-pkg-tool/security/rsa"
 	"github.com/vulcan-frame/vulcan-pkg-tool/time"
 	"google.golang.org/protobuf/proto"
 )
 
-func (s *Service) OnConnected(ctx context.Context, ss net.Session) (err error) {
+// OnConnected delivers the offline messages after the session is served, so the pushes in the meantime reach the session instead of the store.
+// the login goes on if they fail to be delivered
+func (s *Service) OnConnected(ctx context.Context, ss net.Session, p net.Pusher) (err error) {
 	log.Debugf("[net.Service] connected. uid=%d color=%s status=%d", ss.UID(), ss.Color(), ss.Status())
+	if err = s.offline.Deliver(ctx, ss, p); err != nil {
+		log.Errorf("[net.Service] offline messages delivery failed. uid=%d color=%s %+v", ss.UID(), ss.Color(), err)
+	}
 	return nil
 }
 
 func (s *Service) OnDisconnect(ctx context.Context, ss net.Session) (err error) {
 	log.Debugf("[net.Service] disconnected. uid=%d color=%s status=%d", ss.UID(), ss.Color(), ss.Status())
+	s.offline.Forget(ss)
 	return nil
 }
 
@@ -63,7 +71,11 @@ func (s *Service) Auth(ctx context.Context, in []byte) (out []byte, session net.
 
 	log.Debugf("[net.Service] handshake received. len=%d token=%s", len(inp.Data), cs.Token)
 
-	if key, session, err = s.auth(cs.Token, cs.ServerId); err != nil {
+	cred := &authenticator.Credential{Token: cs.Token, AccountID: cs.AccountId, Session: cs.Session}
+	if key, session, err = s.auth(ctx, cred, cs.ServerId); err != nil {
+		if errors.Is(err, net.ErrHandshakeRejected) {
+			return s.rejectPack(cs, err), nil, err
+		}
 		return nil, nil, err
 	}
 
@@ -99,49 +111,57 @@ func (s *Service) Auth(ctx context.Context, in []byte) (out []byte, session net.
 	return out, session, nil
 }
 
-func (s *Service) auth(authToken string, sid int64) (key []byte, ss net.Session, err error) {
-	if len(authToken) <= 0 {
-		err = errors.New("[net.auth] token is empty")
-		return
-	}
-
+func (s *Service) auth(ctx context.Context, cred *authenticator.Credential, sid int64) (key []byte, ss net.Session, err error) {
 	var (
-		token *intrav1.AuthToken
-		block cipher.Block
+		claims *authenticator.Claims
+		block  cipher.Block
 	)
 
 	now := time.Now()
-	if token, err = decryptAccountToken(authToken); err != nil {
+	if claims, err = s.authenticator.Authenticate(ctx, cred); err != nil {
+		err = errors.WithMessagef(err, "authenticator=%s", s.authenticator.Type())
 		return
 	}
-	if now.After(time.Time(token.Timeout)) {
+	if claims.Expired(now) {
 		err = errors.New("token expired")
 		return
 	}
+	if st, ok := s.maintenance.Active(sid); ok && !s.maintenance.Allowed(claims.UID, rctx.ClientIP(ctx), claims.Status) {
+		err = errors.Wrapf(net.ErrHandshakeRejected, "maintenance. sid=%d maintenance-sid=%d uid=%d", sid, st.SID, claims.UID)
+		return
+	}
 	if block, key, err = security.InitApiCrypto(); err != nil {
 		return
 	}
 
-	ss = net.NewSession(token.AccountId, sid, now.Unix(), block, key, s.encrypted, token.Color, int64(token.Status))
+	ss = net.NewSession(claims.UID, sid, now.Unix(), block, key, s.encrypted, claims.Color, claims.Status)
+	ss.SetTokenTimeout(claims.Timeout.Unix())
 	return
 }
 
-func decryptAccountToken(token string) (auth *intrav1.AuthToken, err error) {
-	if len(token) <= 0 {
-		err = errors.New("token is empty")
-		return
+// rejectPack returns the SCServerLogout telling the client that the server is under maintenance,
+// the rejection is still returned without the reply if the reply fails to be built
+func (s *Service) rejectPack(cs *climsg.CSHandshake, reason error) []byte {
+	ss := net.DefaultSession()
+	out, err := s.logoutPack(ss, net.LogoutCodeWaiting, cs.ServerId)
+	if err != nil {
+		log.Errorf("[net.Service] handshake rejection pack failed. reason=%v %+v", reason, err)
+		return nil
+	}
+	// the rejection is written in place of the handshake reply, not by the write loop
+	out = tunnels.IndexPack(out, ss.IncreaseSCIndex())
+	if !s.encrypted {
+		return out
 	}
 
-	auth = &intrav1.AuthToken{}
-
-	bytes, err := security.DecryptToken(token)
+	pub, err := rsa.ParsePublicKey(cs.Pub)
 	if err != nil {
-		err = errors.Wrap(err, "token decrypt failed")
-		return
+		log.Errorf("[net.Service] handshake rejection pack failed. reason=%v %+v", reason, errors.Wrap(err, "RSA public key decode failed"))
+		return nil
 	}
-
-	if err = proto.Unmarshal(bytes, auth); err != nil {
-		err = errors.Wrap(err, "AuthToken proto decode failed")
+	if out, err = rsa.Encrypt(pub, out); err != nil {
+		log.Errorf("[net.Service] handshake rejection pack failed. reason=%v %+v", reason, errors.WithMessage(err, "Packet encrypt failed"))
+		return nil
 	}
-	return
+	return out
 }
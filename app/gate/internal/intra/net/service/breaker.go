@@ -0,0 +1,40 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/go-kratos/aegis/circuitbreaker"
+	"github.com/go-kratos/aegis/circuitbreaker/sre"
+)
+
+// breakers keeps a circuit breaker per tunnel type, so a backend that is down fails the tunnel creation fast
+type breakers struct {
+	disabled bool
+
+	mu       sync.Mutex
+	breakers map[int32]circuitbreaker.CircuitBreaker
+}
+
+func newBreakers(disabled bool) *breakers {
+	return &breakers{
+		disabled: disabled,
+		breakers: make(map[int32]circuitbreaker.CircuitBreaker),
+	}
+}
+
+// get returns nil if the breakers are disabled
+func (b *breakers) get(tp int32) circuitbreaker.CircuitBreaker {
+	if b.disabled {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb, ok := b.breakers[tp]
+	if !ok {
+		cb = sre.NewBreaker()
+		b.breakers[tp] = cb
+	}
+	return cb
+}
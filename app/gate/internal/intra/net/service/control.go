@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	controlv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/control/v1"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/topic"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+)
+
+var (
+	_ tunnels.Controller = (*Service)(nil)
+	_ tunnels.Logouter   = (*Service)(nil)
+)
+
+// Control handles the control message sent by the backend on the player tunnel stream,
+// so the player service can orchestrate the room/team/fight joins without the client sending the first packet
+func (s *Service) Control(ctx context.Context, ss xnet.Session, worker tunnel.Worker, ctrl *controlv1.Control) error {
+	switch ctrl.Kind {
+	case controlv1.Control_OPEN_TUNNEL:
+		s.openTunnel(ss, worker, ctrl.Mod, ctrl.Oid)
+	case controlv1.Control_CLOSE_TUNNEL:
+		worker.CloseTunnel(ctrl.Mod, ctrl.Oid)
+	case controlv1.Control_KICK:
+		s.Logout(ctx, ss, worker, xnet.LogoutCode(ctrl.Code))
+	case controlv1.Control_SET_TAGS:
+		ss.UpdateTags(ctrl.AddTags, ctrl.RemoveTags)
+	case controlv1.Control_SUBSCRIBE, controlv1.Control_UNSUBSCRIBE:
+		tags, err := topic.Tags(ctrl.Topics)
+		if err != nil {
+			return err
+		}
+		if ctrl.Kind == controlv1.Control_SUBSCRIBE {
+			ss.UpdateTags(tags, nil)
+		} else {
+			ss.UpdateTags(nil, tags)
+		}
+	default:
+		return errors.Errorf("control kind invalid. kind=%d", ctrl.Kind)
+	}
+
+	s.log.WithContext(ctx).Debugf("[net.Service] control handled. uid=%d color=%s kind=%s mod=%d oid=%d", ss.UID(), ss.Color(), ctrl.Kind, ctrl.Mod, ctrl.Oid)
+	return nil
+}
+
+// openTunnel opens the tunnel in the background with the session context,
+// so the player stream which carries the control message is not blocked by the tunnel creation
+func (s *Service) openTunnel(ss xnet.Session, worker tunnel.Worker, mod int32, oid int64) {
+	xsync.GoSafe(fmt.Sprintf("gate.Service.openTunnel-%d-%d-%d", ss.UID(), mod, oid), func() error {
+		ctx := worker.Context()
+		if _, err := worker.Tunnel(ctx, mod, oid); err != nil {
+			s.log.WithContext(ctx).Errorf("[net.Service] control open tunnel failed. uid=%d color=%s mod=%d oid=%d %+v", ss.UID(), ss.Color(), mod, oid, err)
+		}
+		return nil
+	})
+}
+
+// Logout sends the logout message to the client before the worker is stopped
+func (s *Service) Logout(ctx context.Context, ss xnet.Session, worker tunnel.Worker, code xnet.LogoutCode) {
+	s.logout(ctx, ss, worker, code)
+	worker.TriggerStop()
+}
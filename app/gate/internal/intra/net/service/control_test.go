@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	controlv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/control/v1"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/topic"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+)
+
+type ctxKey struct{}
+
+type controlWorker struct {
+	tunnel.Worker
+
+	ctx     context.Context
+	release chan struct{}
+	opened  chan context.Context
+	closed  chan int64
+}
+
+func (w *controlWorker) Context() context.Context { return w.ctx }
+
+func (w *controlWorker) Tunnel(ctx context.Context, mod int32, oid int64) (tunnel.Tunnel, error) {
+	<-w.release
+	w.opened <- ctx
+	return nil, nil
+}
+
+func (w *controlWorker) CloseTunnel(mod int32, oid int64) {
+	w.closed <- oid
+}
+
+func TestControl(t *testing.T) {
+	s := &Service{log: log.NewHelper(log.DefaultLogger)}
+	ss := xnet.NewSession(10001, 1, time.Now().Unix(), nil, nil, false, "", 0)
+	w := &controlWorker{
+		ctx:     context.WithValue(context.Background(), ctxKey{}, "session"),
+		release: make(chan struct{}),
+		opened:  make(chan context.Context, 1),
+		closed:  make(chan int64, 1),
+	}
+	ctx := context.Background()
+
+	// the tunnel creation is blocked, the control returns at once
+	require.NoError(t, s.Control(ctx, ss, w, &controlv1.Control{Kind: controlv1.Control_OPEN_TUNNEL, Mod: int32(climod.ModuleID_Room), Oid: 7}))
+	close(w.release)
+	select {
+	case c := <-w.opened:
+		assert.Equal(t, "session", c.Value(ctxKey{}), "the tunnel is opened with the session context")
+	case <-time.After(time.Second):
+		t.Fatal("the tunnel is not opened")
+	}
+
+	require.NoError(t, s.Control(ctx, ss, w, &controlv1.Control{Kind: controlv1.Control_CLOSE_TUNNEL, Mod: int32(climod.ModuleID_Room), Oid: 7}))
+	assert.Equal(t, int64(7), <-w.closed)
+
+	require.NoError(t, s.Control(ctx, ss, w, &controlv1.Control{Kind: controlv1.Control_SET_TAGS, AddTags: []string{"guild:1", "zone:2"}}))
+	require.NoError(t, s.Control(ctx, ss, w, &controlv1.Control{Kind: controlv1.Control_SET_TAGS, RemoveTags: []string{"zone:2"}}))
+	assert.ElementsMatch(t, []string{"guild:1"}, ss.Tags())
+
+	require.NoError(t, s.Control(ctx, ss, w, &controlv1.Control{Kind: controlv1.Control_SUBSCRIBE, Topics: []string{"news"}}))
+	assert.True(t, ss.HasTag(topic.Tag("news")))
+	require.NoError(t, s.Control(ctx, ss, w, &controlv1.Control{Kind: controlv1.Control_UNSUBSCRIBE, Topics: []string{"news"}}))
+	assert.False(t, ss.HasTag(topic.Tag("news")))
+
+	assert.Error(t, s.Control(ctx, ss, w, &controlv1.Control{Kind: controlv1.Control_NONE}))
+}
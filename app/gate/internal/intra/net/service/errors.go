@@ -0,0 +1,82 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	verrors "github.com/vulcan-frame/vulcan-pkg-app/errors"
+)
+
+// ErrUnknownModule is returned when the module is routed to a tunnel type without backend
+var ErrUnknownModule = errors.New("module unknown")
+
+const (
+	errClassFatal           = "fatal"
+	errClassUnknownModule   = "unknown_module"
+	errClassBackendBreak    = "backend_unavailable"
+	errClassCreateTimeout   = "create_timeout"
+	errClassCreateFailed    = "create_failed"
+	errClassTunnelBusy      = "tunnel_busy"
+	errClassTunnelStopped   = "tunnel_stopped"
+	errClassForwardRejected = "forward_rejected"
+)
+
+var handleErrorCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "vulcan",
+	Subsystem: "gate",
+	Name:      "handle_error_total",
+	Help:      "errors of the client packets by class(fatal, unknown_module, backend_unavailable, create_timeout, create_failed, tunnel_busy, tunnel_stopped, forward_rejected), the session is closed only by the fatal ones",
+}, []string{"class"})
+
+// recoverableError is replied to the client by SCServerUnknownErr and the session is kept
+type recoverableError struct {
+	class string
+	err   error
+}
+
+func (e *recoverableError) Error() string {
+	return e.class + ": " + e.err.Error()
+}
+
+func (e *recoverableError) Unwrap() error {
+	return e.err
+}
+
+// tunnelError classifies the error of the tunnel lookup or creation
+func tunnelError(err error) error {
+	class := errClassCreateFailed
+	switch {
+	case errors.Is(err, ErrUnknownModule):
+		class = errClassUnknownModule
+	case errors.Is(err, ErrBackendUnavailable):
+		class = errClassBackendBreak
+	case errors.Is(err, ErrCreateTimeout):
+		class = errClassCreateTimeout
+	case errors.Is(err, tunnel.ErrCreateFailed):
+		class = errClassCreateFailed
+	}
+	return &recoverableError{class: class, err: err}
+}
+
+// forwardError classifies the error of the tunnel forward
+func forwardError(err error) error {
+	class := errClassForwardRejected
+	switch {
+	case errors.Is(err, tunnels.ErrTunnelBusy):
+		class = errClassTunnelBusy
+	case errors.Is(err, verrors.ErrTunnelStopped):
+		class = errClassTunnelStopped
+	}
+	return &recoverableError{class: class, err: err}
+}
+
+// errorClass returns the class of the error and whether the session is kept
+func errorClass(err error) (string, bool) {
+	var re *recoverableError
+	if errors.As(err, &re) {
+		return re.class, true
+	}
+	return errClassFatal, false
+}
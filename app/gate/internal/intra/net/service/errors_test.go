@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	verrors "github.com/vulcan-frame/vulcan-pkg-app/errors"
+)
+
+func TestErrorClass(t *testing.T) {
+	cases := []struct {
+		err         error
+		class       string
+		recoverable bool
+	}{
+		{errors.New("packet unmarshal failed"), errClassFatal, false},
+		{tunnelError(errors.Wrap(ErrUnknownModule, "type=9")), errClassUnknownModule, true},
+		{tunnelError(errors.Wrap(ErrBackendUnavailable, "type=2")), errClassBackendBreak, true},
+		{tunnelError(errors.Wrap(ErrCreateTimeout, "type=2")), errClassCreateTimeout, true},
+		{tunnelError(errors.Wrap(tunnel.ErrCreateFailed, "type=2")), errClassCreateFailed, true},
+		{tunnelError(errors.New("dial failed")), errClassCreateFailed, true},
+		{forwardError(errors.Wrap(tunnels.ErrTunnelBusy, "type=2")), errClassTunnelBusy, true},
+		{forwardError(verrors.ErrTunnelStopped), errClassTunnelStopped, true},
+		{errors.WithMessage(forwardError(errors.New("invalid packet type")), "mod=1"), errClassForwardRejected, true},
+	}
+
+	for _, c := range cases {
+		class, recoverable := errorClass(c.err)
+		assert.Equal(t, c.class, class, c.err.Error())
+		assert.Equal(t, c.recoverable, recoverable, c.err.Error())
+	}
+}
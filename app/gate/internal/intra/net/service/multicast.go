@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/router"
+	pushv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	"github.com/vulcan-frame/vulcan-pkg-app/profile"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	forwardTimeout = 2 * time.Second
+	// maxForwarding is the max count of the multicast envelopes being forwarded to the other gates
+	maxForwarding = 1024
+)
+
+// ErrForwardBusy is returned by Multicast when too many multicast envelopes are being forwarded to the other gates
+var ErrForwardBusy = errors.New("multicast forwarding is busy")
+
+// LocalPusher pushes the packets to the sessions held by this gate, it is the tcp server
+type LocalPusher interface {
+	Multicast(ctx context.Context, uids []int64, pack func(ss xnet.Session) ([]byte, error), opts xnet.PushOptions) (missing []int64, err error)
+}
+
+var _ tunnels.Multicaster = (*Service)(nil)
+
+// SetLocalPusher is called after the tcp server is created, because the server is created with the service
+func (s *Service) SetLocalPusher(p LocalPusher) {
+	s.local = p
+}
+
+// Multicast fans the multicast envelope out to the target sessions.
+// the sessions held by this gate are pushed locally, the others are resolved through the gate route table
+// and forwarded to their gates with the PushService in the background, so the sc loop of the tunnel is never blocked by the other gates.
+// the offline users are skipped
+func (s *Service) Multicast(ctx context.Context, color string, uids []int64, msg tunnel.ForwardMessage) error {
+	if s.local == nil {
+		return errors.New("local pusher is not set")
+	}
+
+	missing, err := s.local.Multicast(ctx, uids, func(ss xnet.Session) ([]byte, error) {
+		return tunnels.Pack(msg)
+	}, xnet.PushOptions{})
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("[net.Service] local multicast failed. mod=%d seq=%d %+v", msg.GetMod(), msg.GetSeq(), err)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	select {
+	case s.forwarding <- struct{}{}:
+	default:
+		return errors.Wrapf(ErrForwardBusy, "mod=%d seq=%d size=%d", msg.GetMod(), msg.GetSeq(), len(missing))
+	}
+
+	body := &pushv1.PushBody{Mod: msg.GetMod(), Seq: msg.GetSeq(), Obj: msg.GetObj(), Data: msg.GetData()}
+	ctx = context.WithoutCancel(ctx)
+	xsync.GoSafe(fmt.Sprintf("gate.Service.forwardMulticast-%d-%d", msg.GetMod(), msg.GetSeq()), func() error {
+		defer func() { <-s.forwarding }()
+
+		if err := s.forwardMulticast(ctx, color, missing, body); err != nil {
+			s.log.WithContext(ctx).Errorf("[net.Service] mod=%d seq=%d %+v", body.Mod, body.Seq, err)
+		}
+		return nil
+	})
+	return nil
+}
+
+// forwardMulticast looks the gates of the users up concurrently, and forwards the body to each gate concurrently.
+// the conn of a gate which is unavailable is evicted, it is dialed again when the gate is back in the route table
+func (s *Service) forwardMulticast(ctx context.Context, color string, uids []int64, body *pushv1.PushBody) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, forwardTimeout)
+	defer cancel()
+
+	self := profile.GRPCEndpoint()
+	groups := make(map[string][]int64)
+	for uid, addr := range router.GetBatch(ctx, s.gateRT, color, uids) {
+		if addr != self {
+			groups[addr] = append(groups[addr], uid)
+		}
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for addr, group := range groups {
+		wg.Add(1)
+		go func(addr string, group []int64) {
+			defer wg.Done()
+
+			cli, err0 := s.gates.PushClient(addr)
+			if err0 == nil {
+				_, err0 = cli.Multicast(ctx, &pushv1.MulticastRequest{Uid: group, Bodies: []*pushv1.PushBody{body}})
+				if status.Code(err0) == codes.Unavailable {
+					s.gates.Evict(addr)
+				}
+			}
+			if err0 != nil {
+				mu.Lock()
+				err = errors.WithMessagef(err0, "multicast forward failed. addr=%s size=%d", addr, len(group))
+				mu.Unlock()
+			}
+		}(addr, group)
+	}
+	wg.Wait()
+	return
+}
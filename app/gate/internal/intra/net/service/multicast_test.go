@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/gate"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/router"
+	pushv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-pkg-app/router/routetable"
+	"google.golang.org/grpc"
+)
+
+type fakeRouteTable struct {
+	routetable.RouteTable
+
+	addrs map[int64]string
+}
+
+func (rt *fakeRouteTable) Get(ctx context.Context, color string, key int64) (string, error) {
+	return rt.addrs[key], nil
+}
+
+type fakeLocalPusher struct {
+	pushed chan []int64
+}
+
+// Multicast holds the users below 100, the others are missing
+func (p *fakeLocalPusher) Multicast(ctx context.Context, uids []int64, pack func(ss xnet.Session) ([]byte, error), opts xnet.PushOptions) (missing []int64, err error) {
+	var local []int64
+	for _, uid := range uids {
+		if uid < 100 {
+			local = append(local, uid)
+		} else {
+			missing = append(missing, uid)
+		}
+	}
+	p.pushed <- local
+	return missing, nil
+}
+
+type fakePushServer struct {
+	pushv1.UnimplementedPushServiceServer
+
+	block    chan struct{}
+	received chan *pushv1.MulticastRequest
+}
+
+func (s *fakePushServer) Multicast(ctx context.Context, req *pushv1.MulticastRequest) (*pushv1.MulticastResponse, error) {
+	<-s.block
+	s.received <- req
+	return &pushv1.MulticastResponse{}, nil
+}
+
+func startPushServer(t *testing.T, srv *fakePushServer) string {
+	s := grpc.NewServer()
+	pushv1.RegisterPushServiceServer(s, srv)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+func TestMulticastForward(t *testing.T) {
+	srv := &fakePushServer{block: make(chan struct{}), received: make(chan *pushv1.MulticastRequest, 1)}
+	addr := startPushServer(t, srv)
+
+	gates, cleanup, err := gate.NewClients(log.DefaultLogger)
+	require.NoError(t, err)
+	defer cleanup()
+
+	local := &fakeLocalPusher{pushed: make(chan []int64, 1)}
+	s := &Service{
+		log:        log.NewHelper(log.DefaultLogger),
+		gateRT:     &router.RouteTable{RouteTable: &fakeRouteTable{addrs: map[int64]string{101: "grpc://" + addr, 102: "grpc://" + addr}}},
+		gates:      gates,
+		local:      local,
+		forwarding: make(chan struct{}, 1),
+	}
+
+	msg := &pushv1.PushBody{Mod: 6, Seq: 1, Data: []byte("sc")}
+	done := make(chan error, 1)
+	go func() { done <- s.Multicast(context.Background(), "", []int64{1, 101, 102, 103}, msg) }()
+
+	// the remote gate is blocked, the multicast still returns after the local push
+	select {
+	case err = <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the multicast is blocked by the remote gate")
+	}
+	assert.Equal(t, []int64{1}, <-local.pushed)
+
+	// the forwarding slot is taken by the blocked one
+	err = s.Multicast(context.Background(), "", []int64{104}, msg)
+	assert.ErrorIs(t, err, ErrForwardBusy)
+	<-local.pushed
+
+	close(srv.block)
+	select {
+	case req := <-srv.received:
+		assert.ElementsMatch(t, []int64{101, 102}, req.Uid, "the users of the same gate are grouped, the offline user is skipped")
+		require.Len(t, req.Bodies, 1)
+		assert.Equal(t, []byte("sc"), req.Bodies[0].Data)
+	case <-time.After(time.Second * 3):
+		t.Fatal("the multicast is not forwarded")
+	}
+}
@@ -2,54 +2,103 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
 	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/backend"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/gate"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/player"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/room"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/authenticator"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/guard"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/maintenance"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/offline"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/queue"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/topic"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/pkg/pool"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/router"
+	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
 	playerv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/player/intra/v1"
 	roomv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/room/intra/v1"
 	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
 	rctx "github.com/vulcan-frame/vulcan-gate/pkg/net/context"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	verrors "github.com/vulcan-frame/vulcan-pkg-app/errors"
 	"github.com/vulcan-frame/vulcan-pkg-tool/compress"
 	"google.golang.org/protobuf/proto"
 )
 
-var ProviderSet = wire.NewSet(NewTCPService)
+var ProviderSet = wire.NewSet(NewTCPService, authenticator.ProviderSet, guard.ProviderSet, offline.ProviderSet, maintenance.ProviderSet, queue.ProviderSet)
 
 var _ xnet.Service = (*Service)(nil)
 
 type Service struct {
-	logger    log.Logger
-	encrypted bool
+	log           *log.Helper
+	logger        log.Logger
+	encrypted     bool
+	authenticator authenticator.Authenticator
 
 	playerClient playerv1.TunnelServiceClient
 	playerRT     *player.RouteTable
 
 	roomClient roomv1.TunnelServiceClient
 	roomRT     *room.RouteTable
+
+	backends *backend.Backends
+
+	gateRT *router.RouteTable
+	gates  *gate.Clients
+	local  LocalPusher
+
+	forwarding chan struct{} // the multicast envelopes being forwarded to the other gates
+
+	acl *topic.ACL
+
+	offline     *offline.Store
+	maintenance *maintenance.Maintenance
+
+	breakers      *breakers
+	createTimeout time.Duration
 }
 
-func NewTCPService(logger log.Logger, label *conf.Label,
+func NewTCPService(logger log.Logger, label *conf.Label, auth authenticator.Authenticator,
 	playerRT *player.RouteTable, playerClient playerv1.TunnelServiceClient,
-	roomRT *room.RouteTable, roomClient roomv1.TunnelServiceClient,
+	roomRT *room.RouteTable, roomClient roomv1.TunnelServiceClient, backends *backend.Backends,
+	gateRT *router.RouteTable, gates *gate.Clients, tunnels *conf.Tunnels, topics *conf.Topics, store *offline.Store,
+	m *maintenance.Maintenance,
 ) *Service {
+	createTimeout := defaultCreateTimeout
+	if d := tunnels.GetCreateTimeout(); d != nil && d.AsDuration() > 0 {
+		createTimeout = d.AsDuration()
+	}
+
 	return &Service{
-		logger:       logger,
-		encrypted:    label.Encrypted,
-		playerClient: playerClient,
-		playerRT:     playerRT,
-		roomClient:   roomClient,
-		roomRT:       roomRT,
+		log:           log.NewHelper(log.With(logger, "module", "gate/service")),
+		logger:        logger,
+		encrypted:     label.Encrypted,
+		authenticator: auth,
+		playerClient:  playerClient,
+		playerRT:      playerRT,
+		roomClient:    roomClient,
+		roomRT:        roomRT,
+		backends:      backends,
+		gateRT:        gateRT,
+		gates:         gates,
+		forwarding:    make(chan struct{}, maxForwarding),
+		acl:           topic.NewACL(topics),
+		offline:       store,
+		maintenance:   m,
+		breakers:      newBreakers(tunnels.GetBreakerDisabled()),
+		createTimeout: createTimeout,
 	}
 }
 
 func (s *Service) Handle(ctx context.Context, ss xnet.Session, th tunnel.Holder, in []byte) (err error) {
 	if err = s.handle(ctx, ss, th, in); err != nil {
+		handleErrorCounter.WithLabelValues(errClassFatal).Inc()
 		return errors.WithMessagef(err, "uid=%d color=%s status=%d", ss.UID(), ss.Color(), ss.Status())
 	}
 	return nil
@@ -80,17 +129,46 @@ func (s *Service) handle(ctx context.Context, ss xnet.Session, th tunnel.Holder,
 		}
 	}
 
+	if isSystemMessage(p) {
+		return s.handleSystem(ctx, ss, th, p)
+	}
+
 	if p.Obj == 0 {
 		p.Obj = int64(ss.UID())
 	}
 	ctx = rctx.SetOID(ctx, p.Obj)
 
+	if err = s.forward(ctx, th, p); err != nil {
+		return s.handleForwardError(ctx, ss, th, p, err)
+	}
+	return nil
+}
+
+// forward retries once if the tunnel is reclaimed between the lookup and the forward
+func (s *Service) forward(ctx context.Context, th tunnel.Holder, p *clipkt.Packet) (err error) {
 	var t tunnel.Tunnel
-	if t, err = th.Tunnel(ctx, p.Mod, p.Obj); err != nil {
-		return errors.WithMessagef(err, "mod=%d seq=%d obj=%d", p.Mod, p.Seq, p.Obj)
+	for i := 0; i < 2; i++ {
+		if t, err = th.Tunnel(ctx, p.Mod, p.Obj); err != nil {
+			return tunnelError(err)
+		}
+		if err = t.Forward(ctx, p); err == nil {
+			return nil
+		}
+		if !errors.Is(err, verrors.ErrTunnelStopped) {
+			return forwardError(err)
+		}
 	}
-	if err = t.Forward(ctx, p); err != nil {
+	return forwardError(err)
+}
+
+// handleForwardError keeps the session and replies SCServerUnknownErr to the client if the error is recoverable
+func (s *Service) handleForwardError(ctx context.Context, ss xnet.Session, th tunnel.Holder, p *clipkt.Packet, err error) error {
+	class, recoverable := errorClass(err)
+	if !recoverable {
 		return errors.WithMessagef(err, "mod=%d seq=%d obj=%d", p.Mod, p.Seq, p.Obj)
 	}
-	return nil
+	handleErrorCounter.WithLabelValues(class).Inc()
+
+	s.log.WithContext(ctx).Warnf("forward failed. uid=%d color=%s mod=%d seq=%d obj=%d %+v", ss.UID(), ss.Color(), p.Mod, p.Seq, p.Obj, err)
+	return s.replyServerUnknownErr(ctx, ss, th, p)
 }
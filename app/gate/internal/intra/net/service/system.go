@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/authenticator"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/topic"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/pkg/pool"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
+	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+	cliseq "github.com/vulcan-frame/vulcan-gate/gen/api/client/sequence"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	"github.com/vulcan-frame/vulcan-pkg-tool/time"
+	"google.golang.org/protobuf/proto"
+)
+
+// isSystemMessage returns true if the packet is handled by the gate itself instead of being forwarded to a tunnel
+func isSystemMessage(p *clipkt.Packet) bool {
+	if p.Mod != int32(climod.ModuleID_System) {
+		return false
+	}
+	switch cliseq.SystemSeq(p.Seq) {
+	case cliseq.SystemSeq_Reauth, cliseq.SystemSeq_Subscribe, cliseq.SystemSeq_Unsubscribe, cliseq.SystemSeq_OfflineAck:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Service) handleSystem(ctx context.Context, ss xnet.Session, th tunnel.Holder, p *clipkt.Packet) error {
+	switch cliseq.SystemSeq(p.Seq) {
+	case cliseq.SystemSeq_Reauth:
+		return s.reauth(ctx, ss, th, p)
+	case cliseq.SystemSeq_Subscribe:
+		return s.subscribe(ctx, ss, th, p)
+	case cliseq.SystemSeq_Unsubscribe:
+		return s.unsubscribe(ctx, ss, th, p)
+	case cliseq.SystemSeq_OfflineAck:
+		return s.offlineAck(ctx, ss, p)
+	default:
+		return errors.Errorf("system seq invalid. seq=%d", p.Seq)
+	}
+}
+
+// reauth lets the client present a refreshed token without reconnecting
+func (s *Service) reauth(ctx context.Context, ss xnet.Session, th tunnel.Holder, p *clipkt.Packet) error {
+	cs := &climsg.CSReauth{}
+	if err := proto.Unmarshal(p.Data, cs); err != nil {
+		return errors.Wrap(err, "CSReauth decode failed")
+	}
+
+	claims, err := s.authenticator.Authenticate(ctx, &authenticator.Credential{Token: cs.Token, AccountID: cs.AccountId, Session: cs.Session})
+	if err == nil && claims.UID != ss.UID() {
+		err = errors.Errorf("token uid mismatch. token-uid=%d", claims.UID)
+	}
+	if err == nil && claims.Expired(time.Now()) {
+		err = errors.New("token expired")
+	}
+	if err != nil {
+		s.logout(ctx, ss, th, xnet.LogoutCodeAuth)
+		return errors.WithMessagef(err, "reauth failed. authenticator=%s", s.authenticator.Type())
+	}
+
+	ss.SetTokenTimeout(claims.Timeout.Unix())
+	return s.reply(ctx, ss, th, p.Mod, p.Seq, p.Obj, &climsg.SCReauth{TokenTimeout: claims.Timeout.Unix()})
+}
+
+// subscribe adds the topic tags to the session, so the messages published on the topics are pushed to it.
+// the invalid or not allowed topics are replied with the error code and the session is kept
+func (s *Service) subscribe(ctx context.Context, ss xnet.Session, th tunnel.Holder, p *clipkt.Packet) error {
+	cs := &climsg.CSSubscribe{}
+	if err := proto.Unmarshal(p.Data, cs); err != nil {
+		return errors.Wrap(err, "CSSubscribe decode failed")
+	}
+
+	sc := &climsg.SCSubscribe{Topics: cs.Topics, Code: climsg.SCSubscribe_Success}
+	tags, err := topic.Tags(cs.Topics)
+	if err == nil {
+		for _, t := range cs.Topics {
+			if !s.acl.Allow(t) {
+				err = errors.Errorf("topic not allowed. topic=%q", t)
+				break
+			}
+		}
+	}
+	if err != nil {
+		sc.Code = climsg.SCSubscribe_ErrTopic
+	} else if count := topic.Count(ss.Tags()) + len(tags); count > topic.MaxTopicsPerSession {
+		err = errors.Errorf("too many topics subscribed. count=%d max=%d", count, topic.MaxTopicsPerSession)
+		sc.Code = climsg.SCSubscribe_ErrTooMany
+	}
+
+	if err != nil {
+		s.log.WithContext(ctx).Debugf("[net.Service] subscribe refused. uid=%d color=%s %+v", ss.UID(), ss.Color(), err)
+	} else {
+		ss.UpdateTags(tags, nil)
+	}
+	return s.reply(ctx, ss, th, p.Mod, p.Seq, p.Obj, sc)
+}
+
+func (s *Service) unsubscribe(ctx context.Context, ss xnet.Session, th tunnel.Holder, p *clipkt.Packet) error {
+	cs := &climsg.CSUnsubscribe{}
+	if err := proto.Unmarshal(p.Data, cs); err != nil {
+		return errors.Wrap(err, "CSUnsubscribe decode failed")
+	}
+
+	sc := &climsg.SCUnsubscribe{Topics: cs.Topics, Code: climsg.SCUnsubscribe_Success}
+	tags, err := topic.Tags(cs.Topics)
+	if err != nil {
+		s.log.WithContext(ctx).Debugf("[net.Service] unsubscribe refused. uid=%d color=%s %+v", ss.UID(), ss.Color(), err)
+		sc.Code = climsg.SCUnsubscribe_ErrTopic
+	} else {
+		ss.UpdateTags(nil, tags)
+	}
+	return s.reply(ctx, ss, th, p.Mod, p.Seq, p.Obj, sc)
+}
+
+// offlineAck deletes the offline messages received by the client, it is not replied
+func (s *Service) offlineAck(ctx context.Context, ss xnet.Session, p *clipkt.Packet) error {
+	cs := &climsg.CSOfflineAck{}
+	if err := proto.Unmarshal(p.Data, cs); err != nil {
+		return errors.Wrap(err, "CSOfflineAck decode failed")
+	}
+
+	if err := s.offline.Ack(ctx, ss, int64(cs.Index)); err != nil {
+		s.log.WithContext(ctx).Errorf("[net.Service] offline ack failed. uid=%d color=%s index=%d %+v", ss.UID(), ss.Color(), cs.Index, err)
+	}
+	return nil
+}
+
+func (s *Service) LogoutPack(ctx context.Context, ss xnet.Session, code xnet.LogoutCode) ([]byte, error) {
+	return s.logoutPack(ss, code, ss.SID())
+}
+
+// logoutPack fills the maintenance message and ETA of the sid into the Waiting logout
+func (s *Service) logoutPack(ss xnet.Session, code xnet.LogoutCode, sid int64) ([]byte, error) {
+	sc := &climsg.SCServerLogout{Code: climsg.SCServerLogout_Code(code)}
+	if code == xnet.LogoutCodeWaiting {
+		if st, ok := s.maintenance.Active(sid); ok {
+			sc.Msg, sc.Eta = st.Message, st.ETA
+		}
+	}
+	return s.pack(ss, int32(climod.ModuleID_System), int32(cliseq.SystemSeq_ServerLogout), 0, sc)
+}
+
+func (s *Service) logout(ctx context.Context, ss xnet.Session, th tunnel.Holder, code xnet.LogoutCode) {
+	out, err := s.LogoutPack(ctx, ss, code)
+	if err == nil {
+		err = th.Push(ctx, out)
+	}
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("[net.Service] logout push failed. uid=%d color=%s code=%d %+v", ss.UID(), ss.Color(), code, err)
+	}
+}
+
+// reply sends the message generated by the gate itself to the client
+func (s *Service) reply(ctx context.Context, ss xnet.Session, th tunnel.Holder, mod, seq int32, obj int64, msg proto.Message) error {
+	out, err := s.pack(ss, mod, seq, obj, msg)
+	if err != nil {
+		return err
+	}
+	return th.Push(ctx, out)
+}
+
+// replyServerUnknownErr tells the client that the request is not handled
+func (s *Service) replyServerUnknownErr(ctx context.Context, ss xnet.Session, th tunnel.Holder, p *clipkt.Packet) error {
+	return s.reply(ctx, ss, th, int32(climod.ModuleID_System), int32(cliseq.SystemSeq_ServerUnknownErr), p.Obj,
+		&climsg.SCServerUnknownErr{Mod: p.Mod, Seq: p.Seq, Msg: "service unavailable"})
+}
+
+// IndexPack stamps the sc index on the packet when the worker writes it
+func (s *Service) IndexPack(pack []byte, index int64) ([]byte, error) {
+	return tunnels.IndexPack(pack, index), nil
+}
+
+func (s *Service) pack(ss xnet.Session, mod, seq int32, obj int64, msg proto.Message) ([]byte, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "message encode failed. mod=%d seq=%d", mod, seq)
+	}
+
+	p := pool.GetPacket()
+	defer pool.PutPacket(p)
+
+	p.Mod = mod
+	p.Seq = seq
+	p.Obj = obj
+	p.Data = data
+
+	out, err := proto.Marshal(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "Packet encode failed")
+	}
+	return out, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/topic"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
+	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+	cliseq "github.com/vulcan-frame/vulcan-gate/gen/api/client/sequence"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	"google.golang.org/protobuf/proto"
+)
+
+type replyHolder struct {
+	tunnel.Holder
+
+	pushed [][]byte
+}
+
+func (h *replyHolder) Push(ctx context.Context, pack []byte) error {
+	h.pushed = append(h.pushed, pack)
+	return nil
+}
+
+// call handles the system request and decodes the reply
+func (h *replyHolder) call(t *testing.T, s *Service, ss xnet.Session, seq cliseq.SystemSeq, cs, sc proto.Message) {
+	data, err := proto.Marshal(cs)
+	require.NoError(t, err)
+	require.NoError(t, s.handleSystem(context.Background(), ss, h, &clipkt.Packet{Mod: int32(climod.ModuleID_System), Seq: int32(seq), Data: data}),
+		"the session is kept")
+
+	require.NotEmpty(t, h.pushed)
+	p := &clipkt.Packet{}
+	require.NoError(t, proto.Unmarshal(h.pushed[len(h.pushed)-1], p))
+	require.NoError(t, proto.Unmarshal(p.Data, sc))
+}
+
+func TestSubscribe(t *testing.T) {
+	s := &Service{
+		log: log.NewHelper(log.DefaultLogger),
+		acl: topic.NewACL(&conf.Topics{AllowedPrefixes: []string{"world_chat", "news."}}),
+	}
+	ss := xnet.NewSession(10001, 1, time.Now().Unix(), nil, nil, false, "", 0)
+	h := &replyHolder{}
+
+	sc := &climsg.SCSubscribe{}
+	h.call(t, s, ss, cliseq.SystemSeq_Subscribe, &climsg.CSSubscribe{Topics: []string{"world_chat", "news.1"}}, sc)
+	assert.Equal(t, climsg.SCSubscribe_Success, sc.Code)
+	assert.Equal(t, 2, topic.Count(ss.Tags()))
+
+	for _, topics := range [][]string{{"a b"}, {"news.2", "guild:1"}} {
+		h.call(t, s, ss, cliseq.SystemSeq_Subscribe, &climsg.CSSubscribe{Topics: topics}, sc)
+		assert.Equal(t, climsg.SCSubscribe_ErrTopic, sc.Code, topics)
+	}
+	assert.Equal(t, 2, topic.Count(ss.Tags()), "nothing is subscribed by the refused request")
+
+	topics := make([]string, topic.MaxTopicsPerSession-1)
+	for i := range topics {
+		topics[i] = fmt.Sprintf("news.%d", i+10)
+	}
+	h.call(t, s, ss, cliseq.SystemSeq_Subscribe, &climsg.CSSubscribe{Topics: topics}, sc)
+	assert.Equal(t, climsg.SCSubscribe_ErrTooMany, sc.Code)
+	assert.Equal(t, 2, topic.Count(ss.Tags()))
+
+	usc := &climsg.SCUnsubscribe{}
+	h.call(t, s, ss, cliseq.SystemSeq_Unsubscribe, &climsg.CSUnsubscribe{Topics: []string{"a&&b"}}, usc)
+	assert.Equal(t, climsg.SCUnsubscribe_ErrTopic, usc.Code)
+	h.call(t, s, ss, cliseq.SystemSeq_Unsubscribe, &climsg.CSUnsubscribe{Topics: []string{"world_chat"}}, usc)
+	assert.Equal(t, climsg.SCUnsubscribe_Success, usc.Code)
+	assert.Equal(t, 1, topic.Count(ss.Tags()))
+}
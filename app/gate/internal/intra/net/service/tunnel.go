@@ -2,43 +2,122 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/generic"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/mux"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/player"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/room"
-	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
 	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+)
+
+const defaultCreateTimeout = 3 * time.Second
+
+var (
+	ErrBackendUnavailable = errors.New("tunnel backend is unavailable")
+	ErrCreateTimeout      = errors.New("tunnel creation timeout")
 )
 
 // TunnelType returns the tunnel type based on the module ID
-// most modules have the specific tunnel type, except for the player module
+// the modules not declared in the tunnels config are forwarded to the player tunnel
 func (s *Service) TunnelType(mod int32) (int32, error) {
-	switch climod.ModuleID(mod) {
-	case climod.ModuleID_Room:
-		return int32(tunnels.RoomTunnelType), nil
-	default:
-		return int32(tunnels.PlayerTunnelType), nil
-	}
+	return int32(s.backends.TunnelType(mod)), nil
 }
 
+// CreateTunnel fails fast while the circuit breaker of the backend is open, and gives up the creation after the timeout.
+// the tunnel has its own context, which is canceled when the tunnel is stopped or the creation fails
 func (s *Service) CreateTunnel(ctx context.Context, ss xnet.Session, tp int32, oid int64, worker tunnel.Worker) (tunnel.Tunnel, error) {
-	tunnel, err := s.createAppTunnel(ctx, ss, tp, oid, worker)
+	cb := s.breakers.get(tp)
+	if cb != nil {
+		if err := cb.Allow(); err != nil {
+			return nil, errors.Wrapf(ErrBackendUnavailable, "type=%d uid=%d color=%s oid=%d", tp, ss.UID(), ss.Color(), oid)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	app, err := s.createAppTunnelWithTimeout(ctx, ss, tp, oid, worker)
 	if err != nil {
+		cancel()
+		if cb != nil {
+			cb.MarkFailed()
+		}
 		return nil, err
 	}
-	return tunnels.NewTunnel(ctx, worker, tunnel), nil
+	if cb != nil {
+		cb.MarkSuccess()
+	}
+	return tunnels.NewTunnel(ctx, cancel, worker, app, s, s), nil
+}
+
+// createAppTunnelWithTimeout returns after the timeout even if the stream is still being created.
+// the late stream is released by the caller canceling the context, and the late app tunnel is stopped when it is created
+func (s *Service) createAppTunnelWithTimeout(ctx context.Context, ss xnet.Session, tp int32, oid int64, worker tunnel.Worker) (tunnels.AppTunnel, error) {
+	app, err := createWithTimeout(s.createTimeout, fmt.Sprintf("%d-%d-%d", ss.UID(), tp, oid), func() (tunnels.AppTunnel, error) {
+		return s.createAppTunnel(ctx, ss, tp, oid, worker)
+	})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "type=%d uid=%d color=%s oid=%d", tp, ss.UID(), ss.Color(), oid)
+	}
+	return app, nil
+}
+
+func createWithTimeout(timeout time.Duration, name string, create func() (tunnels.AppTunnel, error)) (tunnels.AppTunnel, error) {
+	type result struct {
+		app tunnels.AppTunnel
+		err error
+	}
+
+	done := make(chan result, 1)
+	xsync.GoSafe("gate.Service.createTunnel-"+name, func() error {
+		app, err := create()
+		done <- result{app: app, err: err}
+		return nil
+	})
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-done:
+		return r.app, r.err
+	case <-timer.C:
+		xsync.GoSafe("gate.Service.drainTunnel-"+name, func() error {
+			if r := <-done; r.app != nil {
+				r.app.OnStop()
+			}
+			return nil
+		})
+		return nil, errors.Wrapf(ErrCreateTimeout, "timeout=%s", timeout)
+	}
 }
 
 func (s *Service) createAppTunnel(ctx context.Context, ss xnet.Session, tp int32, oid int64, worker tunnel.Worker) (tunnels.AppTunnel, error) {
 	switch tunnels.TunnelType(tp) {
 	case tunnels.PlayerTunnelType:
-		return player.NewTunnel(ctx, s.playerClient, ss, s.logger, s.playerRT, worker)
+		cli := s.playerClient
+		if m := s.backends.BuiltinMux(tunnels.PlayerTunnelType); m != nil {
+			cli = player.NewMuxClient(m, ss)
+		}
+		return player.NewTunnel(ctx, cli, ss, s.logger, s.playerRT, worker, s)
 	case tunnels.RoomTunnelType:
-		return room.NewTunnel(ctx, oid, s.roomClient, ss, s.logger)
+		cli := s.roomClient
+		if m := s.backends.BuiltinMux(tunnels.RoomTunnelType); m != nil {
+			cli = room.NewMuxClient(m, oid, ss)
+		}
+		return room.NewTunnel(ctx, oid, cli, ss, s.logger, worker)
+	}
 
-	default:
-		return nil, errors.Errorf("TunnelType invalid. TunnelType=%d uid=%d color=%s oid=%d", tp, ss.UID(), ss.Color(), oid)
+	b, ok := s.backends.Get(tunnels.TunnelType(tp))
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownModule, "TunnelType invalid. TunnelType=%d uid=%d color=%s oid=%d", tp, ss.UID(), ss.Color(), oid)
+	}
+	if b.Mux != nil {
+		return mux.NewTunnel(ctx, b.Mux, b.Type, oid, ss, s.logger, worker, b.Main)
 	}
+	return generic.NewTunnel(ctx, b, oid, ss, s.logger, worker)
 }
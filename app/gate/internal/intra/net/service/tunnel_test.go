@@ -0,0 +1,49 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+)
+
+type lateTunnel struct {
+	tunnels.AppTunnel
+
+	stopped chan struct{}
+}
+
+func (t *lateTunnel) OnStop() {
+	close(t.stopped)
+}
+
+func TestCreateWithTimeout(t *testing.T) {
+	app := &lateTunnel{stopped: make(chan struct{})}
+	got, err := createWithTimeout(time.Millisecond*10, "test", func() (tunnels.AppTunnel, error) {
+		return app, nil
+	})
+	assert.NoError(t, err)
+	assert.Same(t, app, got)
+
+	late := &lateTunnel{stopped: make(chan struct{})}
+	release := make(chan struct{})
+	_, err = createWithTimeout(time.Millisecond*10, "test", func() (tunnels.AppTunnel, error) {
+		<-release
+		return late, nil
+	})
+	assert.True(t, errors.Is(err, ErrCreateTimeout))
+
+	close(release)
+	select {
+	case <-late.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the tunnel created after the timeout is not stopped")
+	}
+	select {
+	case <-app.stopped:
+		t.Fatal("the tunnel created in time is stopped")
+	default:
+	}
+}
@@ -0,0 +1,264 @@
+package topic
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	servicev1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	tcp "github.com/vulcan-frame/vulcan-gate/pkg/net/tcp/server"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	"google.golang.org/protobuf/proto"
+)
+
+var ProviderSet = wire.NewSet(NewHub)
+
+const (
+	defaultChannel          = "gate:topics"
+	defaultBatchInterval    = time.Millisecond * 20
+	defaultBatchSize        = 64
+	defaultSubscribeTimeout = time.Second * 3
+	defaultPublishTimeout   = time.Second
+	defaultMaxPending       = 4096
+)
+
+var ErrHubBusy = errors.New("topic hub is busy")
+
+// the topics are not labeled, they are named by the business and may be as many as the guilds
+var (
+	publishedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "vulcan",
+		Subsystem: "gate",
+		Name:      "topic_published_total",
+		Help:      "messages published to the topics on this gate",
+	})
+	deliveredCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "vulcan",
+		Subsystem: "gate",
+		Name:      "topic_delivered_total",
+		Help:      "messages pushed to the subscribers of the topics on this gate, one per subscriber",
+	})
+	droppedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "vulcan",
+		Subsystem: "gate",
+		Name:      "topic_dropped_total",
+		Help:      "messages of the topics dropped because the redis publish failed",
+	})
+)
+
+// subscriber is implemented by both the redis client and the cluster client
+type subscriber interface {
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+}
+
+// Hub fans out the topic messages to the subscribers on all gates.
+// the messages published on this gate are batched by topic and sent to the redis channel shared by all gates,
+// every gate receives the batches from the channel and pushes them to the local subscribers of the topic
+type Hub struct {
+	log    *log.Helper
+	server *tcp.Server
+
+	rdb           redis.Cmdable // nil in the local mode
+	pubsub        *redis.PubSub
+	channel       string
+	batchInterval time.Duration
+	batchSize     int
+	maxPending    int
+
+	mu      sync.Mutex
+	pending map[string][]*servicev1.PushBody
+	size    int // the bodies in the pending batches
+
+	full chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewHub(c *conf.Topics, logger log.Logger, d *data.Data, ts *tcp.Server) (*Hub, func(), error) {
+	if c == nil {
+		c = &conf.Topics{}
+	}
+
+	h := &Hub{
+		log:           log.NewHelper(log.With(logger, "module", "gate/topic")),
+		server:        ts,
+		channel:       defaultChannel,
+		batchInterval: defaultBatchInterval,
+		batchSize:     defaultBatchSize,
+		maxPending:    defaultMaxPending,
+		pending:       make(map[string][]*servicev1.PushBody),
+		full:          make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	if c.Channel != "" {
+		h.channel = c.Channel
+	}
+	if c.BatchInterval != nil {
+		h.batchInterval = c.BatchInterval.AsDuration()
+	}
+	if c.BatchSize > 0 {
+		h.batchSize = int(c.BatchSize)
+	}
+	if c.MaxPending > 0 {
+		h.maxPending = int(c.MaxPending)
+	}
+
+	if !c.Local {
+		sub, ok := d.Rdb.(subscriber)
+		if !ok {
+			return nil, nil, errors.Errorf("redis client does not support pub/sub. client=%T", d.Rdb)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), defaultSubscribeTimeout)
+		defer cancel()
+
+		h.pubsub = sub.Subscribe(ctx, h.channel)
+		if _, err := h.pubsub.Receive(ctx); err != nil {
+			_ = h.pubsub.Close()
+			return nil, nil, errors.Wrapf(err, "topic channel subscribe failed. channel=%s", h.channel)
+		}
+		h.rdb = d.Rdb
+
+		xsync.GoSafe("gate.topic.receive", func() error {
+			return h.receive()
+		})
+	}
+
+	xsync.GoSafe("gate.topic.flush", func() error {
+		return h.loop()
+	})
+
+	cleanup := func() {
+		close(h.stop)
+		<-h.done
+		if h.pubsub != nil {
+			_ = h.pubsub.Close()
+		}
+	}
+	return h, cleanup, nil
+}
+
+// Publish queues the bodies to the batch of the topic, they are pushed to the subscribers on all gates after the batch is flushed.
+// it fails with ErrHubBusy if the pending bodies exceed the max pending, e.g. while redis is slow
+func (h *Hub) Publish(ctx context.Context, topic string, bodies []*servicev1.PushBody) error {
+	if err := Validate(topic); err != nil {
+		return err
+	}
+	if len(bodies) == 0 {
+		return nil
+	}
+
+	h.mu.Lock()
+	if h.size+len(bodies) > h.maxPending {
+		h.mu.Unlock()
+		return errors.Wrapf(ErrHubBusy, "topic=%s bodies=%d max_pending=%d", topic, len(bodies), h.maxPending)
+	}
+	h.pending[topic] = append(h.pending[topic], bodies...)
+	h.size += len(bodies)
+	full := len(h.pending[topic]) >= h.batchSize
+	h.mu.Unlock()
+
+	publishedCounter.Add(float64(len(bodies)))
+
+	if full {
+		select {
+		case h.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (h *Hub) loop() error {
+	defer close(h.done)
+
+	ticker := time.NewTicker(h.batchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.stop:
+			h.flush()
+			return nil
+		case <-ticker.C:
+			h.flush()
+		case <-h.full:
+			h.flush()
+		}
+	}
+}
+
+// flush sends the batches of all topics, one redis publish per topic.
+// each publish is bounded by the publish timeout, so a slow redis does not hold the flushing loop
+func (h *Hub) flush() {
+	h.mu.Lock()
+	if len(h.pending) == 0 {
+		h.mu.Unlock()
+		return
+	}
+	pending := h.pending
+	h.pending = make(map[string][]*servicev1.PushBody, len(pending))
+	h.size = 0
+	h.mu.Unlock()
+
+	for topic, bodies := range pending {
+		batch := &servicev1.PublishRequest{Topic: topic, Bodies: bodies}
+		if h.rdb == nil {
+			h.deliver(context.Background(), batch)
+			continue
+		}
+
+		if err := h.publish(batch); err != nil {
+			droppedCounter.Add(float64(len(bodies)))
+			h.log.Errorf("[topic.Hub] batch publish failed. topic=%s bodies=%d %+v", topic, len(bodies), err)
+		}
+	}
+}
+
+func (h *Hub) publish(batch *servicev1.PublishRequest) error {
+	payload, err := proto.Marshal(batch)
+	if err != nil {
+		return errors.Wrap(err, "batch encode failed")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultPublishTimeout)
+	defer cancel()
+	return h.rdb.Publish(ctx, h.channel, payload).Err()
+}
+
+// receive pushes the batches published by all gates, the pubsub reconnects and resubscribes by itself until it is closed
+func (h *Hub) receive() error {
+	for msg := range h.pubsub.Channel() {
+		batch := &servicev1.PublishRequest{}
+		if err := proto.Unmarshal([]byte(msg.Payload), batch); err != nil {
+			h.log.Errorf("[topic.Hub] batch decode failed. channel=%s %+v", msg.Channel, err)
+			continue
+		}
+		h.deliver(context.Background(), batch)
+	}
+	return nil
+}
+
+func (h *Hub) deliver(ctx context.Context, batch *servicev1.PublishRequest) {
+	tag := Tag(batch.Topic)
+	for _, body := range batch.Bodies {
+		count, err := h.server.PushByTag(ctx, tag, func(ss xnet.Session) ([]byte, error) {
+			return tunnels.Pack(body)
+		}, tunnels.PushOptions(body))
+		if err != nil {
+			h.log.Errorf("[topic.Hub] push failed. topic=%s mod=%d seq=%d %+v", batch.Topic, body.Mod, body.Seq, err)
+		}
+		deliveredCounter.Add(float64(count))
+	}
+}
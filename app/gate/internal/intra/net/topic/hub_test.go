@@ -0,0 +1,51 @@
+package topic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	servicev1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+)
+
+type fakePublisher struct {
+	redis.Cmdable
+
+	deadlines []time.Time
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+	deadline, _ := ctx.Deadline()
+	p.deadlines = append(p.deadlines, deadline)
+	return redis.NewIntResult(1, nil)
+}
+
+func TestHubBounded(t *testing.T) {
+	rdb := &fakePublisher{}
+	h := &Hub{
+		log:        log.NewHelper(log.DefaultLogger),
+		rdb:        rdb,
+		channel:    defaultChannel,
+		batchSize:  defaultBatchSize,
+		maxPending: 3,
+		pending:    make(map[string][]*servicev1.PushBody),
+		full:       make(chan struct{}, 1),
+	}
+	ctx := context.Background()
+	body := &servicev1.PushBody{}
+
+	assert.Nil(t, h.Publish(ctx, "world_chat", []*servicev1.PushBody{body, body}))
+	assert.ErrorIs(t, h.Publish(ctx, "news", []*servicev1.PushBody{body, body}), ErrHubBusy)
+	assert.Nil(t, h.Publish(ctx, "news", []*servicev1.PushBody{body}))
+
+	h.flush()
+	assert.Len(t, rdb.deadlines, 2)
+	for _, deadline := range rdb.deadlines {
+		assert.False(t, deadline.IsZero(), "the publish is bounded by the timeout")
+	}
+
+	assert.Nil(t, h.Publish(ctx, "news", []*servicev1.PushBody{body, body, body}), "the flushed bodies are not pending")
+}
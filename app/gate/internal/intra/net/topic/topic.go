@@ -0,0 +1,85 @@
+package topic
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+)
+
+const (
+	// TagPrefix marks the session tags which are the topic subscriptions, so the subscribers are looked up by the tag index
+	TagPrefix = "topic:"
+
+	// MaxTopicsPerSession limits the topics subscribed by the client itself, the backend is not limited
+	MaxTopicsPerSession = 32
+
+	maxNameLen = 64
+)
+
+// Tag returns the session tag of the topic
+func Tag(topic string) string {
+	return TagPrefix + topic
+}
+
+// Tags validates the topics and returns their session tags
+func Tags(topics []string) ([]string, error) {
+	tags := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		if err := Validate(topic); err != nil {
+			return nil, err
+		}
+		tags = append(tags, Tag(topic))
+	}
+	return tags, nil
+}
+
+// Count returns the topics subscribed in the session tags
+func Count(tags []string) (count int) {
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, TagPrefix) {
+			count++
+		}
+	}
+	return
+}
+
+// ACL limits the topics which the clients subscribe by themselves to the allowed prefixes,
+// so the private topics such as the guild chats are subscribed by the backend only
+type ACL struct {
+	prefixes []string
+}
+
+// NewACL returns the ACL of the allowed prefixes, no prefix allows all topics
+func NewACL(c *conf.Topics) *ACL {
+	return &ACL{prefixes: c.GetAllowedPrefixes()}
+}
+
+// Allow reports whether the client may subscribe the topic by itself
+func (a *ACL) Allow(topic string) bool {
+	if len(a.prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range a.prefixes {
+		if strings.HasPrefix(topic, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the topic name, it is made of letters, digits and _ - . : so the topic tag can be used in the tag expressions
+func Validate(topic string) error {
+	if len(topic) == 0 || len(topic) > maxNameLen {
+		return errors.Errorf("topic invalid. length must be 1-%d topic=%q", maxNameLen, topic)
+	}
+	for _, c := range topic {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '_', c == '-', c == '.', c == ':':
+		default:
+			return errors.Errorf("topic invalid. unexpected %q topic=%q", c, topic)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package topic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+)
+
+func TestTags(t *testing.T) {
+	tags, err := Tags([]string{"world_chat", "event.banner", "guild:1"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"topic:world_chat", "topic:event.banner", "topic:guild:1"}, tags)
+
+	for _, topic := range []string{"", "a b", "a&&b", "!a", "(a)", strings.Repeat("a", maxNameLen+1)} {
+		_, err = Tags([]string{"world_chat", topic})
+		assert.NotNil(t, err, topic)
+	}
+}
+
+func TestCount(t *testing.T) {
+	assert.Equal(t, 0, Count(nil))
+	assert.Equal(t, 2, Count([]string{"guild:1", "topic:world_chat", "region:eu", "topic:event.banner"}))
+}
+
+func TestACL(t *testing.T) {
+	acl := NewACL(nil)
+	assert.True(t, acl.Allow("guild:1"))
+
+	acl = NewACL(&conf.Topics{AllowedPrefixes: []string{"world_chat", "event."}})
+	assert.True(t, acl.Allow("world_chat"))
+	assert.True(t, acl.Allow("event.banner"))
+	assert.False(t, acl.Allow("guild:1"))
+	assert.False(t, acl.Allow("event"))
+}
@@ -47,6 +47,16 @@ func (t *Tunnel) Color() string {
 	return t.session.Color()
 }
 
+// IsMain returns false by default, the main tunnel overrides it
+func (t *Tunnel) IsMain() bool {
+	return false
+}
+
+// IsPinned returns false by default, the tunnel which must outlive the idle timeout overrides it
+func (t *Tunnel) IsPinned() bool {
+	return false
+}
+
 func (t *Tunnel) Session() net.Session {
 	return t.session
 }
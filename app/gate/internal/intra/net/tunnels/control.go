@@ -0,0 +1,25 @@
+package tunnels
+
+import (
+	"context"
+
+	controlv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/control/v1"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+)
+
+// ControlMod is the reserved module of the control messages sent by the backend on the player tunnel stream.
+// it is out of the range of the client modules, so the control messages are handled by the gate and never pushed to the client
+const ControlMod = int32(controlv1.Module_MODULE_CONTROL)
+
+// TagsKey is the header key of the player tunnel stream, the backend attaches the session tags by it when the stream is opened
+const TagsKey = "x-session-tags"
+
+// Controller handles the control messages, it lets the backend open or close the tunnels, kick the session and set the session tags
+type Controller interface {
+	Control(ctx context.Context, ss net.Session, worker tunnel.Worker, ctrl *controlv1.Control) error
+}
+
+func IsControl(msg tunnel.ForwardMessage) bool {
+	return msg.GetMod() == ControlMod
+}
@@ -0,0 +1,15 @@
+package tunnels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
+	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+)
+
+func TestIsControl(t *testing.T) {
+	assert.True(t, IsControl(&clipkt.Packet{Mod: ControlMod}))
+	assert.False(t, IsControl(&clipkt.Packet{Mod: int32(climod.ModuleID_ModuleUnknown)}), "a client message of module 0 is not a control message")
+	assert.False(t, IsControl(&clipkt.Packet{Mod: int32(climod.ModuleID_Room)}))
+}
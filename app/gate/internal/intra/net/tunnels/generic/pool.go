@@ -0,0 +1,45 @@
+package generic
+
+import (
+	"sync"
+
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/player/intra/v1"
+)
+
+type message = intrav1.Message
+
+type messagePool struct {
+	pool sync.Pool
+}
+
+func newMessagePool() *messagePool {
+	return &messagePool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return new(message)
+			},
+		},
+	}
+}
+
+func (p *messagePool) get() *message {
+	return p.pool.Get().(*message)
+}
+
+func (p *messagePool) put(msg *message) {
+	if msg == nil {
+		return
+	}
+	msg.Reset()
+	p.pool.Put(msg)
+}
+
+var globalPool = newMessagePool()
+
+func getMessage() *message {
+	return globalPool.get()
+}
+
+func putMessage(msg *message) {
+	globalPool.put(msg)
+}
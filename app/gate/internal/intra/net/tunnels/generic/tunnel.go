@@ -0,0 +1,102 @@
+package generic
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/backend"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/base"
+	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	"github.com/vulcan-frame/vulcan-pkg-tool/time"
+	"google.golang.org/grpc"
+)
+
+var _ tunnels.AppTunnel = (*Tunnel)(nil)
+
+var streamDesc = &grpc.StreamDesc{
+	StreamName:    "Tunnel",
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+// Tunnel is the bidi-stream tunnel to the backend declared in the config.
+// all the tunnel services share the same message schema, so the player message is used on the wire
+type Tunnel struct {
+	*base.Tunnel
+
+	backend *backend.Backend
+	worker  tunnel.Worker
+	stream  grpc.ClientStream
+}
+
+func NewTunnel(ctx context.Context, b *backend.Backend, oid int64, ss net.Session, logger log.Logger, worker tunnel.Worker) (*Tunnel, error) {
+	stream, err := b.Conn.NewStream(ctx, streamDesc, b.Method)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get tunnel stream failed. name=%s uid=%d color=%s oid=%d", b.Name, ss.UID(), ss.Color(), oid)
+	}
+
+	if b.Main {
+		worker.Reset()
+	}
+
+	t := &Tunnel{
+		Tunnel:  base.NewTunnel(b.Type, oid, ss, logger),
+		backend: b,
+		worker:  worker,
+		stream:  stream,
+	}
+	return t, nil
+}
+
+// TransformMessage transform the packet to the forward message
+// the forward message is pooled, so it should be put back to the pool after use on [Tunnel.CSHandle]
+func (t *Tunnel) TransformMessage(p *clipkt.Packet) (to tunnel.ForwardMessage) {
+	msg := getMessage()
+	msg.Mod = p.Mod
+	msg.Seq = p.Seq
+	msg.Obj = p.Obj
+	msg.Data = p.Data
+	msg.DataVersion = p.DataVersion
+	return msg
+}
+
+// CSHandle send the message to the service
+// the parameter [msg] is pooled, so it will be put back to the pool on the end of the function
+func (t *Tunnel) CSHandle(msg tunnel.ForwardMessage) error {
+	m := msg.(*message)
+	defer putMessage(m)
+	if err := t.stream.SendMsg(m); err != nil {
+		return errors.Wrapf(err, "stream send failed. name=%s", t.backend.Name)
+	}
+	return nil
+}
+
+func (t *Tunnel) SCHandle() (tunnel.ForwardMessage, error) {
+	out := new(message)
+	if err := t.stream.RecvMsg(out); err != nil {
+		return nil, errors.Wrapf(tunnels.StreamError(err, t.stream.Trailer(), t.backend.Main), "stream receive failed. name=%s", t.backend.Name)
+	}
+	return out, nil
+}
+
+func (t *Tunnel) OnStop() {
+	if err := t.stream.CloseSend(); err != nil {
+		t.Log().Errorf("[generic.Tunnel] stream close failed. name=%s uid=%d color=%s oid=%d %+v", t.backend.Name, t.UID(), t.Color(), t.OID(), err)
+	}
+}
+
+func (t *Tunnel) IsMain() bool {
+	return t.backend.Main
+}
+
+// OnGroupStop closes the worker if the tunnel is the main one
+func (t *Tunnel) OnGroupStop(ctx context.Context, err error) {
+	if t.backend.Main {
+		t.worker.SetExpiryTime(time.Now())
+	}
+	t.Log().Debugf("[generic.Tunnel] tunnel group exit. name=%s uid=%d color=%s oid=%d %+v", t.backend.Name, t.UID(), t.Color(), t.OID(), err)
+}
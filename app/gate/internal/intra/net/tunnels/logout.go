@@ -0,0 +1,76 @@
+package tunnels
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// LogoutReasonKey is the trailer key for the backend to state the logout reason when it ends the stream.
+// the value is the name of the SCServerLogout code, e.g. Banned, KickedOut or Waiting
+const LogoutReasonKey = "x-logout-reason"
+
+// Logouter sends the logout message to the client and closes the session
+type Logouter interface {
+	Logout(ctx context.Context, ss net.Session, worker tunnel.Worker, code net.LogoutCode)
+}
+
+// LogoutError is returned by SCHandle when the backend ends the stream to close the session
+type LogoutError struct {
+	Code net.LogoutCode
+	err  error
+}
+
+func (e *LogoutError) Error() string {
+	return "logout by backend. code=" + climsg.SCServerLogout_Code(e.Code).String() + " " + e.err.Error()
+}
+
+func (e *LogoutError) Unwrap() error {
+	return e.err
+}
+
+// StreamError wraps the receive error of the stream into a LogoutError if the backend ends the stream with a logout reason.
+// the trailer with the LogoutReasonKey closes the session from any tunnel, e.g. Waiting for the maintenance.
+// without the trailer, PermissionDenied means Banned and Aborted means KickedOut only on the main tunnel,
+// an auxiliary backend may use them for its own requests
+func StreamError(err error, trailer metadata.MD, main bool) error {
+	if err == nil {
+		return nil
+	}
+
+	if vs := trailer.Get(LogoutReasonKey); len(vs) > 0 {
+		if code, ok := climsg.SCServerLogout_Code_value[vs[0]]; ok {
+			return &LogoutError{Code: net.LogoutCode(code), err: err}
+		}
+	}
+	if !main {
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.PermissionDenied:
+		return &LogoutError{Code: net.LogoutCodeBanned, err: err}
+	case codes.Aborted:
+		return &LogoutError{Code: net.LogoutCodeKickedOut, err: err}
+	}
+	return err
+}
+
+// IsLogout returns the logout code if the error is a LogoutError
+func IsLogout(err error) (net.LogoutCode, bool) {
+	var le *LogoutError
+	if errors.As(err, &le) {
+		return le.Code, true
+	}
+	return 0, false
+}
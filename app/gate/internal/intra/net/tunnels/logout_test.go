@@ -0,0 +1,40 @@
+package tunnels
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestStreamError(t *testing.T) {
+	cases := []struct {
+		err     error
+		trailer metadata.MD
+		main    bool
+		code    net.LogoutCode
+		logout  bool
+	}{
+		{status.Error(codes.PermissionDenied, "banned"), nil, true, net.LogoutCodeBanned, true},
+		{status.Error(codes.Aborted, "kicked"), nil, true, net.LogoutCodeKickedOut, true},
+		{status.Error(codes.PermissionDenied, "not the leader"), nil, false, 0, false},
+		{status.Error(codes.Aborted, "match aborted"), nil, false, 0, false},
+		{status.Error(codes.Unavailable, "transport is closing"), nil, true, 0, false},
+		{status.Error(codes.Unavailable, "unrelated trailer"), metadata.Pairs("x-trace-id", "1"), true, 0, false},
+		{status.Error(codes.Unavailable, "maintenance"), metadata.Pairs(LogoutReasonKey, "Waiting"), false, net.LogoutCodeWaiting, true},
+		{status.Error(codes.Unknown, "closed"), metadata.Pairs(LogoutReasonKey, "KickedOut"), false, net.LogoutCodeKickedOut, true},
+		{status.Error(codes.Internal, "panic"), metadata.Pairs(LogoutReasonKey, "Unknown"), true, 0, false},
+		{errors.New("EOF"), nil, true, 0, false},
+	}
+
+	for _, c := range cases {
+		err := errors.Wrap(StreamError(c.err, c.trailer, c.main), "stream receive failed")
+		code, ok := IsLogout(err)
+		assert.Equal(t, c.logout, ok, c.err.Error())
+		assert.Equal(t, c.code, code, c.err.Error())
+	}
+}
@@ -0,0 +1,115 @@
+package mux
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	muxv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/mux/v1"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	vctx "github.com/vulcan-frame/vulcan-gate/pkg/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Codec copies the fields between the tunnel message of a built-in backend and the envelope
+type Codec[M any] struct {
+	Encode func(msg *M, env *muxv1.Envelope)
+	Decode func(env *muxv1.Envelope, msg *M)
+}
+
+// Client opens the tunnel of a built-in backend as a session on the shared stream,
+// so the built-in tunnels keep their own stream handling, e.g. the reconnection of the player tunnel
+type Client[M any] struct {
+	mux   *Mux
+	tp    int32
+	oid   int64
+	ss    net.Session
+	codec Codec[M]
+}
+
+func NewClient[M any](m *Mux, tp int32, oid int64, ss net.Session, codec Codec[M]) *Client[M] {
+	return &Client[M]{
+		mux:   m,
+		tp:    tp,
+		oid:   oid,
+		ss:    ss,
+		codec: codec,
+	}
+}
+
+// Tunnel opens a session, the reattach attempt in the context is passed to the backend on OPEN.
+// the session is closed when the context is done
+func (c *Client[M]) Tunnel(ctx context.Context, _ ...grpc.CallOption) (grpc.BidiStreamingClient[M, M], error) {
+	sess, err := c.mux.open(ctx, c.tp, c.oid, c.ss, vctx.Reattach(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &clientStream[M]{ctx: ctx, session: sess, codec: c.codec}, nil
+}
+
+type clientStream[M any] struct {
+	ctx     context.Context
+	session *session
+	codec   Codec[M]
+}
+
+func (s *clientStream[M]) Send(msg *M) error {
+	env := getEnvelope()
+	defer putEnvelope(env)
+
+	env.Kind = muxv1.Envelope_MESSAGE
+	s.codec.Encode(msg, env)
+	return s.session.send(env)
+}
+
+func (s *clientStream[M]) Recv() (*M, error) {
+	msg := new(M)
+	if err := s.RecvMsg(msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Header returns an empty header, the session tags are not carried by the mux stream
+func (s *clientStream[M]) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (s *clientStream[M]) Trailer() metadata.MD {
+	return nil
+}
+
+func (s *clientStream[M]) CloseSend() error {
+	return s.session.close()
+}
+
+func (s *clientStream[M]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *clientStream[M]) SendMsg(m any) error {
+	msg, ok := m.(*M)
+	if !ok {
+		return errors.Errorf("mux client send message type invalid. type=%T", m)
+	}
+	return s.Send(msg)
+}
+
+func (s *clientStream[M]) RecvMsg(m any) error {
+	msg, ok := m.(*M)
+	if !ok {
+		return errors.Errorf("mux client receive message type invalid. type=%T", m)
+	}
+
+	select {
+	case env := <-s.session.scChan:
+		s.codec.Decode(env, msg)
+		return nil
+	case <-s.session.done:
+		return s.session.err
+	case <-s.ctx.Done():
+		_ = s.session.close()
+		return status.FromContextError(s.ctx.Err()).Err()
+	}
+}
@@ -0,0 +1,282 @@
+package mux
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/metadata"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
+	"github.com/go-kratos/kratos/v2/registry"
+	kgrpc "github.com/go-kratos/kratos/v2/transport/grpc"
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-pkg-app/router/routetable"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc"
+)
+
+const FullMethodName = "/gate.api.mux.v1.MuxTunnelService/Tunnel"
+
+var streamDesc = &grpc.StreamDesc{
+	StreamName:    "Tunnel",
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+// Mux keeps a few shared streams per backend node, the sessions are spread over the streams by the oid.
+// the node of a session is resolved by the route table, or picked randomly from the discovery if there is no route.
+// the dials and the stream openings of the same node and slot are merged, the different ones run concurrently.
+// a node without any live stream is pruned, and dialed again on demand
+type Mux struct {
+	log        *log.Helper
+	name       string
+	service    string
+	size       int
+	routeTable routetable.RouteTable
+	discovery  registry.Discovery
+
+	dialing singleflight.Group
+	opening singleflight.Group
+
+	mu     sync.Mutex
+	nodes  map[string]*node
+	closed bool
+}
+
+type node struct {
+	addr string
+	conn *grpc.ClientConn
+
+	mu      sync.Mutex
+	streams []*stream
+	pruned  bool
+}
+
+func NewMux(name, service string, size int, rt routetable.RouteTable, r registry.Discovery, logger log.Logger) *Mux {
+	return &Mux{
+		log:        log.NewHelper(log.With(logger, "module", "gate/tunnel/mux/"+name)),
+		name:       name,
+		service:    service,
+		size:       size,
+		routeTable: rt,
+		discovery:  r,
+		nodes:      make(map[string]*node),
+	}
+}
+
+// stream returns the shared stream for the session, the stream is opened on demand
+func (m *Mux) stream(ctx context.Context, color string, oid int64) (*stream, error) {
+	addr, err := m.resolve(ctx, color, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := m.node(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := int(uint64(oid) % uint64(m.size))
+	if s := n.get(slot); s != nil {
+		return s, nil
+	}
+
+	v, err, _ := m.opening.Do(fmt.Sprintf("%s/%d", addr, slot), func() (interface{}, error) {
+		if s := n.get(slot); s != nil {
+			return s, nil
+		}
+		s, err := newStream(m, n, slot)
+		if err != nil {
+			m.prune(n)
+			return nil, err
+		}
+		if !n.set(slot, s) {
+			s.close()
+			return nil, errors.Wrapf(ErrStreamBroken, "node pruned. name=%s addr=%s", m.name, addr)
+		}
+		return s, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*stream), nil
+}
+
+// node returns the node of the address, it is dialed on demand
+func (m *Mux) node(addr string) (*node, error) {
+	m.mu.Lock()
+	n, ok := m.nodes[addr]
+	m.mu.Unlock()
+	if ok {
+		return n, nil
+	}
+
+	v, err, _ := m.dialing.Do(addr, func() (interface{}, error) {
+		m.mu.Lock()
+		if n, ok := m.nodes[addr]; ok {
+			m.mu.Unlock()
+			return n, nil
+		}
+		m.mu.Unlock()
+
+		n, err := m.dial(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.closed {
+			_ = n.conn.Close()
+			return nil, errors.Wrapf(ErrStreamBroken, "mux closed. name=%s", m.name)
+		}
+		m.nodes[addr] = n
+		return n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*node), nil
+}
+
+func (n *node) get(slot int) *stream {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if s := n.streams[slot]; s != nil && !s.isBroken() {
+		return s
+	}
+	return nil
+}
+
+// set returns false if the node is pruned meanwhile
+func (n *node) set(slot int, s *stream) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.pruned {
+		return false
+	}
+	n.streams[slot] = s
+	return true
+}
+
+func (m *Mux) resolve(ctx context.Context, color string, oid int64) (string, error) {
+	if m.routeTable != nil {
+		addr, err := m.routeTable.Get(ctx, color, oid)
+		if err != nil {
+			m.log.WithContext(ctx).Debugf("[mux.Mux] route table get failed, pick a random node. color=%s oid=%d %+v", color, oid, err)
+		}
+		if len(addr) > 0 {
+			return trimScheme(addr), nil
+		}
+	}
+
+	instances, err := m.discovery.GetService(ctx, m.service)
+	if err != nil {
+		return "", errors.Wrapf(err, "discovery get service failed. service=%s", m.service)
+	}
+	if len(instances) == 0 {
+		return "", errors.Errorf("no instance available. service=%s", m.service)
+	}
+
+	ins := instances[rand.Intn(len(instances))]
+	for _, ep := range ins.Endpoints {
+		if strings.HasPrefix(ep, "grpc://") {
+			return trimScheme(ep), nil
+		}
+	}
+	return "", errors.Errorf("no grpc endpoint. service=%s instance=%s", m.service, ins.ID)
+}
+
+func trimScheme(addr string) string {
+	if i := strings.Index(addr, "://"); i >= 0 {
+		return addr[i+3:]
+	}
+	return addr
+}
+
+func (m *Mux) dial(addr string) (*node, error) {
+	conn, err := kgrpc.DialInsecure(context.Background(),
+		kgrpc.WithEndpoint(addr),
+		kgrpc.WithMiddleware(
+			recovery.Recovery(),
+			metadata.Client(),
+			tracing.Client(),
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "dial backend node failed. name=%s addr=%s", m.name, addr)
+	}
+	return &node{
+		addr:    addr,
+		conn:    conn,
+		streams: make([]*stream, m.size),
+	}, nil
+}
+
+// remove drops the broken stream from its node, the next session on the slot opens a new one
+func (m *Mux) remove(s *stream) {
+	s.node.mu.Lock()
+	if s.node.streams[s.slot] == s {
+		s.node.streams[s.slot] = nil
+	}
+	s.node.mu.Unlock()
+
+	m.prune(s.node)
+}
+
+// prune drops the node and closes its conn if it has no live stream
+func (m *Mux) prune(n *node) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n.mu.Lock()
+	for _, s := range n.streams {
+		if s != nil && !s.isBroken() {
+			n.mu.Unlock()
+			return
+		}
+	}
+	if n.pruned {
+		n.mu.Unlock()
+		return
+	}
+	n.pruned = true
+	n.mu.Unlock()
+
+	if m.nodes[n.addr] == n {
+		delete(m.nodes, n.addr)
+	}
+	if err := n.conn.Close(); err != nil {
+		m.log.Errorf("[mux.Mux] conn close failed. addr=%s %+v", n.addr, err)
+	}
+	m.log.Infof("[mux.Mux] node pruned. addr=%s", n.addr)
+}
+
+func (m *Mux) Close() {
+	m.mu.Lock()
+	m.closed = true
+	nodes := m.nodes
+	m.nodes = make(map[string]*node)
+	m.mu.Unlock()
+
+	// the streams prune their nodes, so the conns are closed by the last stream
+	for _, n := range nodes {
+		n.mu.Lock()
+		streams := slices.Clone(n.streams)
+		n.mu.Unlock()
+
+		for _, s := range streams {
+			if s != nil {
+				s.close()
+			}
+		}
+		m.prune(n)
+	}
+}
@@ -0,0 +1,185 @@
+package mux
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	muxv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/mux/v1"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"google.golang.org/grpc"
+)
+
+type fakeDiscovery struct {
+	endpoint string
+}
+
+func (d *fakeDiscovery) GetService(ctx context.Context, name string) ([]*registry.ServiceInstance, error) {
+	return []*registry.ServiceInstance{{ID: "1", Name: name, Endpoints: []string{"grpc://" + d.endpoint}}}, nil
+}
+
+func (d *fakeDiscovery) Watch(ctx context.Context, name string) (registry.Watcher, error) {
+	return nil, nil
+}
+
+// startBackend serves the mux service which echoes the messages and counts the opened streams
+func startBackend(t *testing.T) (string, func() int) {
+	var (
+		mu     sync.Mutex
+		opened int
+	)
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		mu.Lock()
+		opened++
+		mu.Unlock()
+		for {
+			env := new(muxv1.Envelope)
+			if err := ss.RecvMsg(env); err != nil {
+				return nil
+			}
+			if env.Kind == muxv1.Envelope_MESSAGE {
+				if err := ss.SendMsg(env); err != nil {
+					return nil
+				}
+			}
+		}
+	}
+
+	s := grpc.NewServer()
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "gate.api.mux.v1.MuxTunnelService",
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "Tunnel",
+			Handler:       handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		}},
+	}, nil)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	return lis.Addr().String(), func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return opened
+	}
+}
+
+func TestMuxStreamMerged(t *testing.T) {
+	addr, opened := startBackend(t)
+	m := NewMux("test", "test.service", 2, nil, &fakeDiscovery{endpoint: addr}, log.DefaultLogger)
+	defer m.Close()
+
+	var wg sync.WaitGroup
+	streams := make([]*stream, 16)
+	for i := range streams {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s, err := m.stream(context.Background(), "", 2)
+			assert.Nil(t, err)
+			streams[i] = s
+		}(i)
+	}
+	wg.Wait()
+
+	for _, s := range streams {
+		assert.Same(t, streams[0], s)
+	}
+	other, err := m.stream(context.Background(), "", 3)
+	assert.Nil(t, err)
+	assert.NotSame(t, streams[0], other)
+
+	assert.Eventually(t, func() bool { return opened() == 2 }, time.Second, 10*time.Millisecond)
+	assert.Len(t, m.nodes, 1)
+}
+
+func TestMuxNodePruned(t *testing.T) {
+	addr, _ := startBackend(t)
+	m := NewMux("test", "test.service", 2, nil, &fakeDiscovery{endpoint: addr}, log.DefaultLogger)
+	defer m.Close()
+
+	s0, err := m.stream(context.Background(), "", 0)
+	require.Nil(t, err)
+	s1, err := m.stream(context.Background(), "", 1)
+	require.Nil(t, err)
+	n := s0.node
+
+	s0.close()
+	m.mu.Lock()
+	assert.Same(t, n, m.nodes[addr], "the node is kept while a stream is live")
+	m.mu.Unlock()
+
+	s1.close()
+	m.mu.Lock()
+	assert.Empty(t, m.nodes)
+	m.mu.Unlock()
+	assert.True(t, n.pruned)
+
+	s, err := m.stream(context.Background(), "", 0)
+	require.Nil(t, err)
+	assert.NotSame(t, n, s.node, "the node is dialed again")
+}
+
+func TestClientSession(t *testing.T) {
+	addr, _ := startBackend(t)
+	m := NewMux("test", "test.service", 1, nil, &fakeDiscovery{endpoint: addr}, log.DefaultLogger)
+	defer m.Close()
+
+	codec := Codec[muxv1.Envelope]{
+		Encode: func(msg *muxv1.Envelope, env *muxv1.Envelope) { env.Data = msg.Data },
+		Decode: func(env *muxv1.Envelope, msg *muxv1.Envelope) { msg.Data = env.Data },
+	}
+	cli := NewClient(m, 1, 1, &fakeSession{}, codec)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := cli.Tunnel(ctx)
+	require.Nil(t, err)
+
+	require.Nil(t, stream.Send(&muxv1.Envelope{Data: []byte("ping")}))
+	out, err := stream.Recv()
+	require.Nil(t, err)
+	assert.Equal(t, []byte("ping"), out.Data)
+
+	// canceling the context interrupts the blocked receiving, as a grpc stream does
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	_, err = stream.Recv()
+	assert.NotNil(t, err)
+}
+
+type fakeSession struct {
+	xnet.Session
+}
+
+func (s *fakeSession) UID() int64       { return 1 }
+func (s *fakeSession) SID() int64       { return 1 }
+func (s *fakeSession) Color() string    { return "" }
+func (s *fakeSession) Status() int64    { return 0 }
+func (s *fakeSession) ClientIP() string { return "" }
+
+func TestCloseError(t *testing.T) {
+	err := closeError(&muxv1.Envelope{Kind: muxv1.Envelope_CLOSE})
+	assert.ErrorIs(t, err, ErrClosedByBackend)
+	_, ok := tunnels.IsLogout(err)
+	assert.False(t, ok)
+
+	err = closeError(&muxv1.Envelope{Kind: muxv1.Envelope_CLOSE, LogoutReason: "Banned"})
+	assert.ErrorIs(t, err, ErrClosedByBackend)
+	code, ok := tunnels.IsLogout(err)
+	assert.True(t, ok)
+	assert.Equal(t, xnet.LogoutCodeBanned, code)
+}
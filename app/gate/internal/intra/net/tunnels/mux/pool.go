@@ -0,0 +1,25 @@
+package mux
+
+import (
+	"sync"
+
+	muxv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/mux/v1"
+)
+
+var envelopePool = sync.Pool{
+	New: func() interface{} {
+		return new(muxv1.Envelope)
+	},
+}
+
+func getEnvelope() *muxv1.Envelope {
+	return envelopePool.Get().(*muxv1.Envelope)
+}
+
+func putEnvelope(env *muxv1.Envelope) {
+	if env == nil {
+		return
+	}
+	env.Reset()
+	envelopePool.Put(env)
+}
@@ -0,0 +1,105 @@
+package mux
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	muxv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/mux/v1"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"go.uber.org/atomic"
+)
+
+const scChanSize = 1024
+
+var sessionIDGen = atomic.NewUint64(0)
+
+// session is a logical stream on the shared stream, the envelopes of the session are dispatched to its channel
+type session struct {
+	id     uint64
+	stream *stream
+
+	scChan   chan *muxv1.Envelope
+	done     chan struct{}
+	failOnce sync.Once
+	err      error
+}
+
+// open attaches a new session to the shared stream of the oid and sends the OPEN envelope with the session metadata.
+// the reattach is the attempt number when the session replaces a broken one of the same tunnel
+func (m *Mux) open(ctx context.Context, tp int32, oid int64, ss net.Session, reattach int) (*session, error) {
+	s, err := m.stream(ctx, ss.Color(), oid)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := &session{
+		id:     sessionIDGen.Inc(),
+		stream: s,
+		scChan: make(chan *muxv1.Envelope, scChanSize),
+		done:   make(chan struct{}),
+	}
+
+	s.attach(sess)
+	open := &muxv1.Envelope{
+		Kind:       muxv1.Envelope_OPEN,
+		SessionId:  sess.id,
+		Uid:        ss.UID(),
+		Oid:        oid,
+		Sid:        ss.SID(),
+		Color:      ss.Color(),
+		Status:     ss.Status(),
+		ClientIp:   ss.ClientIP(),
+		TunnelType: tp,
+		Reattach:   int32(reattach),
+	}
+	if err = s.send(open); err != nil {
+		s.detach(sess.id)
+		return nil, errors.WithMessagef(err, "mux session open failed. uid=%d color=%s oid=%d", ss.UID(), ss.Color(), oid)
+	}
+	return sess, nil
+}
+
+// send sends the envelope of the session, the session ID is set here
+func (s *session) send(env *muxv1.Envelope) error {
+	env.SessionId = s.id
+	return s.stream.send(env)
+}
+
+// recv returns the next envelope from the backend, or the error which closed the session
+func (s *session) recv() (*muxv1.Envelope, error) {
+	select {
+	case env := <-s.scChan:
+		return env, nil
+	case <-s.done:
+		return nil, s.err
+	}
+}
+
+// deliver is called by the receiving loop of the stream, it returns false if the session can not keep up
+func (s *session) deliver(env *muxv1.Envelope) bool {
+	select {
+	case s.scChan <- env:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *session) fail(err error) {
+	s.failOnce.Do(func() {
+		s.err = err
+		close(s.done)
+	})
+}
+
+// close detaches the session from the shared stream and tells the backend
+func (s *session) close() error {
+	s.stream.detach(s.id)
+	s.fail(ErrStreamBroken)
+
+	if err := s.stream.send(&muxv1.Envelope{Kind: muxv1.Envelope_CLOSE, SessionId: s.id}); err != nil && !errors.Is(err, ErrStreamBroken) {
+		return err
+	}
+	return nil
+}
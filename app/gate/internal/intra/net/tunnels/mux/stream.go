@@ -0,0 +1,148 @@
+package mux
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	muxv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/mux/v1"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	ErrStreamBroken    = errors.New("mux stream broken")
+	ErrClosedByBackend = errors.New("mux session closed by backend")
+	ErrSlowSession     = errors.New("mux session is too slow to receive")
+)
+
+// stream is a shared bidi stream to one backend node.
+// the sending is serialized, the receiving loop dispatches the envelopes to the sessions
+type stream struct {
+	mux    *Mux
+	node   *node
+	slot   int
+	cs     grpc.ClientStream
+	cancel context.CancelFunc
+	broken *atomic.Bool
+
+	sendLock sync.Mutex
+
+	mu       sync.RWMutex
+	sessions map[uint64]*session
+}
+
+func newStream(m *Mux, n *node, slot int) (*stream, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cs, err := n.conn.NewStream(ctx, streamDesc, FullMethodName)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "open mux stream failed. name=%s addr=%s slot=%d", m.name, n.addr, slot)
+	}
+
+	s := &stream{
+		mux:      m,
+		node:     n,
+		slot:     slot,
+		cs:       cs,
+		cancel:   cancel,
+		broken:   atomic.NewBool(false),
+		sessions: make(map[uint64]*session),
+	}
+
+	xsync.GoSafe(fmt.Sprintf("gate.mux.stream-%s-%s-%d", m.name, n.addr, slot), func() error {
+		return s.recvLoop()
+	})
+	return s, nil
+}
+
+func (s *stream) isBroken() bool {
+	return s.broken.Load()
+}
+
+func (s *stream) send(env *muxv1.Envelope) error {
+	if s.isBroken() {
+		return ErrStreamBroken
+	}
+
+	s.sendLock.Lock()
+	defer s.sendLock.Unlock()
+
+	if err := s.cs.SendMsg(env); err != nil {
+		return errors.Wrapf(err, "mux stream send failed. addr=%s slot=%d", s.node.addr, s.slot)
+	}
+	return nil
+}
+
+func (s *stream) attach(ss *session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[ss.id] = ss
+}
+
+func (s *stream) detach(sessionID uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+}
+
+func (s *stream) recvLoop() error {
+	for {
+		env := new(muxv1.Envelope)
+		if err := s.cs.RecvMsg(env); err != nil {
+			s.fail(errors.Wrapf(ErrStreamBroken, "addr=%s slot=%d %+v", s.node.addr, s.slot, err))
+			return nil
+		}
+
+		s.mu.RLock()
+		ss, ok := s.sessions[env.SessionId]
+		s.mu.RUnlock()
+		if !ok {
+			continue
+		}
+
+		if env.Kind == muxv1.Envelope_CLOSE {
+			s.detach(env.SessionId)
+			ss.fail(closeError(env))
+			continue
+		}
+		if !ss.deliver(env) {
+			s.detach(env.SessionId)
+			ss.fail(ErrSlowSession)
+		}
+	}
+}
+
+// closeError is the error of the session closed by the backend, the logout reason closes the client session as the trailer of a stream does
+func closeError(env *muxv1.Envelope) error {
+	if len(env.LogoutReason) == 0 {
+		return ErrClosedByBackend
+	}
+	return tunnels.StreamError(ErrClosedByBackend, metadata.Pairs(tunnels.LogoutReasonKey, env.LogoutReason), false)
+}
+
+// fail breaks the stream, and all the sessions on it are closed with the error
+func (s *stream) fail(err error) {
+	if !s.broken.CompareAndSwap(false, true) {
+		return
+	}
+	s.mux.remove(s)
+	s.cancel()
+
+	s.mu.Lock()
+	sessions := s.sessions
+	s.sessions = make(map[uint64]*session)
+	s.mu.Unlock()
+
+	for _, ss := range sessions {
+		ss.fail(err)
+	}
+}
+
+func (s *stream) close() {
+	s.fail(ErrStreamBroken)
+}
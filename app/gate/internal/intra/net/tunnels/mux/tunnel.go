@@ -0,0 +1,88 @@
+package mux
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	muxv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/mux/v1"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/base"
+	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	"github.com/vulcan-frame/vulcan-pkg-tool/time"
+)
+
+var _ tunnels.AppTunnel = (*Tunnel)(nil)
+
+// Tunnel is a session on the shared stream, it has the same behavior as the tunnel with its own stream
+type Tunnel struct {
+	*base.Tunnel
+
+	main    bool
+	worker  tunnel.Worker
+	session *session
+}
+
+func NewTunnel(ctx context.Context, m *Mux, tp tunnels.TunnelType, oid int64, ss net.Session, logger log.Logger, worker tunnel.Worker, main bool) (*Tunnel, error) {
+	sess, err := m.open(ctx, int32(tp), oid, ss, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Tunnel{
+		Tunnel:  base.NewTunnel(tp, oid, ss, logger),
+		main:    main,
+		worker:  worker,
+		session: sess,
+	}
+
+	if main {
+		worker.Reset()
+	}
+	return t, nil
+}
+
+// TransformMessage transform the packet to the forward message
+// the forward message is pooled, so it should be put back to the pool after use on [Tunnel.CSHandle]
+func (t *Tunnel) TransformMessage(p *clipkt.Packet) (to tunnel.ForwardMessage) {
+	env := getEnvelope()
+	env.Kind = muxv1.Envelope_MESSAGE
+	env.Mod = p.Mod
+	env.Seq = p.Seq
+	env.Obj = p.Obj
+	env.Data = p.Data
+	env.DataVersion = p.DataVersion
+	return env
+}
+
+// CSHandle send the message to the service
+// the parameter [msg] is pooled, so it will be put back to the pool on the end of the function
+func (t *Tunnel) CSHandle(msg tunnel.ForwardMessage) error {
+	env := msg.(*muxv1.Envelope)
+	defer putEnvelope(env)
+	return t.session.send(env)
+}
+
+func (t *Tunnel) SCHandle() (tunnel.ForwardMessage, error) {
+	return t.session.recv()
+}
+
+func (t *Tunnel) IsMain() bool {
+	return t.main
+}
+
+// OnStop detaches the session from the shared stream and tells the backend
+func (t *Tunnel) OnStop() {
+	if err := t.session.close(); err != nil {
+		t.Log().Errorf("[mux.Tunnel] session close failed. uid=%d color=%s oid=%d %+v", t.UID(), t.Color(), t.OID(), err)
+	}
+}
+
+// OnGroupStop closes the worker if the tunnel is the main one
+func (t *Tunnel) OnGroupStop(ctx context.Context, err error) {
+	if t.main {
+		t.worker.SetExpiryTime(time.Now())
+	}
+	t.Log().Debugf("[mux.Tunnel] tunnel group exit. uid=%d color=%s oid=%d session=%d %+v", t.UID(), t.Color(), t.OID(), t.session.id, err)
+}
@@ -2,35 +2,68 @@ package player
 
 import (
 	"context"
+	"sync"
+	gotime "time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/pkg/errors"
+	controlv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/control/v1"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/player"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/base"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/room"
 	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
 	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/player/intra/v1"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	vctx "github.com/vulcan-frame/vulcan-gate/pkg/net/context"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
 	"github.com/vulcan-frame/vulcan-pkg-app/router"
 	"github.com/vulcan-frame/vulcan-pkg-tool/time"
+	"google.golang.org/protobuf/proto"
 )
 
 var _ tunnels.AppTunnel = (*Tunnel)(nil)
 
+const (
+	// maxPendingSize is the max count of the cs messages buffered while the stream is being rebuilt
+	maxPendingSize = 256
+
+	minReconnectBackoff = 100 * gotime.Millisecond
+	maxReconnectBackoff = 3 * gotime.Second
+)
+
+var ErrPendingFull = errors.New("pending messages are full while reconnecting")
+
 // Tunnel is the player tunnel which is the main tunnel for the user
-// if the player tunnel is closed, the worker will be closed, and the client will be disconnected
+// if the stream is broken, it is rebuilt with backoff until the worker countdown expires,
+// and then the worker will be closed, and the client will be disconnected.
+// the control messages on the stream are handled by the controller instead of being pushed to the client
 type Tunnel struct {
 	*base.Tunnel
 
+	ctx        context.Context
+	cli        intrav1.TunnelServiceClient
 	worker     tunnel.Worker
 	routeTable *player.RouteTable
-	stream     intrav1.TunnelService_TunnelClient
+	controller tunnels.Controller
+
+	// sendMu serializes the Send and the CloseSend of the streams, grpc forbids calling them concurrently.
+	// it is taken before mu, and mu is never held over the network
+	sendMu sync.Mutex
+
+	mu           sync.Mutex
+	stream       intrav1.TunnelService_TunnelClient
+	cancel       context.CancelFunc // cancels the stream, so the receiver gets the error at once
+	reconnecting bool
+	headerRead   bool
+	pending      []*intrav1.Message
 }
 
-func NewTunnel(ctx context.Context, cli intrav1.TunnelServiceClient, ss net.Session, log log.Logger, rt *player.RouteTable, worker tunnel.Worker) (*Tunnel, error) {
-	stream, err := cli.Tunnel(ctx)
+func NewTunnel(ctx context.Context, cli intrav1.TunnelServiceClient, ss net.Session, log log.Logger, rt *player.RouteTable, worker tunnel.Worker, controller tunnels.Controller) (*Tunnel, error) {
+	sctx, cancel := context.WithCancel(ctx)
+	stream, err := cli.Tunnel(sctx)
 	if err != nil {
+		cancel()
 		return nil, errors.Wrapf(err, "get tunnel stream failed. uid=%d color=%s %+v", ss.UID(), ss.Color(), err)
 	}
 
@@ -39,9 +72,13 @@ func NewTunnel(ctx context.Context, cli intrav1.TunnelServiceClient, ss net.Sess
 
 	t := &Tunnel{
 		Tunnel:     base.NewTunnel(tunnels.PlayerTunnelType, ss.UID(), ss, log),
+		ctx:        ctx,
+		cli:        cli,
 		worker:     worker,
 		routeTable: rt,
+		controller: controller,
 		stream:     stream,
+		cancel:     cancel,
 	}
 	return t, nil
 }
@@ -59,22 +96,210 @@ func (t *Tunnel) TransformMessage(p *clipkt.Packet) (to tunnel.ForwardMessage) {
 }
 
 // CSHandle send the message to the service
-// the parameter [msg] is pooled, so it will be put back to the pool on the end of the function
+// the parameter [msg] is pooled, so it will be put back to the pool after it is sent.
+// the message is buffered while the stream is being rebuilt, and sent on the new stream.
+// a send failure cancels the stream, so the receiver rebuilds it even if its Recv is still blocked
 func (t *Tunnel) CSHandle(msg tunnel.ForwardMessage) error {
-	defer putMessage(msg.(*intrav1.Message))
-	if err := t.stream.Send(msg.(*intrav1.Message)); err != nil {
-		return errors.Wrapf(err, "stream send failed")
+	m := msg.(*intrav1.Message)
+
+	// the stream is only replaced with the send lock held, so it is the current one until the send returns
+	t.sendMu.Lock()
+	defer t.sendMu.Unlock()
+
+	t.mu.Lock()
+	if t.reconnecting {
+		defer t.mu.Unlock()
+		return t.appendPending(m)
 	}
+	stream := t.stream
+	t.mu.Unlock()
+
+	err := stream.Send(m)
+	if err == nil {
+		putMessage(m)
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.reconnecting {
+		t.Log().Infof("[player.Tunnel] stream send failed, reconnecting. uid=%d color=%s %+v", t.UID(), t.Color(), err)
+		t.reconnecting = true
+		t.cancel()
+	}
+	return t.appendPending(m)
+}
+
+func (t *Tunnel) appendPending(m *intrav1.Message) error {
+	if len(t.pending) >= maxPendingSize {
+		putMessage(m)
+		return errors.Wrapf(ErrPendingFull, "size=%d", len(t.pending))
+	}
+	t.pending = append(t.pending, m)
 	return nil
 }
 
 func (t *Tunnel) SCHandle() (tunnel.ForwardMessage, error) {
-	out, err := t.stream.Recv()
+	for {
+		t.mu.Lock()
+		stream := t.stream
+		t.mu.Unlock()
+
+		out, err := stream.Recv()
+		if err == nil {
+			t.readHeader(stream)
+			if tunnels.IsControl(out) {
+				t.control(out)
+				continue
+			}
+			// the room pushes may arrive on the player stream before the user has a room tunnel
+			room.Watch(t.Log(), t.Tunnel.Session(), t.worker, out)
+			return out, nil
+		}
+		// the backend closes the session on purpose, so the stream is not rebuilt
+		if err = tunnels.StreamError(err, stream.Trailer(), true); isLogout(err) {
+			return nil, errors.WithMessagef(err, "stream receive failed")
+		}
+		if err = t.reconnect(errors.Wrapf(err, "stream receive failed")); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readHeader applies the session tags in the stream header once per stream,
+// the header is already received when the first message is received, so it never blocks
+func (t *Tunnel) readHeader(stream intrav1.TunnelService_TunnelClient) {
+	t.mu.Lock()
+	if t.headerRead || stream != t.stream {
+		t.mu.Unlock()
+		return
+	}
+	t.headerRead = true
+	t.mu.Unlock()
+
+	md, err := stream.Header()
+	if err != nil {
+		t.Log().Errorf("[player.Tunnel] stream header read failed. uid=%d color=%s %+v", t.UID(), t.Color(), err)
+		return
+	}
+	if tags := md.Get(tunnels.TagsKey); len(tags) > 0 {
+		t.Session().UpdateTags(tags, nil)
+	}
+}
+
+func isLogout(err error) bool {
+	_, ok := tunnels.IsLogout(err)
+	return ok
+}
+
+// control handles the control message from the backend, the failure is logged and the stream is kept
+func (t *Tunnel) control(msg *intrav1.Message) {
+	ctrl := &controlv1.Control{}
+	if err := proto.Unmarshal(msg.Data, ctrl); err != nil {
+		t.Log().Errorf("[player.Tunnel] control unmarshal failed. uid=%d color=%s %+v", t.UID(), t.Color(), err)
+		return
+	}
+	if err := t.controller.Control(t.ctx, t.Tunnel.Session(), t.worker, ctrl); err != nil {
+		t.Log().Errorf("[player.Tunnel] control failed. uid=%d color=%s kind=%s %+v", t.UID(), t.Color(), ctrl.Kind, err)
+	}
+}
+
+// reconnect rebuilds the stream with exponential backoff until the worker countdown expires.
+// the worker is not closed while reconnecting, and the countdown is reset on success
+func (t *Tunnel) reconnect(cause error) error {
+	if t.worker.IsStopping() {
+		return cause
+	}
+
+	t.mu.Lock()
+	t.reconnecting = true
+	// the cancel unblocks the send in flight, so the send lock is taken soon
+	t.cancel()
+	t.mu.Unlock()
+
+	if err := t.closeSend(); err != nil {
+		t.Log().Debugf("[player.Tunnel] broken stream close failed. uid=%d color=%s %+v", t.UID(), t.Color(), err)
+	}
+
+	t.worker.SetStopCountDownTime(time.Now())
+	deadline := t.worker.ExpiryTime()
+	t.Log().Infof("[player.Tunnel] stream broken, reconnecting. uid=%d color=%s deadline=%s %+v", t.UID(), t.Color(), deadline, cause)
+
+	backoff := minReconnectBackoff
+	for attempt := 1; ; attempt++ {
+		err := t.reattach(attempt)
+		if err == nil {
+			t.worker.Reset()
+			t.Log().Infof("[player.Tunnel] stream reattached. uid=%d color=%s attempt=%d", t.UID(), t.Color(), attempt)
+			return nil
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			return errors.WithMessagef(cause, "reconnect failed. attempt=%d %+v", attempt, err)
+		}
+		select {
+		case <-t.worker.Stopping():
+			return cause
+		case <-gotime.After(backoff):
+		}
+		if backoff *= 2; backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+// reattach opens a new stream marked as reattached, swaps it in and flushes the pending messages on it.
+// the send lock is held until the pending messages are sent, so the new messages are sent after them
+func (t *Tunnel) reattach(attempt int) error {
+	sctx, cancel := context.WithCancel(t.ctx)
+	stream, err := t.cli.Tunnel(vctx.SetReattach(sctx, attempt))
 	if err != nil {
-		return nil, errors.Wrapf(err, "stream receive failed")
+		cancel()
+		return errors.Wrapf(err, "get tunnel stream failed")
 	}
 
-	return out, nil
+	t.sendMu.Lock()
+	defer t.sendMu.Unlock()
+
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = nil
+	t.stream = stream
+	t.cancel = cancel
+	t.reconnecting = false
+	t.headerRead = false
+	t.mu.Unlock()
+
+	for i, m := range pending {
+		if err = stream.Send(m); err != nil {
+			t.mu.Lock()
+			// the messages sent by CSHandle are not mixed in, it waits for the send lock
+			t.pending = append(pending[i:], t.pending...)
+			t.reconnecting = true
+			t.mu.Unlock()
+
+			cancel()
+			if err0 := stream.CloseSend(); err0 != nil {
+				t.Log().Debugf("[player.Tunnel] broken stream close failed. uid=%d color=%s %+v", t.UID(), t.Color(), err0)
+			}
+			return errors.Wrapf(err, "pending message send failed")
+		}
+		putMessage(m)
+	}
+	return nil
+}
+
+// closeSend closes the send direction of the current stream with the send lock held
+func (t *Tunnel) closeSend() error {
+	t.sendMu.Lock()
+	defer t.sendMu.Unlock()
+
+	t.mu.Lock()
+	stream := t.stream
+	t.mu.Unlock()
+
+	return stream.CloseSend()
 }
 
 // OnStop is called when the player tunnel is closed
@@ -84,9 +309,19 @@ func (t *Tunnel) OnStop() {
 	if err := t.routeTable.DelDelay(context.Background(), t.Color(), t.UID(), router.HolderCacheTimeout); err != nil {
 		t.Log().Errorf("[player.Tunnel] route table delete failed. uid=%d color=%s oid=%d %+v", t.UID(), t.Color(), t.OID(), err)
 	}
-	if err := t.stream.CloseSend(); err != nil {
+
+	if err := t.closeSend(); err != nil {
 		t.Log().Errorf("[player.Tunnel] stream close failed. uid=%d color=%s oid=%d %+v", t.UID(), t.Color(), t.OID(), err)
 	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cancel()
+	for _, m := range t.pending {
+		putMessage(m)
+	}
+	t.pending = nil
 }
 
 // OnGroupStop is called when the player tunnel is closed
@@ -96,6 +331,10 @@ func (t *Tunnel) OnGroupStop(ctx context.Context, err error) {
 	t.Log().Debugf("[player.Tunnel] tunnel group exit. uid=%d color=%s oid=%d %+v", t.UID(), t.Color(), t.OID(), err)
 }
 
+func (t *Tunnel) IsMain() bool {
+	return true
+}
+
 func (t *Tunnel) Session() net.Session {
-	return t.Session()
+	return t.Tunnel.Session()
 }
@@ -0,0 +1,233 @@
+package player
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	gotime "time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	controlv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/control/v1"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/player/intra/v1"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeStream blocks Recv until a message is queued or its context is cancelled, like a half-broken grpc stream
+type fakeStream struct {
+	intrav1.TunnelService_TunnelClient
+
+	ctx     context.Context
+	header  metadata.MD
+	sendErr error
+	block   chan struct{} // the send waits for it to be closed if it is set
+	sent    chan *intrav1.Message
+	recv    chan *intrav1.Message
+
+	calls      atomic.Int32
+	overlapped atomic.Bool // Send or CloseSend is called concurrently, which grpc forbids
+}
+
+func newFakeStream(ctx context.Context, sendErr error) *fakeStream {
+	return &fakeStream{ctx: ctx, sendErr: sendErr, sent: make(chan *intrav1.Message, 16), recv: make(chan *intrav1.Message, 16)}
+}
+
+func (s *fakeStream) enter() func() {
+	if s.calls.Add(1) > 1 {
+		s.overlapped.Store(true)
+	}
+	return func() { s.calls.Add(-1) }
+}
+
+func (s *fakeStream) Send(m *intrav1.Message) error {
+	defer s.enter()()
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	if s.block != nil {
+		<-s.block
+	}
+	s.sent <- proto.Clone(m).(*intrav1.Message)
+	return nil
+}
+
+func (s *fakeStream) Recv() (*intrav1.Message, error) {
+	select {
+	case m := <-s.recv:
+		return m, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func (s *fakeStream) Header() (metadata.MD, error) { return s.header, nil }
+func (s *fakeStream) Trailer() metadata.MD         { return nil }
+func (s *fakeStream) CloseSend() error {
+	defer s.enter()()
+	return nil
+}
+
+type fakeClient struct {
+	intrav1.TunnelServiceClient
+
+	streams chan *fakeStream
+	header  metadata.MD
+	sendErr []error // the send error of each stream opened in order
+	block   chan struct{}
+}
+
+func (c *fakeClient) Tunnel(ctx context.Context, _ ...grpc.CallOption) (grpc.BidiStreamingClient[intrav1.Message, intrav1.Message], error) {
+	var err error
+	if len(c.sendErr) > 0 {
+		err, c.sendErr = c.sendErr[0], c.sendErr[1:]
+	}
+	s := newFakeStream(ctx, err)
+	s.header = c.header
+	s.block = c.block
+	c.streams <- s
+	return s, nil
+}
+
+type fakeWorker struct {
+	tunnel.Worker
+
+	expiry gotime.Time
+	stop   chan struct{}
+}
+
+func (w *fakeWorker) IsStopping() bool                     { return false }
+func (w *fakeWorker) Stopping() <-chan struct{}            { return w.stop }
+func (w *fakeWorker) Reset()                               { w.expiry = gotime.Time{} }
+func (w *fakeWorker) SetStopCountDownTime(now gotime.Time) { w.expiry = now.Add(gotime.Second * 5) }
+func (w *fakeWorker) ExpiryTime() gotime.Time              { return w.expiry }
+
+func TestTunnelReconnectOnSendFailure(t *testing.T) {
+	cli := &fakeClient{streams: make(chan *fakeStream, 4), sendErr: []error{io.EOF}}
+	ss := net.NewSession(10001, 1, gotime.Now().Unix(), nil, nil, false, "", 0)
+	tn, err := NewTunnel(context.Background(), cli, ss, log.DefaultLogger, nil, &fakeWorker{stop: make(chan struct{})}, nil)
+	require.NoError(t, err)
+	broken := <-cli.streams
+
+	received := make(chan *intrav1.Message, 1)
+	go func() {
+		out, err := tn.SCHandle()
+		assert.NoError(t, err)
+		received <- out.(*intrav1.Message)
+	}()
+
+	// the send fails while the Recv of the broken stream is still blocked
+	assert.NoError(t, tn.CSHandle(&intrav1.Message{Mod: 1, Seq: 2, Data: []byte("cs")}))
+	assert.Error(t, broken.ctx.Err(), "the broken stream is cancelled")
+
+	var fresh *fakeStream
+	select {
+	case fresh = <-cli.streams:
+	case <-gotime.After(gotime.Second * 3):
+		t.Fatal("the stream is not rebuilt after the send failure")
+	}
+
+	select {
+	case m := <-fresh.sent:
+		assert.Equal(t, int32(1), m.Mod)
+		assert.Equal(t, []byte("cs"), m.Data)
+	case <-gotime.After(gotime.Second * 3):
+		t.Fatal("the pending message is not sent on the new stream")
+	}
+
+	fresh.recv <- &intrav1.Message{Mod: 1, Seq: 3, Data: []byte("sc")}
+	select {
+	case m := <-received:
+		assert.Equal(t, []byte("sc"), m.Data)
+	case <-gotime.After(gotime.Second * 3):
+		t.Fatal("the message of the new stream is not received")
+	}
+
+	assert.NoError(t, tn.CSHandle(&intrav1.Message{Mod: 1, Seq: 4}))
+	assert.Equal(t, int32(4), (<-fresh.sent).Seq)
+}
+
+func TestTunnelSendLock(t *testing.T) {
+	block := make(chan struct{})
+	cli := &fakeClient{streams: make(chan *fakeStream, 4), sendErr: []error{io.EOF}, block: block}
+	ss := net.NewSession(10001, 1, gotime.Now().Unix(), nil, nil, false, "", 0)
+	tn, err := NewTunnel(context.Background(), cli, ss, log.DefaultLogger, nil, &fakeWorker{stop: make(chan struct{})}, nil)
+	require.NoError(t, err)
+	broken := <-cli.streams
+
+	go func() {
+		_, _ = tn.SCHandle()
+	}()
+	assert.NoError(t, tn.CSHandle(&intrav1.Message{Seq: 1}))
+
+	var fresh *fakeStream
+	select {
+	case fresh = <-cli.streams:
+	case <-gotime.After(gotime.Second * 3):
+		t.Fatal("the stream is not rebuilt after the send failure")
+	}
+
+	// the pending message is being sent on the new stream, the state lock is not held over the network
+	assert.Eventually(t, func() bool { return fresh.calls.Load() == 1 }, gotime.Second, 10*gotime.Millisecond)
+	require.True(t, tn.mu.TryLock())
+	tn.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- tn.CSHandle(&intrav1.Message{Seq: 2})
+	}()
+	gotime.Sleep(50 * gotime.Millisecond)
+	assert.Empty(t, done, "the new message waits for the pending ones")
+
+	close(block)
+	assert.NoError(t, <-done)
+	assert.Equal(t, int32(1), (<-fresh.sent).Seq)
+	assert.Equal(t, int32(2), (<-fresh.sent).Seq)
+
+	assert.NoError(t, tn.closeSend())
+	assert.False(t, broken.overlapped.Load())
+	assert.False(t, fresh.overlapped.Load(), "the sends and the close of the stream never overlap")
+}
+
+type fakeController struct {
+	ctrls chan *controlv1.Control
+}
+
+func (c *fakeController) Control(ctx context.Context, ss net.Session, worker tunnel.Worker, ctrl *controlv1.Control) error {
+	c.ctrls <- ctrl
+	return nil
+}
+
+func TestTunnelControl(t *testing.T) {
+	cli := &fakeClient{streams: make(chan *fakeStream, 1), header: metadata.Pairs(tunnels.TagsKey, "guild:1", tunnels.TagsKey, "zone:2")}
+	ctrl := &fakeController{ctrls: make(chan *controlv1.Control, 1)}
+	ss := net.NewSession(10001, 1, gotime.Now().Unix(), nil, nil, false, "", 0)
+	tn, err := NewTunnel(context.Background(), cli, ss, log.DefaultLogger, nil, &fakeWorker{stop: make(chan struct{})}, ctrl)
+	require.NoError(t, err)
+	stream := <-cli.streams
+
+	data, err := proto.Marshal(&controlv1.Control{Kind: controlv1.Control_SET_TAGS, AddTags: []string{"team:3"}})
+	require.NoError(t, err)
+	stream.recv <- &intrav1.Message{Mod: tunnels.ControlMod, Data: data}
+	stream.recv <- &intrav1.Message{Mod: 0, Seq: 1, Data: []byte("sc")}
+
+	out, err := tn.SCHandle()
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), out.GetMod(), "the message of module 0 is pushed to the client")
+	assert.Equal(t, []byte("sc"), out.GetData())
+
+	select {
+	case c := <-ctrl.ctrls:
+		assert.Equal(t, controlv1.Control_SET_TAGS, c.Kind)
+		assert.Equal(t, []string{"team:3"}, c.AddTags)
+	default:
+		t.Fatal("the control message is not handled")
+	}
+	assert.ElementsMatch(t, []string{"guild:1", "zone:2"}, ss.Tags(), "the session tags in the stream header are applied")
+}
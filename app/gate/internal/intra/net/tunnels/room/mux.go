@@ -0,0 +1,33 @@
+package room
+
+import (
+	muxv1 "github.com/vulcan-frame/vulcan-gate/app/gate/api/mux/v1"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/mux"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/room/intra/v1"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+)
+
+var muxCodec = mux.Codec[intrav1.Message]{
+	Encode: func(msg *intrav1.Message, env *muxv1.Envelope) {
+		env.Mod = msg.Mod
+		env.Seq = msg.Seq
+		env.Obj = msg.Obj
+		env.Data = msg.Data
+		env.DataVersion = msg.DataVersion
+		env.MulticastUids = msg.MulticastUids
+	},
+	Decode: func(env *muxv1.Envelope, msg *intrav1.Message) {
+		msg.Mod = env.Mod
+		msg.Seq = env.Seq
+		msg.Obj = env.Obj
+		msg.Data = env.Data
+		msg.DataVersion = env.DataVersion
+		msg.MulticastUids = env.MulticastUids
+	},
+}
+
+// NewMuxClient opens the room tunnel of the session on the shared streams of the room node
+func NewMuxClient(m *mux.Mux, oid int64, ss net.Session) intrav1.TunnelServiceClient {
+	return mux.NewClient(m, int32(tunnels.RoomTunnelType), oid, ss, muxCodec)
+}
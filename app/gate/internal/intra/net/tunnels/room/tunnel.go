@@ -7,21 +7,49 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels/base"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
 	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+	cliseq "github.com/vulcan-frame/vulcan-gate/gen/api/client/sequence"
 	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/room/intra/v1"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	"go.uber.org/atomic"
+	"google.golang.org/protobuf/proto"
 )
 
 var _ tunnels.AppTunnel = (*Tunnel)(nil)
 
+// ErrRoomLeft is returned by [Tunnel.SCHandle] to close the stream cleanly after the user is no longer in the room
+var ErrRoomLeft = errors.New("user is not in the room")
+
+type joinedKey struct{}
+
+// withJoined marks the tunnel created with the context as the one of a room the user has joined
+func withJoined(ctx context.Context) context.Context {
+	return context.WithValue(ctx, joinedKey{}, true)
+}
+
+func joined(ctx context.Context) bool {
+	v, _ := ctx.Value(joinedKey{}).(bool)
+	return v
+}
+
+// Tunnel is the room tunnel, the oid is the room ID.
+// it watches the membership events on the sc stream, the stream is opened when the user joined the room,
+// and closed when the user left, was removed from or closed the room.
+// the tunnel is pinned while the user is a member, so it is not reclaimed however quiet the room is
 type Tunnel struct {
 	*base.Tunnel
 
+	worker tunnel.Worker
 	stream intrav1.TunnelService_TunnelClient
+	member *atomic.Bool
+	left   *atomic.Bool
 }
 
-func NewTunnel(ctx context.Context, oid int64, cli intrav1.TunnelServiceClient, ss net.Session, logger log.Logger) (*Tunnel, error) {
+func NewTunnel(ctx context.Context, oid int64, cli intrav1.TunnelServiceClient, ss net.Session, logger log.Logger, worker tunnel.Worker) (*Tunnel, error) {
 	stream, err := cli.Tunnel(ctx)
 	if err != nil {
 		return nil, errors.Wrapf(err, "get tunnel failed. uid=%d color=%s oid=%d %+v", ss.UID(), ss.Color(), oid, err)
@@ -29,7 +57,10 @@ func NewTunnel(ctx context.Context, oid int64, cli intrav1.TunnelServiceClient,
 
 	t := &Tunnel{
 		Tunnel: base.NewTunnel(tunnels.RoomTunnelType, oid, ss, logger),
+		worker: worker,
 		stream: stream,
+		member: atomic.NewBool(joined(ctx)),
+		left:   atomic.NewBool(false),
 	}
 	return t, nil
 }
@@ -56,14 +87,91 @@ func (t *Tunnel) CSHandle(msg tunnel.ForwardMessage) error {
 	return nil
 }
 
+// SCHandle receives the message from the service and watches the membership events on it.
+// the message which makes the user leave the room is still pushed to the client, and the stream is closed on the next call
 func (t *Tunnel) SCHandle() (tunnel.ForwardMessage, error) {
+	if t.left.Load() {
+		return nil, ErrRoomLeft
+	}
+
 	out, err := t.stream.Recv()
 	if err != nil {
-		return nil, errors.Wrapf(err, "stream receive failed")
+		return nil, errors.Wrapf(tunnels.StreamError(err, t.stream.Trailer(), false), "stream receive failed")
 	}
+	t.watch(out)
 	return out, nil
 }
 
+// watch pins the tunnel or opens the tunnel of the joined room on joined, and closes it on left, removed or closed.
+// the membership of this room is learned from the joined push, the created room and the room detail
+func (t *Tunnel) watch(msg *intrav1.Message) {
+	if msg.Mod != int32(climod.ModuleID_Room) {
+		return
+	}
+
+	if rid, ok := joinedRoom(t.Log(), msg); ok {
+		t.join(rid)
+		return
+	}
+
+	switch cliseq.RoomSeq(msg.Seq) {
+	case cliseq.RoomSeq_RoomDetail:
+		p := &climsg.SCRoomDetail{}
+		if err := proto.Unmarshal(msg.Data, p); err != nil || p.Code != climsg.SCRoomDetail_Success {
+			return
+		}
+		if _, ok := p.GetRoom().GetMembers()[t.UID()]; ok && p.GetRoom().GetBasic().GetId() == t.OID() {
+			t.member.Store(true)
+		}
+	case cliseq.RoomSeq_PushRemovedFromRoom:
+		p := &climsg.SCPushRemovedFromRoom{}
+		if err := proto.Unmarshal(msg.Data, p); err != nil {
+			t.Log().Errorf("[room.Tunnel] removed push unmarshal failed. uid=%d color=%s oid=%d %+v", t.UID(), t.Color(), t.OID(), err)
+			return
+		}
+		t.leave(p.RoomId)
+	case cliseq.RoomSeq_LeaveRoom:
+		p := &climsg.SCLeaveRoom{}
+		if err := proto.Unmarshal(msg.Data, p); err != nil || p.Code != climsg.SCLeaveRoom_Success {
+			return
+		}
+		t.leave(msg.Obj)
+	case cliseq.RoomSeq_CloseRoom:
+		p := &climsg.SCCloseRoom{}
+		if err := proto.Unmarshal(msg.Data, p); err != nil || p.Code != climsg.SCCloseRoom_Success {
+			return
+		}
+		t.leave(msg.Obj)
+	}
+}
+
+// join pins this tunnel if the user joined its room,
+// or creates the tunnel of the joined room in advance, so the first room request has no tunnel setup latency
+func (t *Tunnel) join(rid int64) {
+	if rid == 0 {
+		return
+	}
+	if rid == t.OID() {
+		t.member.Store(true)
+		return
+	}
+	open(t.Log(), t.Session(), t.worker, rid)
+}
+
+// leave closes the tunnel of the room. the room ID falls back to the oid when the message does not carry it
+func (t *Tunnel) leave(rid int64) {
+	if rid == 0 || rid == t.OID() {
+		t.left.Store(true)
+		return
+	}
+	t.worker.CloseTunnel(int32(climod.ModuleID_Room), rid)
+}
+
+// IsPinned keeps the tunnel while the user is in the room, the room may be quiet for longer than the idle timeout
+func (t *Tunnel) IsPinned() bool {
+	return t.member.Load() && !t.left.Load()
+}
+
 func (t *Tunnel) ResetMessage(msg tunnel.ForwardMessage) {
 	if m, ok := msg.(*intrav1.Message); ok {
 		putMessage(m)
@@ -77,9 +185,13 @@ func (t *Tunnel) OnStop() {
 }
 
 func (t *Tunnel) OnGroupStop(ctx context.Context, err error) {
+	if errors.Is(err, ErrRoomLeft) || errors.Is(err, xsync.GroupStopping) {
+		t.Log().Debugf("[room.Tunnel] tunnel group exit. uid=%d color=%s oid=%d %+v", t.UID(), t.Color(), t.OID(), err)
+		return
+	}
 	t.Log().Errorf("[room.Tunnel] tunnel group exit. uid=%d color=%s oid=%d %+v", t.UID(), t.Color(), t.OID(), err)
 }
 
 func (t *Tunnel) Session() net.Session {
-	return t.Session()
+	return t.Tunnel.Session()
 }
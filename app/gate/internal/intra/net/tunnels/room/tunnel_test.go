@@ -0,0 +1,155 @@
+package room
+
+import (
+	"context"
+	"testing"
+	gotime "time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
+	cliseq "github.com/vulcan-frame/vulcan-gate/gen/api/client/sequence"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/room/intra/v1"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeStream struct {
+	intrav1.TunnelService_TunnelClient
+
+	recv chan *intrav1.Message
+}
+
+func (s *fakeStream) Recv() (*intrav1.Message, error) { return <-s.recv, nil }
+func (s *fakeStream) Trailer() metadata.MD            { return nil }
+func (s *fakeStream) CloseSend() error                { return nil }
+
+type fakeClient struct {
+	intrav1.TunnelServiceClient
+
+	stream *fakeStream
+}
+
+func (c *fakeClient) Tunnel(ctx context.Context, _ ...grpc.CallOption) (grpc.BidiStreamingClient[intrav1.Message, intrav1.Message], error) {
+	return c.stream, nil
+}
+
+type opened struct {
+	ctx context.Context
+	oid int64
+}
+
+type fakeWorker struct {
+	tunnel.Worker
+
+	ctx    context.Context
+	opened chan opened
+	closed chan int64
+}
+
+func newFakeWorker() *fakeWorker {
+	return &fakeWorker{ctx: context.Background(), opened: make(chan opened, 4), closed: make(chan int64, 4)}
+}
+
+func (w *fakeWorker) Context() context.Context { return w.ctx }
+
+func (w *fakeWorker) Tunnel(ctx context.Context, mod int32, oid int64) (tunnel.Tunnel, error) {
+	w.opened <- opened{ctx: ctx, oid: oid}
+	return nil, nil
+}
+
+func (w *fakeWorker) CloseTunnel(mod int32, oid int64) {
+	w.closed <- oid
+}
+
+func newTestTunnel(t *testing.T, ctx context.Context, oid int64, w *fakeWorker) (*Tunnel, *fakeStream) {
+	stream := &fakeStream{recv: make(chan *intrav1.Message, 4)}
+	ss := net.NewSession(10001, 1, gotime.Now().Unix(), nil, nil, false, "", 0)
+	tn, err := NewTunnel(ctx, oid, &fakeClient{stream: stream}, ss, log.DefaultLogger, w)
+	require.NoError(t, err)
+	return tn, stream
+}
+
+func message(seq cliseq.RoomSeq, obj int64, body proto.Message) *intrav1.Message {
+	data, _ := proto.Marshal(body)
+	return &intrav1.Message{Mod: int32(climod.ModuleID_Room), Seq: int32(seq), Obj: obj, Data: data}
+}
+
+func TestTunnelWatchJoin(t *testing.T) {
+	w := newFakeWorker()
+	ctx, cancel := context.WithCancel(context.Background())
+	tn, stream := newTestTunnel(t, ctx, 7, w)
+	assert.False(t, tn.IsPinned())
+
+	stream.recv <- message(cliseq.RoomSeq_PushJoinedRoom, 0, &climsg.SCPushJoinedRoom{RoomId: 7})
+	_, err := tn.SCHandle()
+	require.NoError(t, err)
+	assert.True(t, tn.IsPinned(), "the tunnel is pinned after the user joined its room")
+
+	// the tunnel which received the push is stopped before the joined room tunnel is opened
+	cancel()
+	stream.recv <- message(cliseq.RoomSeq_PushJoinedRoom, 0, &climsg.SCPushJoinedRoom{RoomId: 8})
+	_, err = tn.SCHandle()
+	require.NoError(t, err)
+
+	select {
+	case o := <-w.opened:
+		assert.Equal(t, int64(8), o.oid)
+		assert.NoError(t, o.ctx.Err(), "the joined room tunnel is opened with the session context")
+		assert.True(t, joined(o.ctx))
+	case <-gotime.After(gotime.Second):
+		t.Fatal("the joined room tunnel is not opened")
+	}
+}
+
+func TestTunnelWatchLeave(t *testing.T) {
+	w := newFakeWorker()
+	tn, stream := newTestTunnel(t, withJoined(context.Background()), 7, w)
+	assert.True(t, tn.IsPinned())
+
+	stream.recv <- message(cliseq.RoomSeq_PushRemovedFromRoom, 0, &climsg.SCPushRemovedFromRoom{RoomId: 9})
+	_, err := tn.SCHandle()
+	require.NoError(t, err)
+	select {
+	case oid := <-w.closed:
+		assert.Equal(t, int64(9), oid)
+	case <-gotime.After(gotime.Second):
+		t.Fatal("the tunnel of the other room is not closed")
+	}
+	assert.True(t, tn.IsPinned())
+
+	stream.recv <- message(cliseq.RoomSeq_LeaveRoom, 7, &climsg.SCLeaveRoom{Code: climsg.SCLeaveRoom_Success})
+	out, err := tn.SCHandle()
+	require.NoError(t, err, "the leave reply is still pushed to the client")
+	assert.Equal(t, int32(cliseq.RoomSeq_LeaveRoom), out.GetSeq())
+	assert.False(t, tn.IsPinned())
+
+	_, err = tn.SCHandle()
+	assert.ErrorIs(t, err, ErrRoomLeft)
+}
+
+func TestWatch(t *testing.T) {
+	w := newFakeWorker()
+	ss := net.NewSession(10001, 1, gotime.Now().Unix(), nil, nil, false, "", 0)
+	logger := log.NewHelper(log.DefaultLogger)
+
+	Watch(logger, ss, w, message(cliseq.RoomSeq_LeaveRoom, 7, &climsg.SCLeaveRoom{Code: climsg.SCLeaveRoom_Success}))
+	Watch(logger, ss, w, message(cliseq.RoomSeq_CreateRoom, 0, &climsg.SCCreateRoom{
+		Code: climsg.SCCreateRoom_Success,
+		Room: &climsg.RoomProto{Basic: &climsg.RoomBasicProto{Id: 11}},
+	}))
+
+	select {
+	case o := <-w.opened:
+		assert.Equal(t, int64(11), o.oid)
+		assert.True(t, joined(o.ctx))
+	case <-gotime.After(gotime.Second):
+		t.Fatal("the created room tunnel is not opened")
+	}
+	assert.Empty(t, w.opened)
+}
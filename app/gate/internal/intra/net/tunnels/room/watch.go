@@ -0,0 +1,56 @@
+package room
+
+import (
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+	climsg "github.com/vulcan-frame/vulcan-gate/gen/api/client/message"
+	climod "github.com/vulcan-frame/vulcan-gate/gen/api/client/module"
+	cliseq "github.com/vulcan-frame/vulcan-gate/gen/api/client/sequence"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	"google.golang.org/protobuf/proto"
+)
+
+// Watch opens the tunnel of the room the user joined, it watches the room messages received on the other tunnels,
+// so the room tunnel is opened in advance even if the user has no room tunnel yet
+func Watch(logger *log.Helper, ss net.Session, worker tunnel.Worker, msg tunnel.ForwardMessage) {
+	if msg.GetMod() != int32(climod.ModuleID_Room) {
+		return
+	}
+	if rid, ok := joinedRoom(logger, msg); ok && rid != 0 {
+		open(logger, ss, worker, rid)
+	}
+}
+
+// joinedRoom returns the room ID if the message tells the user joined or created a room
+func joinedRoom(logger *log.Helper, msg tunnel.ForwardMessage) (int64, bool) {
+	switch cliseq.RoomSeq(msg.GetSeq()) {
+	case cliseq.RoomSeq_PushJoinedRoom:
+		p := &climsg.SCPushJoinedRoom{}
+		if err := proto.Unmarshal(msg.GetData(), p); err != nil {
+			logger.Errorf("[room.Tunnel] joined push unmarshal failed. obj=%d %+v", msg.GetObj(), err)
+			return 0, false
+		}
+		return p.RoomId, true
+	case cliseq.RoomSeq_CreateRoom:
+		p := &climsg.SCCreateRoom{}
+		if err := proto.Unmarshal(msg.GetData(), p); err != nil || p.Code != climsg.SCCreateRoom_Success {
+			return 0, false
+		}
+		return p.GetRoom().GetBasic().GetId(), true
+	}
+	return 0, false
+}
+
+// open creates the tunnel of the joined room in the background with the session context,
+// the tunnel which received the message may be stopped before the room tunnel is ready
+func open(logger *log.Helper, ss net.Session, worker tunnel.Worker, rid int64) {
+	xsync.GoSafe(fmt.Sprintf("gate.room.open-%d-%d", ss.UID(), rid), func() error {
+		if _, err := worker.Tunnel(withJoined(worker.Context()), int32(climod.ModuleID_Room), rid); err != nil {
+			logger.Errorf("[room.Tunnel] joined room tunnel open failed. uid=%d color=%s rid=%d %+v", ss.UID(), ss.Color(), rid, err)
+		}
+		return nil
+	})
+}
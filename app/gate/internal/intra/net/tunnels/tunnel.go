@@ -2,6 +2,7 @@ package tunnels
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"time"
 
@@ -9,15 +10,22 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/pkg/pool"
 	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+	intrav1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/intra/v1"
+	pushv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
 	verrors "github.com/vulcan-frame/vulcan-pkg-app/errors"
 	"github.com/vulcan-frame/vulcan-pkg-tool/compress"
 	"github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	"go.uber.org/atomic"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 )
 
+// ErrTunnelBusy is returned by Forward when the backend does not consume the messages in time
+var ErrTunnelBusy = errors.New("tunnel is busy")
+
 type AppTunnel interface {
 	AppTunnelBase
 
@@ -35,6 +43,18 @@ type AppTunnelBase interface {
 	Color() string
 	OID() int64
 	Session() net.Session
+	IsMain() bool
+	IsPinned() bool
+}
+
+// MulticastMessage is the forward message which may be a multicast envelope
+type MulticastMessage interface {
+	GetMulticastUids() []int64
+}
+
+// Multicaster fans the multicast envelopes out to the target sessions instead of the stream owner
+type Multicaster interface {
+	Multicast(ctx context.Context, color string, uids []int64, msg tunnel.ForwardMessage) error
 }
 
 var _ tunnel.Tunnel = (*Tunnel)(nil)
@@ -43,17 +63,28 @@ type Tunnel struct {
 	sync.Stoppable
 	tunnel.Pusher
 
-	app AppTunnel
+	worker      tunnel.Worker
+	app         AppTunnel
+	multicaster Multicaster
+	logouter    Logouter
+	cancel      context.CancelFunc
+	lastActive  *atomic.Int64
 
 	csChan chan tunnel.ForwardMessage
 }
 
-func NewTunnel(ctx context.Context, pusher tunnel.Pusher, app AppTunnel) *Tunnel {
+// NewTunnel starts the tunnel, the cancel of the tunnel context is called when the tunnel is stopped
+func NewTunnel(ctx context.Context, cancel context.CancelFunc, worker tunnel.Worker, app AppTunnel, multicaster Multicaster, logouter Logouter) *Tunnel {
 	t := &Tunnel{
-		Stoppable: sync.NewStopper(time.Second * 10),
-		app:       app,
-		Pusher:    pusher,
-		csChan:    make(chan tunnel.ForwardMessage, 1024),
+		Stoppable:   sync.NewStopper(time.Second * 10),
+		worker:      worker,
+		app:         app,
+		multicaster: multicaster,
+		logouter:    logouter,
+		cancel:      cancel,
+		Pusher:      worker,
+		lastActive:  atomic.NewInt64(time.Now().UnixNano()),
+		csChan:      make(chan tunnel.ForwardMessage, 1024),
 	}
 
 	t.start(ctx)
@@ -64,18 +95,35 @@ func (t *Tunnel) Type() int32 {
 	return t.app.Type()
 }
 
+func (t *Tunnel) IsMain() bool {
+	return t.app.IsMain()
+}
+
+func (t *Tunnel) IsPinned() bool {
+	return t.app.IsPinned()
+}
+
+func (t *Tunnel) LastActive() time.Time {
+	return time.Unix(0, t.lastActive.Load())
+}
+
 func (t *Tunnel) Forward(ctx context.Context, p tunnel.ForwardMessage) error {
 	if t.IsStopping() {
 		return verrors.ErrTunnelStopped
 	}
+	t.lastActive.Store(time.Now().UnixNano())
 
 	msg, err := t.transform(p)
 	if err != nil {
 		return err
 	}
 
-	t.csChan <- msg
-	return nil
+	select {
+	case t.csChan <- msg:
+		return nil
+	default:
+		return errors.Wrapf(ErrTunnelBusy, "type=%d oid=%d", t.app.Type(), t.app.OID())
+	}
 }
 
 func (t *Tunnel) transform(from tunnel.ForwardMessage) (to tunnel.ForwardMessage, err error) {
@@ -88,6 +136,7 @@ func (t *Tunnel) transform(from tunnel.ForwardMessage) (to tunnel.ForwardMessage
 }
 
 func (t *Tunnel) Push(ctx context.Context, pack []byte) error {
+	t.lastActive.Store(time.Now().UnixNano())
 	return t.Pusher.Push(ctx, pack)
 }
 
@@ -144,9 +193,20 @@ func (t *Tunnel) scLoop(ctx context.Context) error {
 	for {
 		msg, err := t.app.SCHandle()
 		if err != nil {
+			if code, ok := IsLogout(err); ok {
+				t.app.Log().WithContext(ctx).Infof("[gate.Tunnel] uid=%d color=%s oid=%d logout by backend. code=%d %+v", t.app.UID(), t.app.Color(), t.app.OID(), code, err)
+				t.logouter.Logout(ctx, t.app.Session(), t.worker, code)
+			}
 			return err
 		}
 
+		if m, ok := msg.(MulticastMessage); ok && len(m.GetMulticastUids()) > 0 {
+			if err = t.multicaster.Multicast(ctx, t.app.Color(), m.GetMulticastUids(), msg); err != nil {
+				t.app.Log().WithContext(ctx).Errorf("[gate.Tunnel] uid=%d color=%s oid=%d multicast failed. %+v", t.app.UID(), t.app.Color(), t.app.OID(), err)
+			}
+			continue
+		}
+
 		if err = t.push(ctx, msg); err != nil {
 			t.app.Log().WithContext(ctx).Errorf("[gate.Tunnel] uid=%d color=%s oid=%d push failed. %+v", t.app.UID(), t.app.Color(), t.app.OID(), err)
 		}
@@ -154,6 +214,17 @@ func (t *Tunnel) scLoop(ctx context.Context) error {
 }
 
 func (t *Tunnel) push(ctx context.Context, sc tunnel.ForwardMessage) error {
+	bytes, err := Pack(sc)
+	if err != nil {
+		return err
+	}
+	return t.Push(ctx, bytes)
+}
+
+// Pack builds the packet of the sc message, the sc index is stamped by IndexPack when the packet is written.
+// the data of the message is compressed and the packet is flagged if it is, the pushes of the push service are packed alike.
+// the push of the tunnel compressed the empty data of the pooled packet before Pack, so the message data never reached the client
+func Pack(sc tunnel.ForwardMessage) ([]byte, error) {
 	p := pool.GetPacket()
 	defer pool.PutPacket(p)
 
@@ -161,29 +232,59 @@ func (t *Tunnel) push(ctx context.Context, sc tunnel.ForwardMessage) error {
 	p.Seq = sc.GetSeq()
 	p.Obj = sc.GetObj()
 
-	if newData, compressed, err := compress.Compress(p.Data); err != nil {
-		return err
+	if newData, compressed, err := compress.Compress(sc.GetData()); err != nil {
+		return nil, err
 	} else {
 		p.Data = newData
 		p.Compress = compressed
 	}
 
-	p.Index = int32(t.app.Session().IncreaseSCIndex())
-
 	bytes, err := proto.Marshal(p)
 	if err != nil {
-		return errors.Wrapf(err, "packet marshal failed")
+		return nil, errors.Wrapf(err, "packet marshal failed")
 	}
-	if err = t.Push(ctx, bytes); err != nil {
-		return err
+	return bytes, nil
+}
+
+// packetIndexField is the field number of the sc index in the packet
+var packetIndexField = (&clipkt.Packet{}).ProtoReflect().Descriptor().Fields().ByName("index").Number()
+
+// IndexPack returns the copy of the packet built without the sc index with the index appended,
+// the packet may be shared by the sessions of a broadcast so it is not changed
+func IndexPack(pack []byte, index int64) []byte {
+	out := make([]byte, len(pack), len(pack)+1+binary.MaxVarintLen64)
+	copy(out, pack)
+	out = protowire.AppendTag(out, packetIndexField, protowire.VarintType)
+	return protowire.AppendVarint(out, uint64(int32(index)))
+}
+
+// PushOptions applies the priority and the ttl of the push body, the ttl starts when the body arrives at this gate
+func PushOptions(body *pushv1.PushBody) net.PushOptions {
+	opts := net.PushOptions{Priority: net.Priority(body.GetPriority())}
+	if ttl := body.GetTtlMs(); ttl > 0 {
+		opts.ExpireAt = time.Now().Add(time.Duration(ttl) * time.Millisecond)
 	}
+	return opts
+}
 
-	return nil
+// statusConsts lets the session filter name the online status
+var statusConsts = func() map[string]int64 {
+	consts := make(map[string]int64, len(intrav1.OnlineStatus_value))
+	for name, v := range intrav1.OnlineStatus_value {
+		consts[name] = int64(v)
+	}
+	return consts
+}()
+
+// ParseFilter parses the session filter of the pushes, the online status may be named, e.g. `status != ONLINE_STATUS_ADMIN`
+func ParseFilter(expr string) (net.SessionFilter, error) {
+	return net.ParseSessionFilter(expr, statusConsts)
 }
 
 func (t *Tunnel) stop() {
 	t.DoStop(func() {
 		close(t.csChan)
 		t.app.OnStop()
+		t.cancel()
 	})
 }
@@ -0,0 +1,41 @@
+package tunnels
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clipkt "github.com/vulcan-frame/vulcan-gate/gen/api/client/packet"
+	pushv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	"github.com/vulcan-frame/vulcan-pkg-tool/compress"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestIndexPack(t *testing.T) {
+	pack, err := Pack(&pushv1.PushBody{Mod: 1, Seq: 2, Obj: 3, Data: []byte("data")})
+	require.NoError(t, err)
+	origin := append([]byte(nil), pack...)
+
+	p := &clipkt.Packet{}
+	require.NoError(t, proto.Unmarshal(IndexPack(pack, 42), p))
+	assert.Equal(t, int32(42), p.Index)
+	assert.Equal(t, []int32{1, 2}, []int32{p.Mod, p.Seq})
+	assert.Equal(t, int64(3), p.Obj)
+	assert.Equal(t, origin, pack, "the packet shared by the sessions is not changed")
+}
+
+func TestPackData(t *testing.T) {
+	data := bytes.Repeat([]byte("data"), 1024)
+	pack, err := Pack(&pushv1.PushBody{Mod: 1, Seq: 2, Data: data})
+	require.NoError(t, err)
+
+	p := &clipkt.Packet{}
+	require.NoError(t, proto.Unmarshal(pack, p))
+	out := p.Data
+	if p.Compress {
+		out, err = compress.Decompress(p.Data)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, data, out, "the data of the message is packed")
+}
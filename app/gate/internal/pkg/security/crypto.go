@@ -2,45 +2,23 @@ package security
 
 import (
 	"crypto/cipher"
-	"crypto/rsa"
-	"crypto/x509"
 	"encoding/base64"
 
 	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
 	"github.com/vulcan-frame/vulcan-pkg-tool/rand"
 	"github.com/vulcan-frame/vulcan-pkg-tool/security/aes"
 	rrsa "github.com/vulcan-frame/vulcan-pkg-tool/security/rsa"
 )
 
-var (
-	handshakePriKey *rsa.PrivateKey
-	tokenAESKey     []byte
-	tokenAESBlock   cipher.Block
-)
-
-func Init(aesKey string, priKey string) error {
-	var (
-		priKeyBytes []byte
-		priKeyIface interface{}
-		err         error
-	)
-
-	if priKeyBytes, err = base64.URLEncoding.DecodeString(priKey); err != nil {
-		return errors.Wrapf(err, "base64 DecodeString failed.")
-	}
-	if priKeyIface, err = x509.ParsePKCS8PrivateKey(priKeyBytes); err != nil {
-		return errors.Wrapf(err, "x509 ParsePKCS8PrivateKey failed.")
-	}
-	handshakePriKey = priKeyIface.(*rsa.PrivateKey)
-
-	tokenAESKey = []byte(aesKey)
-
-	var block cipher.Block
-	if block, err = aes.NewBlock(tokenAESKey); err != nil {
-		return errors.Wrapf(err, "aes NewBlock failed.")
+// Init builds the token and handshake keyring from the secret config
+// it is safe to call it again when the secret file changes, the old keyring is replaced atomically
+func Init(sc *conf.Secret) error {
+	r, err := newKeyring(sc)
+	if err != nil {
+		return err
 	}
-	tokenAESBlock = block
-
+	ring.Store(r)
 	return nil
 }
 
@@ -58,19 +36,44 @@ func InitApiCrypto() (cipher.Block, []byte, error) {
 	return block, key, nil
 }
 
-func DecryptCSHandshake(secret []byte) ([]byte, error) {
-	return rrsa.Decrypt(handshakePriKey, secret)
+// DecryptCSHandshake decrypts the handshake with the key indicated by its key id, any key still published is accepted
+func DecryptCSHandshake(frame []byte) ([]byte, error) {
+	r := ring.Load()
+	if r == nil {
+		return nil, errors.New("security is not initialized")
+	}
+
+	k, secret, err := r.handshakeKey(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := rrsa.Decrypt(k.priKey, secret)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "handshake decrypt failed. kid=%s", k.id)
+	}
+	return out, nil
 }
 
 func DecryptToken(secret string) ([]byte, error) {
-	ser, err := base64.URLEncoding.DecodeString(secret)
+	r := ring.Load()
+	if r == nil {
+		return nil, errors.New("security is not initialized")
+	}
+
+	k, body, err := r.tokenKey(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	ser, err := base64.URLEncoding.DecodeString(body)
 	if err != nil {
 		return nil, errors.Wrapf(err, "base64 DecodeString failed.")
 	}
 
-	origin, err := aes.Decrypt(tokenAESKey, tokenAESBlock, ser)
+	origin, err := aes.Decrypt(k.key, k.block, ser)
 	if err != nil {
-		return nil, errors.Wrapf(err, "aes Decrypt failed.")
+		return nil, errors.Wrapf(err, "aes Decrypt failed. kid=%s", k.id)
 	}
 	return origin, nil
 }
@@ -0,0 +1,192 @@
+package security
+
+import (
+	"crypto/cipher"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-pkg-tool/security/aes"
+)
+
+// TokenKeyIDSeparator separates the key id prefix from the encrypted token body, e.g. "k2.<base64>"
+// tokens without the prefix are decrypted with the legacy key or the active key
+const TokenKeyIDSeparator = "."
+
+// legacyKeyID is the key id of the single aes_key/private_key pair configured before key rotation
+const legacyKeyID = ""
+
+var ring atomic.Pointer[keyring]
+
+type tokenKey struct {
+	id    string
+	key   []byte
+	block cipher.Block
+}
+
+type handshakeKey struct {
+	id     string
+	priKey *rsa.PrivateKey
+}
+
+// keyring is immutable after creation, it is replaced as a whole when the secret is reloaded
+type keyring struct {
+	activeTokenKey string
+	tokenKeys      map[string]*tokenKey
+
+	activeHandshakeKey string
+	handshakeKeys      map[string]*handshakeKey
+}
+
+func newKeyring(sc *conf.Secret) (*keyring, error) {
+	r := &keyring{
+		activeTokenKey:     sc.ActiveTokenKey,
+		tokenKeys:          make(map[string]*tokenKey, len(sc.TokenKeys)+1),
+		activeHandshakeKey: sc.ActiveHandshakeKey,
+		handshakeKeys:      make(map[string]*handshakeKey, len(sc.HandshakeKeys)+1),
+	}
+
+	if len(sc.AesKey) > 0 {
+		if err := r.addTokenKey(legacyKeyID, sc.AesKey); err != nil {
+			return nil, err
+		}
+	}
+	for _, k := range sc.TokenKeys {
+		if len(k.Id) == 0 {
+			return nil, errors.New("token key id is empty")
+		}
+		if err := r.addTokenKey(k.Id, k.Key); err != nil {
+			return nil, err
+		}
+	}
+	if len(r.tokenKeys) == 0 {
+		return nil, errors.New("no token key configured")
+	}
+	if _, ok := r.tokenKeys[r.activeTokenKey]; !ok {
+		return nil, errors.Errorf("active token key not found. id=%s", r.activeTokenKey)
+	}
+
+	if len(sc.PrivateKey) > 0 {
+		if err := r.addHandshakeKey(legacyKeyID, sc.PrivateKey); err != nil {
+			return nil, err
+		}
+	}
+	for _, hk := range sc.HandshakeKeys {
+		if len(hk.Id) == 0 || len(hk.Id) > xnet.MaxHandshakeKeyIDLen {
+			return nil, errors.Errorf("handshake key id length invalid. id=%s", hk.Id)
+		}
+		if err := r.addHandshakeKey(hk.Id, hk.PrivateKey); err != nil {
+			return nil, err
+		}
+	}
+	if len(r.handshakeKeys) == 0 {
+		return nil, errors.New("no handshake key configured")
+	}
+	if _, ok := r.handshakeKeys[r.activeHandshakeKey]; !ok {
+		return nil, errors.Errorf("active handshake key not found. id=%s", r.activeHandshakeKey)
+	}
+	// the frames are told apart by the length, the key id frame must not be as long as the legacy frame
+	legacy := r.legacyHandshakeKey()
+	for id, k := range r.handshakeKeys {
+		if id != legacyKeyID && xnet.HandshakeKeyIDFrameSize(id, k.priKey.Size()) == legacy.priKey.Size() {
+			return nil, errors.Errorf("handshake key id frame is as long as the legacy frame. id=%s legacy=%s", id, legacy.id)
+		}
+	}
+	return r, nil
+}
+
+func (r *keyring) addTokenKey(id string, key string) error {
+	if _, ok := r.tokenKeys[id]; ok {
+		return errors.Errorf("token key id duplicated. id=%s", id)
+	}
+
+	bytes := []byte(key)
+	block, err := aes.NewBlock(bytes)
+	if err != nil {
+		return errors.Wrapf(err, "aes NewBlock failed. id=%s", id)
+	}
+	r.tokenKeys[id] = &tokenKey{id: id, key: bytes, block: block}
+	return nil
+}
+
+func (r *keyring) addHandshakeKey(id string, priKey string) error {
+	if _, ok := r.handshakeKeys[id]; ok {
+		return errors.Errorf("handshake key id duplicated. id=%s", id)
+	}
+
+	k, err := newHandshakeKey(id, priKey)
+	if err != nil {
+		return err
+	}
+	r.handshakeKeys[id] = k
+	return nil
+}
+
+func newHandshakeKey(id string, priKey string) (*handshakeKey, error) {
+	priKeyBytes, err := base64.URLEncoding.DecodeString(priKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "base64 DecodeString failed. id=%s", id)
+	}
+	priKeyIface, err := x509.ParsePKCS8PrivateKey(priKeyBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "x509 ParsePKCS8PrivateKey failed. id=%s", id)
+	}
+	pk, ok := priKeyIface.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.Errorf("private key is not RSA. id=%s", id)
+	}
+	return &handshakeKey{id: id, priKey: pk}, nil
+}
+
+// tokenKey returns the key indicated by the key id prefix of the token and the token body without the prefix
+func (r *keyring) tokenKey(secret string) (*tokenKey, string, error) {
+	id, body, found := strings.Cut(secret, TokenKeyIDSeparator)
+	if !found {
+		if k, ok := r.tokenKeys[legacyKeyID]; ok {
+			return k, secret, nil
+		}
+		return r.tokenKeys[r.activeTokenKey], secret, nil
+	}
+
+	k, ok := r.tokenKeys[id]
+	if !ok {
+		return nil, "", errors.Errorf("token key not found. id=%s", id)
+	}
+	return k, body, nil
+}
+
+// legacyHandshakeKey returns the key of the frames without the key id, the legacy key or the active key
+func (r *keyring) legacyHandshakeKey() *handshakeKey {
+	if k, ok := r.handshakeKeys[legacyKeyID]; ok {
+		return k
+	}
+	return r.handshakeKeys[r.activeHandshakeKey]
+}
+
+// handshakeKey returns the key indicated by the key id of the handshake frame and the secret without the key id,
+// so the handshake costs one RSA decryption whatever the count of the grace keys.
+// the frame of the legacy key size is the legacy one whatever its first byte is, newKeyring keeps the key id frames of the other sizes
+func (r *keyring) handshakeKey(frame []byte) (*handshakeKey, []byte, error) {
+	legacy := r.legacyHandshakeKey()
+	if len(frame) == legacy.priKey.Size() || len(frame) == 0 || frame[0] != xnet.HandshakeKeyIDMagic {
+		return legacy, frame, nil
+	}
+
+	id, secret, err := xnet.ParseHandshakeKeyIDFrame(frame)
+	if err != nil {
+		return nil, nil, err
+	}
+	k, ok := r.handshakeKeys[id]
+	if !ok {
+		return nil, nil, errors.Errorf("handshake key not found. id=%s", id)
+	}
+	if len(secret) != k.priKey.Size() {
+		return nil, nil, errors.Errorf("handshake secret size mismatches the key. id=%s size=%d", id, len(secret))
+	}
+	return k, secret, nil
+}
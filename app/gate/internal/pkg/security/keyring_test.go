@@ -0,0 +1,198 @@
+package security
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	rrsa "github.com/vulcan-frame/vulcan-pkg-tool/security/rsa"
+)
+
+const (
+	legacyAesKey = "XmTsvEVncDFSaEP0yGKBNvm8t4PNysnV"
+	k2AesKey     = "7nq3VJ0sCkQZr8xWm2LpT5yHdGfB9aEu"
+)
+
+func generateKey(t *testing.T, bits int) *rsa.PrivateKey {
+	pk, err := rsa.GenerateKey(rand.Reader, bits)
+	require.Nil(t, err)
+	return pk
+}
+
+func encodeKey(t *testing.T, pk *rsa.PrivateKey) string {
+	der, err := x509.MarshalPKCS8PrivateKey(pk)
+	require.Nil(t, err)
+	return base64.URLEncoding.EncodeToString(der)
+}
+
+func privateKey(t *testing.T) string {
+	return encodeKey(t, generateKey(t, 1024))
+}
+
+func frame(t *testing.T, id string, secret []byte) []byte {
+	out, err := xnet.HandshakeKeyIDFrame(id, secret)
+	require.Nil(t, err)
+	return out
+}
+
+func TestNewKeyring(t *testing.T) {
+	pk := privateKey(t)
+
+	_, err := newKeyring(&conf.Secret{PrivateKey: pk})
+	assert.NotNil(t, err, "no token key")
+	_, err = newKeyring(&conf.Secret{AesKey: legacyAesKey})
+	assert.NotNil(t, err, "no handshake key")
+	_, err = newKeyring(&conf.Secret{AesKey: legacyAesKey, PrivateKey: pk, ActiveTokenKey: "k2"})
+	assert.NotNil(t, err, "active token key not found")
+	_, err = newKeyring(&conf.Secret{AesKey: legacyAesKey, PrivateKey: pk, ActiveHandshakeKey: "h2"})
+	assert.NotNil(t, err, "active handshake key not found")
+	_, err = newKeyring(&conf.Secret{
+		AesKey:        legacyAesKey,
+		PrivateKey:    pk,
+		HandshakeKeys: []*conf.Secret_HandshakeKey{{Id: "h2", PrivateKey: pk}, {Id: "h2", PrivateKey: pk}},
+	})
+	assert.NotNil(t, err, "handshake key id duplicated")
+	_, err = newKeyring(&conf.Secret{AesKey: legacyAesKey, PrivateKey: "bad"})
+	assert.NotNil(t, err, "private key invalid")
+	_, err = newKeyring(&conf.Secret{
+		AesKey:        legacyAesKey,
+		PrivateKey:    encodeKey(t, generateKey(t, 2048)),
+		HandshakeKeys: []*conf.Secret_HandshakeKey{{Id: strings.Repeat("h", 126), PrivateKey: pk}},
+	})
+	assert.NotNil(t, err, "the key id frame is as long as the legacy frame")
+
+	r, err := newKeyring(&conf.Secret{AesKey: legacyAesKey, PrivateKey: pk})
+	assert.Nil(t, err)
+	assert.Len(t, r.tokenKeys, 1)
+	assert.Len(t, r.handshakeKeys, 1)
+}
+
+func TestTokenKey(t *testing.T) {
+	r, err := newKeyring(&conf.Secret{
+		AesKey:         legacyAesKey,
+		PrivateKey:     privateKey(t),
+		ActiveTokenKey: "k2",
+		TokenKeys:      []*conf.Secret_TokenKey{{Id: "k2", Key: k2AesKey}},
+	})
+	assert.Nil(t, err)
+
+	k, body, err := r.tokenKey("k2.body")
+	assert.Nil(t, err)
+	assert.Equal(t, "k2", k.id)
+	assert.Equal(t, "body", body)
+
+	k, body, err = r.tokenKey("body")
+	assert.Nil(t, err)
+	assert.Equal(t, legacyKeyID, k.id)
+	assert.Equal(t, "body", body)
+
+	_, _, err = r.tokenKey("k3.body")
+	assert.NotNil(t, err)
+}
+
+func TestHandshakeKey(t *testing.T) {
+	r, err := newKeyring(&conf.Secret{
+		AesKey:             legacyAesKey,
+		ActiveHandshakeKey: "h2",
+		HandshakeKeys: []*conf.Secret_HandshakeKey{
+			{Id: "h1", PrivateKey: privateKey(t)},
+			{Id: "h2", PrivateKey: privateKey(t)},
+		},
+	})
+	require.Nil(t, err)
+
+	secret := bytes.Repeat([]byte{0xFF}, 128)
+	k, out, err := r.handshakeKey(frame(t, "h1", secret))
+	assert.Nil(t, err)
+	assert.Equal(t, "h1", k.id)
+	assert.Equal(t, secret, out)
+
+	// the frame of the modulus size is the legacy one though it starts with the magic,
+	// it is decrypted with the active key when no legacy key is configured
+	k, out, err = r.handshakeKey(secret)
+	assert.Nil(t, err)
+	assert.Equal(t, "h2", k.id)
+	assert.Equal(t, secret, out)
+
+	_, _, err = r.handshakeKey(frame(t, "h3", secret))
+	assert.NotNil(t, err, "key not found")
+	_, _, err = r.handshakeKey(frame(t, "h1", secret[:16]))
+	assert.NotNil(t, err, "the secret is not of the key size")
+	_, _, err = r.handshakeKey([]byte{xnet.HandshakeKeyIDMagic, 8, 'h'})
+	assert.NotNil(t, err, "key id truncated")
+}
+
+// legacyKey returns the 1024 bits key of the modulus starting with the key id magic, so its ciphertexts can start with the magic too
+func legacyKey(t *testing.T) *rsa.PrivateKey {
+	e := big.NewInt(65537)
+	// the modulus is at least 0xFFC0 << 1008
+	floor := new(big.Int).Lsh(big.NewInt(0xFFC0), 1008)
+	for {
+		p, err := rand.Prime(rand.Reader, 512)
+		require.Nil(t, err)
+		q := new(big.Int).Div(floor, p)
+		for q.Add(q, big.NewInt(1)); !q.ProbablyPrime(20); q.Add(q, big.NewInt(1)) {
+		}
+
+		phi := new(big.Int).Mul(new(big.Int).Sub(p, big.NewInt(1)), new(big.Int).Sub(q, big.NewInt(1)))
+		d := new(big.Int).ModInverse(e, phi)
+		if d == nil {
+			continue
+		}
+		pk := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: new(big.Int).Mul(p, q), E: int(e.Int64())},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		pk.Precompute()
+		require.Nil(t, pk.Validate())
+		require.Equal(t, xnet.HandshakeKeyIDMagic, pk.N.Bytes()[0])
+		return pk
+	}
+}
+
+func TestDecryptCSHandshake(t *testing.T) {
+	old := ring.Load()
+	t.Cleanup(func() { ring.Store(old) })
+
+	legacy, h2 := legacyKey(t), generateKey(t, 1024)
+	require.Nil(t, Init(&conf.Secret{
+		AesKey:             legacyAesKey,
+		PrivateKey:         encodeKey(t, legacy),
+		ActiveHandshakeKey: "h2",
+		HandshakeKeys:      []*conf.Secret_HandshakeKey{{Id: "h2", PrivateKey: encodeKey(t, h2)}},
+	}))
+	handshake := []byte("handshake")
+
+	// the client of the rotated key sends the key id frame
+	secret, err := rrsa.Encrypt(&h2.PublicKey, handshake)
+	require.Nil(t, err)
+	out, err := DecryptCSHandshake(frame(t, "h2", secret))
+	require.Nil(t, err)
+	assert.Equal(t, handshake, out)
+
+	// the legacy client sends the bare ciphertext, the one starting with the magic is not taken for a key id frame
+	for i := 0; i == 0 || i < 100000 && secret[0] != xnet.HandshakeKeyIDMagic; i++ {
+		secret, err = rrsa.Encrypt(&legacy.PublicKey, handshake)
+		require.Nil(t, err)
+	}
+	require.Equal(t, xnet.HandshakeKeyIDMagic, secret[0])
+	out, err = DecryptCSHandshake(secret)
+	require.Nil(t, err)
+	assert.Equal(t, handshake, out)
+
+	// the legacy frame is not decrypted with the rotated key
+	secret, err = rrsa.Encrypt(&h2.PublicKey, handshake)
+	require.Nil(t, err)
+	_, err = DecryptCSHandshake(secret)
+	assert.NotNil(t, err)
+}
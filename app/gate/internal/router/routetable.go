@@ -2,6 +2,7 @@ package router
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
@@ -10,6 +11,7 @@ import (
 	"github.com/vulcan-frame/vulcan-pkg-app/profile"
 	"github.com/vulcan-frame/vulcan-pkg-app/router/routetable"
 	"github.com/vulcan-frame/vulcan-pkg-app/router/routetable/redis"
+	"golang.org/x/sync/errgroup"
 )
 
 type RouteTable struct {
@@ -46,3 +48,34 @@ func DelRouteTable(ctx context.Context, rt *RouteTable, color string, uid int64)
 	}
 	return nil
 }
+
+// lookupConcurrency is the max count of the concurrent lookups of a batch
+const lookupConcurrency = 32
+
+// GetBatch looks the gates of the users up concurrently, the users without a route or failed to look up are left out
+func GetBatch(ctx context.Context, rt *RouteTable, color string, uids []int64) map[int64]string {
+	var (
+		mu    sync.Mutex
+		addrs = make(map[int64]string, len(uids))
+		eg    errgroup.Group
+	)
+	eg.SetLimit(lookupConcurrency)
+	for _, uid := range uids {
+		eg.Go(func() error {
+			addr, err := rt.Get(ctx, color, uid)
+			if err != nil {
+				log.Debugf("[gate.RouteTable] get route table failed. color=%s oid=%d %+v", color, uid, err)
+				return nil
+			}
+			if len(addr) == 0 {
+				return nil
+			}
+			mu.Lock()
+			addrs[uid] = addr
+			mu.Unlock()
+			return nil
+		})
+	}
+	_ = eg.Wait()
+	return addrs
+}
@@ -2,16 +2,18 @@ package server
 
 import (
 	"github.com/go-kratos/kratos/contrib/registry/etcd/v2"
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/registry"
 	"github.com/google/wire"
 	"github.com/pkg/errors"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	tcp "github.com/vulcan-frame/vulcan-gate/pkg/net/tcp/server"
 	etcdclient "go.etcd.io/etcd/client/v3"
 )
 
-var ProviderSet = wire.NewSet(NewTCPServer, NewGRPCServer, NewHTTPServer, NewRegistrar)
+var ProviderSet = wire.NewSet(NewTCPServer, NewGRPCServer, NewHTTPServer, NewRegistrar, NewHealthServer)
 
-func NewRegistrar(conf *conf.Registry) (registry.Registrar, error) {
+func NewRegistrar(conf *conf.Registry, sc *conf.Server, logger log.Logger, ts *tcp.Server) (registry.Registrar, error) {
 	client, err := etcdclient.New(etcdclient.Config{
 		Endpoints: conf.Etcd.Endpoints,
 		Username:  conf.Etcd.Username,
@@ -21,5 +23,6 @@ func NewRegistrar(conf *conf.Registry) (registry.Registrar, error) {
 		return nil, errors.Wrapf(err, "[etcdclient.New] etcd 客户端创建失败。")
 	}
 
-	return etcd.New(client), nil
+	r := etcd.New(client, etcd.Namespace(registryNamespace))
+	return newLoadRegistrar(r, client, logger, ts, sc.Tcp.GetCapacity(), conf.GetLoadInterval().AsDuration()), nil
 }
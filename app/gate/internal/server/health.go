@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/backend"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/client/player"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/health"
+	tcp "github.com/vulcan-frame/vulcan-gate/pkg/net/tcp/server"
+)
+
+// NewHealthServer serves the probes of the gate.
+// the gate is ready when the redis, the etcd registrar and the player service are reachable and the tcp server takes connections,
+// it is alive as long as the accept loops are not stuck
+func NewHealthServer(c *conf.Server, d *data.Data, rr registry.Registrar, r registry.Discovery, b *backend.Backends, ts *tcp.Server) *health.Server {
+	hs := health.NewServer(c.Health)
+
+	hs.Readiness("tcp", func(ctx context.Context) error {
+		if !ts.Listening() {
+			return errors.New("tcp server not listening")
+		}
+		return nil
+	})
+	if d.Rdb != nil {
+		hs.Readiness("redis", func(ctx context.Context) error {
+			return d.Rdb.Ping(ctx).Err()
+		})
+	}
+	if lr, ok := rr.(*loadRegistrar); ok {
+		hs.Readiness("registrar", lr.Check)
+	}
+
+	services := append([]string{player.ServiceName}, b.MainServices()...)
+	hs.Readiness("discovery", func(ctx context.Context) error {
+		for _, service := range services {
+			ins, err := r.GetService(ctx, service)
+			if err != nil {
+				return errors.Wrapf(err, "service discover failed. service=%s", service)
+			}
+			if len(ins) == 0 {
+				return errors.Errorf("no instance discovered. service=%s", service)
+			}
+		}
+		return nil
+	})
+	hs.Draining(ts.Draining)
+
+	hs.Liveness("accept", func(ctx context.Context) error {
+		return ts.CheckAccept()
+	})
+	return hs
+}
@@ -9,11 +9,12 @@ import (
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/http"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/service/admin"
 	pushv1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
 	"github.com/vulcan-frame/vulcan-pkg-app/metrics"
 )
 
-func NewHTTPServer(c *conf.Server, logger log.Logger, ps pushv1.PushServiceServer) *http.Server {
+func NewHTTPServer(c *conf.Server, logger log.Logger, ps pushv1.PushServiceServer, as *admin.AdminService) *http.Server {
 	var opts = []http.ServerOption{
 		http.Middleware(
 			middleware.Chain(
@@ -36,5 +37,6 @@ func NewHTTPServer(c *conf.Server, logger log.Logger, ps pushv1.PushServiceServe
 
 	svr := http.NewServer(opts...)
 	pushv1.RegisterPushServiceHTTPServer(svr, ps)
+	as.RegisterHTTP(svr)
 	return svr
 }
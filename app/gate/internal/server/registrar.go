@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/conf"
+	xsync "github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	etcdclient "go.etcd.io/etcd/client/v3"
+)
+
+var _ registry.Registrar = (*loadRegistrar)(nil)
+
+const (
+	// registryNamespace is passed to the etcd registry, the load is put on the key the registry puts the instance on, see instanceKey
+	registryNamespace   = "/microservices"
+	defaultLoadInterval = time.Second * 10
+	loadTimeout         = time.Second * 3
+)
+
+// loadRegistrar publishes the load of the gate in the registry metadata and refreshes it in the interval.
+// the value is put on the lease kept alive by the etcd registry, so the instance is still removed when the gate is gone.
+// the gate started offline is not registered, it serves the clients connected by the address but takes no new ones
+type loadRegistrar struct {
+	registry.Registrar
+
+	log      *log.Helper
+	client   *etcdclient.Client
+	ts       load
+	capacity int64
+	interval time.Duration
+
+	mu       sync.Mutex
+	instance *registry.ServiceInstance // the registered one with the load metadata
+	stop     chan struct{}
+}
+
+// load is implemented by the tcp server
+type load interface {
+	Env() conf.Env
+	Connections() int
+}
+
+func newLoadRegistrar(r registry.Registrar, client *etcdclient.Client, logger log.Logger, ts load, capacity int64, interval time.Duration) *loadRegistrar {
+	if interval <= 0 {
+		interval = defaultLoadInterval
+	}
+	return &loadRegistrar{
+		Registrar: r,
+		log:       log.NewHelper(log.With(logger, "module", "gate/registrar")),
+		client:    client,
+		ts:        ts,
+		capacity:  capacity,
+		interval:  interval,
+	}
+}
+
+func (r *loadRegistrar) Register(ctx context.Context, service *registry.ServiceInstance) error {
+	if r.ts.Env().Offline {
+		r.log.Infof("[server.Registrar] the gate is offline and not registered. id=%s", service.ID)
+		return nil
+	}
+
+	ins := *service
+	ins.Metadata = r.metadata(service.Metadata)
+	if err := r.Registrar.Register(ctx, &ins); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.instance = &ins
+	r.stop = make(chan struct{})
+	stop := r.stop
+	r.mu.Unlock()
+
+	xsync.GoSafe("gate.registrar.load", func() error {
+		return r.loop(stop)
+	})
+	return nil
+}
+
+func (r *loadRegistrar) Deregister(ctx context.Context, service *registry.ServiceInstance) error {
+	r.mu.Lock()
+	ins := r.instance
+	r.instance = nil
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+	}
+	r.mu.Unlock()
+
+	if ins == nil {
+		return nil
+	}
+	return r.Registrar.Deregister(ctx, ins)
+}
+
+// Check reaches the etcd and makes sure the registered instance is still in it.
+// the offline gate or the gate not registered yet only checks the etcd
+func (r *loadRegistrar) Check(ctx context.Context) error {
+	r.mu.Lock()
+	ins := r.instance
+	r.mu.Unlock()
+
+	if ins == nil {
+		if _, err := r.client.Get(ctx, registryNamespace, etcdclient.WithPrefix(), etcdclient.WithCountOnly()); err != nil {
+			return errors.Wrapf(err, "etcd get failed")
+		}
+		return nil
+	}
+
+	key := instanceKey(ins)
+	resp, err := r.client.Get(ctx, key, etcdclient.WithCountOnly())
+	if err != nil {
+		return errors.Wrapf(err, "etcd get failed. key=%s", key)
+	}
+	if resp.Count == 0 {
+		return errors.Errorf("instance not registered. key=%s", key)
+	}
+	return nil
+}
+
+// metadata copies the metadata of the service with the current load
+func (r *loadRegistrar) metadata(md map[string]string) map[string]string {
+	env := r.ts.Env()
+
+	ret := make(map[string]string, len(md)+4)
+	maps.Copy(ret, md)
+	ret[net.MetadataConnections] = strconv.Itoa(r.ts.Connections())
+	ret[net.MetadataCapacity] = strconv.FormatInt(r.capacity, 10)
+	ret[net.MetadataWeight] = strconv.FormatInt(env.Weight, 10)
+
+	addrs := make([]string, 0, len(env.Addrs))
+	for _, addr := range env.Addrs {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	ret[net.MetadataAddrs] = strings.Join(addrs, ",")
+	return ret
+}
+
+func (r *loadRegistrar) loop(stop chan struct{}) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			if err := r.refresh(); err != nil {
+				r.log.Errorf("[server.Registrar] load refresh failed. %+v", err)
+			}
+		}
+	}
+}
+
+// refresh puts the instance with the current load on the lease of the registered key.
+// the key missing means the lease is lost, it is registered again by the etcd registry and refreshed in the next interval
+func (r *loadRegistrar) refresh() error {
+	r.mu.Lock()
+	if r.instance == nil {
+		r.mu.Unlock()
+		return nil
+	}
+	ins := *r.instance
+	ins.Metadata = r.metadata(r.instance.Metadata)
+	r.instance.Metadata = ins.Metadata
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+	defer cancel()
+
+	key := instanceKey(&ins)
+	resp, err := r.client.Get(ctx, key)
+	if err != nil {
+		return errors.Wrapf(err, "etcd get failed. key=%s", key)
+	}
+	if len(resp.Kvs) == 0 || resp.Kvs[0].Lease == 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(&ins)
+	if err != nil {
+		return errors.Wrapf(err, "instance encode failed. key=%s", key)
+	}
+	if _, err = r.client.Put(ctx, key, string(value), etcdclient.WithLease(etcdclient.LeaseID(resp.Kvs[0].Lease))); err != nil {
+		return errors.Wrapf(err, "etcd put failed. key=%s", key)
+	}
+	return nil
+}
+
+// instanceKey is the key of the instance in the etcd registry of kratos, namespace/name/id.
+// the registry has no API for it, so it must be checked again when the registry is upgraded
+func instanceKey(ins *registry.ServiceInstance) string {
+	return registryNamespace + "/" + ins.Name + "/" + ins.ID
+}
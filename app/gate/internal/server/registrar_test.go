@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/conf"
+)
+
+type fakeLoad struct {
+	env   conf.Env
+	conns int
+}
+
+func (l *fakeLoad) Env() conf.Env    { return l.env }
+func (l *fakeLoad) Connections() int { return l.conns }
+
+type fakeRegistrar struct {
+	registered   []*registry.ServiceInstance
+	deregistered []*registry.ServiceInstance
+}
+
+func (r *fakeRegistrar) Register(ctx context.Context, ins *registry.ServiceInstance) error {
+	r.registered = append(r.registered, ins)
+	return nil
+}
+
+func (r *fakeRegistrar) Deregister(ctx context.Context, ins *registry.ServiceInstance) error {
+	r.deregistered = append(r.deregistered, ins)
+	return nil
+}
+
+func newInstance() *registry.ServiceInstance {
+	return &registry.ServiceInstance{ID: "gate-1", Name: "gate", Metadata: map[string]string{"color": "blue"}}
+}
+
+func TestRegistrarMetadata(t *testing.T) {
+	l := &fakeLoad{env: conf.Env{Weight: 10, Addrs: []string{"1.1.1.1", " ", " 2.2.2.2"}}, conns: 42}
+	r := newLoadRegistrar(&fakeRegistrar{}, nil, log.DefaultLogger, l, 1000, 0)
+
+	md := map[string]string{"color": "blue"}
+	assert.Equal(t, map[string]string{
+		"color":                 "blue",
+		net.MetadataConnections: "42",
+		net.MetadataCapacity:    "1000",
+		net.MetadataWeight:      "10",
+		net.MetadataAddrs:       "1.1.1.1,2.2.2.2",
+	}, r.metadata(md))
+	assert.Equal(t, map[string]string{"color": "blue"}, md, "the metadata of the service is not changed")
+}
+
+func TestRegistrarOffline(t *testing.T) {
+	fr := &fakeRegistrar{}
+	r := newLoadRegistrar(fr, nil, log.DefaultLogger, &fakeLoad{env: conf.Env{Offline: true}}, 0, 0)
+
+	ins := newInstance()
+	require.Nil(t, r.Register(context.Background(), ins))
+	require.Nil(t, r.Deregister(context.Background(), ins))
+	assert.Empty(t, fr.registered, "the offline gate is not registered")
+	assert.Empty(t, fr.deregistered)
+}
+
+func TestRegistrarRegister(t *testing.T) {
+	fr := &fakeRegistrar{}
+	r := newLoadRegistrar(fr, nil, log.DefaultLogger, &fakeLoad{conns: 3}, 100, 0)
+
+	ins := newInstance()
+	require.Nil(t, r.Register(context.Background(), ins))
+	require.Len(t, fr.registered, 1)
+	assert.Equal(t, "3", fr.registered[0].Metadata[net.MetadataConnections])
+	assert.Equal(t, map[string]string{"color": "blue"}, ins.Metadata, "the instance of the app is not changed")
+
+	require.Nil(t, r.Deregister(context.Background(), ins))
+	require.Nil(t, r.Deregister(context.Background(), ins))
+	require.Len(t, fr.deregistered, 1, "the instance is deregistered once")
+	assert.Same(t, fr.registered[0], fr.deregistered[0], "the registered instance is deregistered")
+}
+
+func TestInstanceKey(t *testing.T) {
+	// the etcd registry of kratos puts the instance on namespace/name/id
+	assert.Equal(t, "/microservices/gate/gate-1", instanceKey(newInstance()))
+}
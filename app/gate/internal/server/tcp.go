@@ -9,17 +9,20 @@ import (
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/pkg/errors"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/guard"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/queue"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/service"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/pkg/middleware/logging"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/pkg/middleware/metadata"
 	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/router"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net"
+	netconf "github.com/vulcan-frame/vulcan-gate/pkg/net/conf"
 	tcp "github.com/vulcan-frame/vulcan-gate/pkg/net/tcp/server"
 	"github.com/vulcan-frame/vulcan-pkg-app/metrics"
 	"github.com/vulcan-frame/vulcan-pkg-app/router/routetable"
 )
 
-func NewTCPServer(c *conf.Server, logger log.Logger, rt *router.RouteTable, svc *service.Service) (*tcp.Server, error) {
+func NewTCPServer(c *conf.Server, logger log.Logger, rt *router.RouteTable, svc *service.Service, g *guard.Guard, q *queue.Queue) (*tcp.Server, error) {
 	var opts = []tcp.Option{
 		tcp.ReadFilter(
 			middleware.Chain(
@@ -40,6 +43,40 @@ func NewTCPServer(c *conf.Server, logger log.Logger, rt *router.RouteTable, svc
 	if c.Tcp.Addr != "" {
 		opts = append(opts, tcp.Bind(c.Tcp.Addr))
 	}
+	if c.Tcp.MaxSessionAge != nil {
+		opts = append(opts, tcp.MaxSessionAge(c.Tcp.MaxSessionAge.AsDuration()))
+	}
+	if c.Tcp.SessionGracePeriod != nil {
+		opts = append(opts, tcp.SessionGracePeriod(c.Tcp.SessionGracePeriod.AsDuration()))
+	}
+	if c.Tcp.EnforceTokenExpiry {
+		opts = append(opts, tcp.EnforceTokenExpiry(true))
+	}
+	if c.Tcp.TunnelIdleTimeout != nil {
+		opts = append(opts, tcp.TunnelIdleTimeout(c.Tcp.TunnelIdleTimeout.AsDuration()))
+	}
+	if c.Tcp.TunnelFailureTtl != nil {
+		opts = append(opts, tcp.TunnelFailureTTL(c.Tcp.TunnelFailureTtl.AsDuration()))
+	}
+	if c.Tcp.MaxTunnelsPerType > 0 {
+		opts = append(opts, tcp.MaxTunnelsPerType(int(c.Tcp.MaxTunnelsPerType)))
+	}
+	if c.Tcp.QueueTimeout != nil && c.Tcp.QueueTimeout.AsDuration() > 0 {
+		opts = append(opts, tcp.QueueTimeout(c.Tcp.QueueTimeout.AsDuration()))
+	}
+	if ch := c.Tcp.Challenge; ch != nil {
+		mode, ok := netconf.ParseChallengeMode(ch.Mode)
+		if !ok {
+			return nil, errors.Errorf("challenge mode invalid. mode=%s", ch.Mode)
+		}
+		opts = append(opts, tcp.HandshakeChallenge(mode, int(ch.Threshold), int(ch.Difficulty)))
+	}
+	if g != nil {
+		opts = append(opts, tcp.Guard(g))
+	}
+	if q != nil {
+		opts = append(opts, tcp.Admission(q))
+	}
 	if logger != nil {
 		opts = append(opts, tcp.Logger(logger))
 	}
@@ -52,6 +89,7 @@ func NewTCPServer(c *conf.Server, logger log.Logger, rt *router.RouteTable, svc
 	if err != nil {
 		return nil, errors.Wrapf(err, "创建TCP服务器失败。config:%+v", c)
 	}
+	svc.SetLocalPusher(s)
 	return s, nil
 }
 
@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+)
+
+const bearerPrefix = "Bearer "
+
+// authFilter accepts the request carrying one of the configured bearer tokens in the Authorization header.
+// the admin API is closed if no token is configured
+func authFilter(c *conf.Admin) khttp.FilterFunc {
+	var tokens [][]byte
+	if c != nil {
+		for _, t := range c.Tokens {
+			if len(t) > 0 {
+				tokens = append(tokens, []byte(t))
+			}
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !authorized(tokens, r.Header.Get("Authorization")) {
+				khttp.DefaultErrorEncoder(w, r, errors.Unauthorized("ADMIN_UNAUTHORIZED", "admin token invalid"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func authorized(tokens [][]byte, header string) bool {
+	token, ok := strings.CutPrefix(header, bearerPrefix)
+	if !ok || len(token) == 0 {
+		return false
+	}
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare(t, []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
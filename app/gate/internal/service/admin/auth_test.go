@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/data"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/maintenance"
+)
+
+func TestAuthFilter(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	serve := func(c *conf.Admin, header string) int {
+		r := httptest.NewRequest(http.MethodGet, "/admin/guard/bans", nil)
+		if len(header) > 0 {
+			r.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		authFilter(c)(ok).ServeHTTP(w, r)
+		return w.Code
+	}
+
+	c := &conf.Admin{Tokens: []string{"old", "new"}}
+	assert.Equal(t, http.StatusOK, serve(c, "Bearer old"))
+	assert.Equal(t, http.StatusOK, serve(c, "Bearer new"))
+	assert.Equal(t, http.StatusUnauthorized, serve(c, "Bearer other"))
+	assert.Equal(t, http.StatusUnauthorized, serve(c, "new"))
+	assert.Equal(t, http.StatusUnauthorized, serve(c, "Bearer "))
+	assert.Equal(t, http.StatusUnauthorized, serve(c, ""))
+
+	assert.Equal(t, http.StatusUnauthorized, serve(nil, "Bearer "), "closed without a token")
+	assert.Equal(t, http.StatusUnauthorized, serve(&conf.Admin{Tokens: []string{""}}, "Bearer "), "empty tokens are ignored")
+}
+
+func TestRoutesAuthorized(t *testing.T) {
+	m, cleanup, err := maintenance.NewMaintenance(&conf.Maintenance{}, log.DefaultLogger, &data.Data{})
+	require.NoError(t, err)
+	defer cleanup()
+
+	svr := khttp.NewServer()
+	NewAdminService(&conf.Admin{Tokens: []string{"token"}}, log.DefaultLogger, nil, m).RegisterHTTP(svr)
+	serve := func(method, path, header string) int {
+		r := httptest.NewRequest(method, path, nil)
+		if len(header) > 0 {
+			r.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		svr.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	for _, route := range [][2]string{
+		{http.MethodGet, "/admin/guard/bans"},
+		{http.MethodGet, "/admin/maintenance"},
+		{http.MethodPost, "/admin/maintenance"},
+		{http.MethodDelete, "/admin/maintenance?sid=0"},
+		{http.MethodGet, "/admin/maintenance/whitelist"},
+		{http.MethodPost, "/admin/maintenance/whitelist"},
+		{http.MethodDelete, "/admin/maintenance/whitelist?uid=1"},
+	} {
+		assert.Equal(t, http.StatusUnauthorized, serve(route[0], route[1], ""), route)
+		assert.Equal(t, http.StatusUnauthorized, serve(route[0], route[1], "Bearer other"), route)
+	}
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/admin/maintenance", "Bearer token"))
+}
@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/maintenance"
+)
+
+type whitelistRequest struct {
+	UID  int64  `json:"uid"`
+	CIDR string `json:"cidr"`
+}
+
+type whitelistReply struct {
+	UIDs []int64  `json:"uids"`
+	IPs  []string `json:"ips"`
+}
+
+func (s *AdminService) maintenances(ctx khttp.Context) error {
+	return ctx.Result(http.StatusOK, s.maintenance.States())
+}
+
+func (s *AdminService) beginMaintenance(ctx khttp.Context) error {
+	var req maintenance.State
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := s.maintenance.Begin(ctx, req); err != nil {
+		return errors.BadRequest("MAINTENANCE_BEGIN_FAILED", err.Error())
+	}
+
+	s.log.WithContext(ctx).Infof("[admin.Maintenance] maintenance begun. sid=%d eta=%d kick-at=%d message=%s", req.SID, req.ETA, req.KickAt, req.Message)
+	return ctx.Result(http.StatusOK, nil)
+}
+
+func (s *AdminService) endMaintenance(ctx khttp.Context) error {
+	sid, err := strconv.ParseInt(ctx.Query().Get("sid"), 10, 64)
+	if err != nil {
+		return errors.BadRequest("SID_INVALID", err.Error())
+	}
+	if err = s.maintenance.End(ctx, sid); err != nil {
+		return errors.BadRequest("MAINTENANCE_END_FAILED", err.Error())
+	}
+
+	s.log.WithContext(ctx).Infof("[admin.Maintenance] maintenance ended. sid=%d", sid)
+	return ctx.Result(http.StatusOK, nil)
+}
+
+func (s *AdminService) whitelist(ctx khttp.Context) error {
+	uids, ips := s.maintenance.Whitelist()
+	return ctx.Result(http.StatusOK, whitelistReply{UIDs: uids, IPs: ips})
+}
+
+func (s *AdminService) addToWhitelist(ctx khttp.Context) error {
+	var req whitelistRequest
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	var err error
+	switch {
+	case len(req.CIDR) > 0:
+		err = s.maintenance.AddIP(ctx, req.CIDR)
+	case req.UID > 0:
+		err = s.maintenance.AddUID(ctx, req.UID)
+	default:
+		return errors.BadRequest("WHITELIST_INVALID", "uid or cidr is required")
+	}
+	if err != nil {
+		return errors.BadRequest("WHITELIST_ADD_FAILED", err.Error())
+	}
+
+	s.log.WithContext(ctx).Infof("[admin.Maintenance] whitelist added. uid=%d cidr=%s", req.UID, req.CIDR)
+	return ctx.Result(http.StatusOK, nil)
+}
+
+func (s *AdminService) removeFromWhitelist(ctx khttp.Context) error {
+	q := ctx.Query()
+	cidr := q.Get("cidr")
+
+	var err error
+	var uid int64
+	switch {
+	case len(cidr) > 0:
+		err = s.maintenance.RemoveIP(ctx, cidr)
+	case len(q.Get("uid")) > 0:
+		if uid, err = strconv.ParseInt(q.Get("uid"), 10, 64); err != nil {
+			return errors.BadRequest("UID_INVALID", err.Error())
+		}
+		err = s.maintenance.RemoveUID(ctx, uid)
+	default:
+		return errors.BadRequest("WHITELIST_INVALID", "uid or cidr is required")
+	}
+	if err != nil {
+		return errors.BadRequest("WHITELIST_REMOVE_FAILED", err.Error())
+	}
+
+	s.log.WithContext(ctx).Infof("[admin.Maintenance] whitelist removed. uid=%d cidr=%s", uid, cidr)
+	return ctx.Result(http.StatusOK, nil)
+}
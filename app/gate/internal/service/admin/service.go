@@ -0,0 +1,143 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/google/wire"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/conf"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/guard"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/maintenance"
+)
+
+var ProviderSet = wire.NewSet(NewAdminService)
+
+// AdminService is the operation API of the gate, it is only registered on the http server.
+// the guard and the maintenance routes require the admin bearer token, see conf.Admin
+//
+//	GET    /admin/guard/bans
+//	POST   /admin/guard/bans          {"ip": "1.2.3.4", "duration": "10m"}
+//	DELETE /admin/guard/bans?ip=1.2.3.4
+//	GET    /admin/guard/lists/{type}  type is allow or deny
+//	POST   /admin/guard/lists/{type}  {"cidr": "10.0.0.0/8"}
+//	DELETE /admin/guard/lists/{type}?cidr=10.0.0.0/8
+//	GET    /admin/maintenance
+//	POST   /admin/maintenance            {"sid": 0, "message": "back at 10:00", "eta": 1760000000, "kick_at": 1759990000}, sid 0 is all servers
+//	DELETE /admin/maintenance?sid=0
+//	GET    /admin/maintenance/whitelist
+//	POST   /admin/maintenance/whitelist  {"uid": 100} or {"cidr": "10.0.0.0/8"}
+//	DELETE /admin/maintenance/whitelist?uid=100 or ?cidr=10.0.0.0/8
+type AdminService struct {
+	log         *log.Helper
+	auth        khttp.FilterFunc
+	guard       *guard.Guard
+	maintenance *maintenance.Maintenance
+}
+
+func NewAdminService(c *conf.Admin, logger log.Logger, g *guard.Guard, m *maintenance.Maintenance) *AdminService {
+	h := log.NewHelper(log.With(logger, "module", "gate/service/admin"))
+	if c == nil || len(c.Tokens) == 0 {
+		h.Warn("[admin.AdminService] no admin token is configured, the admin API rejects every request")
+	}
+	return &AdminService{
+		log:         h,
+		auth:        authFilter(c),
+		guard:       g,
+		maintenance: m,
+	}
+}
+
+func (s *AdminService) RegisterHTTP(svr *khttp.Server) {
+	r := svr.Route("/admin/guard", s.auth)
+	r.GET("/bans", s.bans)
+	r.POST("/bans", s.ban)
+	r.DELETE("/bans", s.unban)
+	r.GET("/lists/{type}", s.list)
+	r.POST("/lists/{type}", s.addToList)
+	r.DELETE("/lists/{type}", s.removeFromList)
+
+	r = svr.Route("/admin/maintenance", s.auth)
+	r.GET("", s.maintenances)
+	r.POST("", s.beginMaintenance)
+	r.DELETE("", s.endMaintenance)
+	r.GET("/whitelist", s.whitelist)
+	r.POST("/whitelist", s.addToWhitelist)
+	r.DELETE("/whitelist", s.removeFromWhitelist)
+}
+
+type banRequest struct {
+	IP       string `json:"ip"`
+	Duration string `json:"duration"`
+}
+
+type listRequest struct {
+	CIDR string `json:"cidr"`
+}
+
+func (s *AdminService) bans(ctx khttp.Context) error {
+	return ctx.Result(http.StatusOK, s.guard.Bans())
+}
+
+func (s *AdminService) ban(ctx khttp.Context) error {
+	var req banRequest
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	var d time.Duration
+	if len(req.Duration) > 0 {
+		var err error
+		if d, err = time.ParseDuration(req.Duration); err != nil {
+			return errors.BadRequest("DURATION_INVALID", err.Error())
+		}
+	}
+	if err := s.guard.Ban(ctx, req.IP, d); err != nil {
+		return errors.BadRequest("BAN_FAILED", err.Error())
+	}
+
+	s.log.WithContext(ctx).Infof("[admin.Guard] ip banned. ip=%s duration=%s", req.IP, d)
+	return ctx.Result(http.StatusOK, nil)
+}
+
+func (s *AdminService) unban(ctx khttp.Context) error {
+	ip := ctx.Query().Get("ip")
+	if err := s.guard.Unban(ctx, ip); err != nil {
+		return errors.BadRequest("UNBAN_FAILED", err.Error())
+	}
+
+	s.log.WithContext(ctx).Infof("[admin.Guard] ip unbanned. ip=%s", ip)
+	return ctx.Result(http.StatusOK, nil)
+}
+
+func (s *AdminService) list(ctx khttp.Context) error {
+	return ctx.Result(http.StatusOK, s.guard.List(guard.ListType(ctx.Vars().Get("type"))))
+}
+
+func (s *AdminService) addToList(ctx khttp.Context) error {
+	var req listRequest
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	tp := guard.ListType(ctx.Vars().Get("type"))
+	if err := s.guard.AddToList(ctx, tp, req.CIDR); err != nil {
+		return errors.BadRequest("LIST_ADD_FAILED", err.Error())
+	}
+
+	s.log.WithContext(ctx).Infof("[admin.Guard] list added. type=%s cidr=%s", tp, req.CIDR)
+	return ctx.Result(http.StatusOK, nil)
+}
+
+func (s *AdminService) removeFromList(ctx khttp.Context) error {
+	tp := guard.ListType(ctx.Vars().Get("type"))
+	cidr := ctx.Query().Get("cidr")
+	if err := s.guard.RemoveFromList(ctx, tp, cidr); err != nil {
+		return errors.BadRequest("LIST_REMOVE_FAILED", err.Error())
+	}
+
+	s.log.WithContext(ctx).Infof("[admin.Guard] list removed. type=%s cidr=%s", tp, cidr)
+	return ctx.Result(http.StatusOK, nil)
+}
@@ -2,7 +2,9 @@ package push
 
 import (
 	"github.com/google/wire"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/notice"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/topic"
 	v1 "github.com/vulcan-frame/vulcan-gate/app/gate/internal/service/push/v1"
 )
 
-var ProviderSet = wire.NewSet(v1.NewPushService)
+var ProviderSet = wire.NewSet(v1.NewPushService, topic.ProviderSet, notice.ProviderSet)
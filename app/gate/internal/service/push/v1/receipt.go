@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	servicev1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	tcp "github.com/vulcan-frame/vulcan-gate/pkg/net/tcp/server"
+)
+
+// receiptTimeout bounds the wait for each body to be written since it is queued, the bodies still queued after it are reported as queued
+const receiptTimeout = time.Second
+
+// wait fills the receipts of the queued bodies once they are written or dropped,
+// each receipt waits until its own deadline so a slow body does not cut the wait of the ones after it
+func wait(ctx context.Context, receipts []*servicev1.PushReceipt, dones []chan error, deadlines []time.Time) {
+	for i, done := range dones {
+		if done == nil {
+			continue
+		}
+
+		var err error
+		select {
+		case err = <-done:
+		default:
+			timer := time.NewTimer(time.Until(deadlines[i]))
+			select {
+			case err = <-done:
+				timer.Stop()
+			case <-timer.C:
+				continue
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+
+		if err != nil {
+			receipts[i] = dropped(err)
+			continue
+		}
+		receipts[i] = &servicev1.PushReceipt{Status: servicev1.PushReceipt_WRITTEN}
+	}
+}
+
+func dropped(err error) *servicev1.PushReceipt {
+	reason := err.Error()
+	switch {
+	case errors.Is(err, tcp.ErrWorkerNotFound):
+		reason = "user offline"
+	case errors.Is(err, xnet.ErrPushExpired):
+		reason = "expired"
+	case errors.Is(err, xnet.ErrWorkerStopped):
+		reason = "worker stopped"
+	}
+	return &servicev1.PushReceipt{Status: servicev1.PushReceipt_DROPPED, Reason: reason}
+}
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	servicev1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+)
+
+func TestWait(t *testing.T) {
+	now := time.Now()
+	receipts := make([]*servicev1.PushReceipt, 4)
+	dones := make([]chan error, 4)
+	deadlines := make([]time.Time, 4)
+	for i := range receipts {
+		receipts[i] = &servicev1.PushReceipt{Status: servicev1.PushReceipt_QUEUED}
+		dones[i] = make(chan error, 1)
+	}
+	deadlines[0] = now.Add(20 * time.Millisecond)
+	deadlines[1] = now.Add(10 * time.Millisecond)
+	deadlines[2] = now.Add(10 * time.Millisecond)
+	deadlines[3] = now.Add(200 * time.Millisecond)
+
+	// the first body is never written, the ones after it are still waited until their own deadlines
+	dones[1] <- nil
+	dones[2] <- xnet.ErrPushExpired
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		dones[3] <- nil
+	}()
+
+	wait(context.Background(), receipts, dones, deadlines)
+	assert.Equal(t, servicev1.PushReceipt_QUEUED, receipts[0].Status)
+	assert.Equal(t, servicev1.PushReceipt_WRITTEN, receipts[1].Status)
+	assert.Equal(t, servicev1.PushReceipt_DROPPED, receipts[2].Status)
+	assert.Equal(t, "expired", receipts[2].Reason)
+	assert.Equal(t, servicev1.PushReceipt_WRITTEN, receipts[3].Status)
+}
+
+func TestWaitCanceled(t *testing.T) {
+	receipts := []*servicev1.PushReceipt{{Status: servicev1.PushReceipt_QUEUED}}
+	dones := []chan error{make(chan error, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	wait(ctx, receipts, dones, []time.Time{start.Add(time.Second)})
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, servicev1.PushReceipt_QUEUED, receipts[0].Status)
+
+	dones[0] <- errors.New("broken")
+	wait(ctx, receipts, dones, []time.Time{start.Add(time.Second)})
+	assert.Equal(t, servicev1.PushReceipt_DROPPED, receipts[0].Status, "the finished receipt is filled even if the context is done")
+}
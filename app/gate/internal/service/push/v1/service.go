@@ -2,10 +2,19 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/offline"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/topic"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/intra/net/tunnels"
+	"github.com/vulcan-frame/vulcan-gate/app/gate/internal/router"
 	servicev1 "github.com/vulcan-frame/vulcan-gate/gen/api/server/gate/service/push/v1"
+	xnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	vctx "github.com/vulcan-frame/vulcan-gate/pkg/net/context"
 	tcp "github.com/vulcan-frame/vulcan-gate/pkg/net/tcp/server"
+	"github.com/vulcan-frame/vulcan-pkg-app/profile"
 )
 
 var _ servicev1.PushServiceServer = (*PushService)(nil)
@@ -13,26 +22,177 @@ var _ servicev1.PushServiceServer = (*PushService)(nil)
 type PushService struct {
 	servicev1.UnimplementedPushServiceServer
 
-	log    *log.Helper
-	server *tcp.Server
+	log     *log.Helper
+	server  *tcp.Server
+	topics  *topic.Hub
+	offline *offline.Store
+	gateRT  *router.RouteTable
 }
 
-func NewPushService(logger log.Logger, ts *tcp.Server) servicev1.PushServiceServer {
+func NewPushService(logger log.Logger, ts *tcp.Server, hub *topic.Hub, store *offline.Store, gateRT *router.RouteTable) servicev1.PushServiceServer {
 	return &PushService{
 		UnimplementedPushServiceServer: servicev1.UnimplementedPushServiceServer{},
 		log:                            log.NewHelper(log.With(logger, "module", "gate/service/push")),
 		server:                         ts,
+		topics:                         hub,
+		offline:                        store,
+		gateRT:                         gateRT,
 	}
 }
 
+// Push pushes the bodies to the user held by this gate, the bodies marked persist are stored if the user is offline.
+// a receipt is returned per body when the request asks for them, it waits for the bodies to be written for a while.
+// the request is never aborted partway, so a retry does not duplicate the bodies already pushed or stored.
+// the receipts are returned anyway if a body fails to be stored, the failed ones are dropped with the reason
 func (s *PushService) Push(ctx context.Context, req *servicev1.PushRequest) (*servicev1.PushResponse, error) {
-	return &servicev1.PushResponse{}, nil
+	storeFailed := false
+	receipts := make([]*servicev1.PushReceipt, len(req.Bodies))
+	dones := make([]chan error, len(req.Bodies))
+	deadlines := make([]time.Time, len(req.Bodies))
+	for i, body := range req.Bodies {
+		opts := tunnels.PushOptions(body)
+		if req.Receipt {
+			done := make(chan error, 1)
+			opts.Done = func(err error) { done <- err }
+			dones[i] = done
+			deadlines[i] = time.Now().Add(receiptTimeout)
+		}
+
+		receipts[i] = &servicev1.PushReceipt{Status: servicev1.PushReceipt_QUEUED}
+		err := s.server.PushWith(ctx, req.Uid, func(ss xnet.Session) ([]byte, error) {
+			return tunnels.Pack(body)
+		}, opts)
+		if err == nil {
+			continue
+		}
+
+		dones[i] = nil
+		if !errors.Is(err, tcp.ErrWorkerNotFound) {
+			s.log.WithContext(ctx).Errorf("[push.PushService] push failed. uid=%d mod=%d seq=%d %+v", req.Uid, body.Mod, body.Seq, err)
+		}
+		if errors.Is(err, tcp.ErrWorkerNotFound) && body.Persist {
+			if addr, online := s.onlineElsewhere(ctx, req.Uid); online {
+				receipts[i] = &servicev1.PushReceipt{Status: servicev1.PushReceipt_DROPPED, Reason: "user on another gate " + addr}
+				continue
+			}
+			if err = s.offline.Save(ctx, req.Uid, body); err != nil {
+				s.log.WithContext(ctx).Errorf("[push.PushService] offline store failed. uid=%d mod=%d seq=%d %+v", req.Uid, body.Mod, body.Seq, err)
+				receipts[i] = &servicev1.PushReceipt{Status: servicev1.PushReceipt_DROPPED, Reason: "offline store failed"}
+				storeFailed = true
+				continue
+			}
+			receipts[i] = &servicev1.PushReceipt{Status: servicev1.PushReceipt_STORED}
+			continue
+		}
+		receipts[i] = dropped(err)
+	}
+
+	if !req.Receipt {
+		if storeFailed {
+			return &servicev1.PushResponse{Receipts: receipts}, nil
+		}
+		return &servicev1.PushResponse{}, nil
+	}
+	wait(ctx, receipts, dones, deadlines)
+	return &servicev1.PushResponse{Receipts: receipts}, nil
 }
 
+// onlineElsewhere looks the user up in the gate route table, the message for the user served by another gate is not stored,
+// the backend is expected to push it to that gate. the user is taken as offline if the lookup fails
+func (s *PushService) onlineElsewhere(ctx context.Context, uid int64) (string, bool) {
+	addr, err := s.gateRT.Get(ctx, vctx.Color(ctx), uid)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("[push.PushService] gate route lookup failed. uid=%d %+v", uid, err)
+		return "", false
+	}
+	return addr, len(addr) > 0 && addr != profile.GRPCEndpoint()
+}
+
+// Multicast pushes the bodies to the users held by this gate, it is also the target of the multicast envelopes forwarded by the other gates
 func (s *PushService) Multicast(ctx context.Context, req *servicev1.MulticastRequest) (*servicev1.MulticastResponse, error) {
+	for _, body := range req.Bodies {
+		missing, err := s.server.Multicast(ctx, req.Uid, func(ss xnet.Session) ([]byte, error) {
+			return tunnels.Pack(body)
+		}, tunnels.PushOptions(body))
+		if err != nil {
+			s.log.WithContext(ctx).Errorf("[push.PushService] multicast failed. mod=%d seq=%d %+v", body.Mod, body.Seq, err)
+		}
+		if len(missing) > 0 {
+			s.log.WithContext(ctx).Debugf("[push.PushService] multicast users not found. mod=%d seq=%d uids=%v", body.Mod, body.Seq, missing)
+		}
+	}
 	return &servicev1.MulticastResponse{}, nil
 }
 
+// Broadcast pushes the bodies to the sessions matched by the filter held by this gate, the backend calls every gate for the whole cluster.
+// the counts are summed over the bodies, the sessions are matched again for each body, so a session counts once per body
 func (s *PushService) Broadcast(ctx context.Context, req *servicev1.BroadcastRequest) (*servicev1.BroadcastResponse, error) {
-	return &servicev1.BroadcastResponse{}, nil
+	filter, err := tunnels.ParseFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	matched, delivered := 0, 0
+	for _, body := range req.Bodies {
+		m, n, err := s.server.BroadcastWith(ctx, filter, func(ss xnet.Session) ([]byte, error) {
+			return tunnels.Pack(body)
+		}, tunnels.PushOptions(body))
+		if err != nil {
+			s.log.WithContext(ctx).Errorf("[push.PushService] broadcast failed. filter=%s mod=%d seq=%d %+v", req.Filter, body.Mod, body.Seq, err)
+		}
+		matched += m
+		delivered += n
+	}
+	return &servicev1.BroadcastResponse{Matched: int32(matched), Delivered: int32(delivered)}, nil
+}
+
+// PushByTag pushes the bodies to the sessions with the tag held by this gate, the backend calls every gate for the whole cluster
+func (s *PushService) PushByTag(ctx context.Context, req *servicev1.PushByTagRequest) (*servicev1.PushByTagResponse, error) {
+	count := 0
+	for _, body := range req.Bodies {
+		n, err := s.server.PushByTag(ctx, req.Tag, func(ss xnet.Session) ([]byte, error) {
+			return tunnels.Pack(body)
+		}, tunnels.PushOptions(body))
+		if err != nil {
+			s.log.WithContext(ctx).Errorf("[push.PushService] push by tag failed. tag=%s mod=%d seq=%d %+v", req.Tag, body.Mod, body.Seq, err)
+		}
+		count = max(count, n)
+	}
+	return &servicev1.PushByTagResponse{Count: int32(count)}, nil
+}
+
+// PushByTagExpr pushes the bodies to the sessions whose tags match the expression held by this gate
+func (s *PushService) PushByTagExpr(ctx context.Context, req *servicev1.PushByTagExprRequest) (*servicev1.PushByTagExprResponse, error) {
+	expr, err := xnet.ParseTagExpr(req.Expr)
+	if err != nil {
+		return nil, err
+	}
+
+	count := 0
+	for _, body := range req.Bodies {
+		n, err := s.server.PushByTagExpr(ctx, expr, func(ss xnet.Session) ([]byte, error) {
+			return tunnels.Pack(body)
+		}, tunnels.PushOptions(body))
+		if err != nil {
+			s.log.WithContext(ctx).Errorf("[push.PushService] push by tag expr failed. expr=%s mod=%d seq=%d %+v", req.Expr, body.Mod, body.Seq, err)
+		}
+		count = max(count, n)
+	}
+	return &servicev1.PushByTagExprResponse{Count: int32(count)}, nil
+}
+
+// UpdateTags updates the tags of the session held by this gate
+func (s *PushService) UpdateTags(ctx context.Context, req *servicev1.UpdateTagsRequest) (*servicev1.UpdateTagsResponse, error) {
+	if err := s.server.UpdateTags(req.Uid, req.AddTags, req.RemoveTags); err != nil {
+		return nil, err
+	}
+	return &servicev1.UpdateTagsResponse{}, nil
+}
+
+// Publish pushes the bodies to the subscribers of the topic on all gates, it returns once the bodies are queued
+func (s *PushService) Publish(ctx context.Context, req *servicev1.PublishRequest) (*servicev1.PublishResponse, error) {
+	if err := s.topics.Publish(ctx, req.Topic, req.Bodies); err != nil {
+		return nil, err
+	}
+	return &servicev1.PublishResponse{}, nil
 }
@@ -129,12 +129,161 @@ func (SCServerLogout_Code) EnumDescriptor() ([]byte, []int) {
 	return file_message_system_proto_rawDescGZIP(), []int{5, 0}
 }
 
+type SCSubscribe_Code int32
+
+const (
+	SCSubscribe_ErrServer  SCSubscribe_Code = 0 // Please try again later
+	SCSubscribe_Success    SCSubscribe_Code = 1 // Success
+	SCSubscribe_ErrTopic   SCSubscribe_Code = 2 // A topic name is invalid or not allowed
+	SCSubscribe_ErrTooMany SCSubscribe_Code = 3 // Too many topics subscribed
+)
+
+// Enum value maps for SCSubscribe_Code.
+var (
+	SCSubscribe_Code_name = map[int32]string{
+		0: "ErrServer",
+		1: "Success",
+		2: "ErrTopic",
+		3: "ErrTooMany",
+	}
+	SCSubscribe_Code_value = map[string]int32{
+		"ErrServer":  0,
+		"Success":    1,
+		"ErrTopic":   2,
+		"ErrTooMany": 3,
+	}
+)
+
+func (x SCSubscribe_Code) Enum() *SCSubscribe_Code {
+	p := new(SCSubscribe_Code)
+	*p = x
+	return p
+}
+
+func (x SCSubscribe_Code) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SCSubscribe_Code) Descriptor() protoreflect.EnumDescriptor {
+	return file_message_system_proto_enumTypes[2].Descriptor()
+}
+
+func (SCSubscribe_Code) Type() protoreflect.EnumType {
+	return &file_message_system_proto_enumTypes[2]
+}
+
+func (x SCSubscribe_Code) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SCSubscribe_Code.Descriptor instead.
+func (SCSubscribe_Code) EnumDescriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{7, 0}
+}
+
+type SCUnsubscribe_Code int32
+
+const (
+	SCUnsubscribe_ErrServer SCUnsubscribe_Code = 0 // Please try again later
+	SCUnsubscribe_Success   SCUnsubscribe_Code = 1 // Success
+	SCUnsubscribe_ErrTopic  SCUnsubscribe_Code = 2 // A topic name is invalid
+)
+
+// Enum value maps for SCUnsubscribe_Code.
+var (
+	SCUnsubscribe_Code_name = map[int32]string{
+		0: "ErrServer",
+		1: "Success",
+		2: "ErrTopic",
+	}
+	SCUnsubscribe_Code_value = map[string]int32{
+		"ErrServer": 0,
+		"Success":   1,
+		"ErrTopic":  2,
+	}
+)
+
+func (x SCUnsubscribe_Code) Enum() *SCUnsubscribe_Code {
+	p := new(SCUnsubscribe_Code)
+	*p = x
+	return p
+}
+
+func (x SCUnsubscribe_Code) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SCUnsubscribe_Code) Descriptor() protoreflect.EnumDescriptor {
+	return file_message_system_proto_enumTypes[3].Descriptor()
+}
+
+func (SCUnsubscribe_Code) Type() protoreflect.EnumType {
+	return &file_message_system_proto_enumTypes[3]
+}
+
+func (x SCUnsubscribe_Code) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SCUnsubscribe_Code.Descriptor instead.
+func (SCUnsubscribe_Code) EnumDescriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{9, 0}
+}
+
+type SCNotice_Kind int32
+
+const (
+	SCNotice_Marquee     SCNotice_Kind = 0 // Scrolling marquee
+	SCNotice_Maintenance SCNotice_Kind = 1 // Maintenance announcement
+)
+
+// Enum value maps for SCNotice_Kind.
+var (
+	SCNotice_Kind_name = map[int32]string{
+		0: "Marquee",
+		1: "Maintenance",
+	}
+	SCNotice_Kind_value = map[string]int32{
+		"Marquee":     0,
+		"Maintenance": 1,
+	}
+)
+
+func (x SCNotice_Kind) Enum() *SCNotice_Kind {
+	p := new(SCNotice_Kind)
+	*p = x
+	return p
+}
+
+func (x SCNotice_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SCNotice_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_message_system_proto_enumTypes[4].Descriptor()
+}
+
+func (SCNotice_Kind) Type() protoreflect.EnumType {
+	return &file_message_system_proto_enumTypes[4]
+}
+
+func (x SCNotice_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SCNotice_Kind.Descriptor instead.
+func (SCNotice_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{11, 0}
+}
+
 // Handshake body. Client encrypts with "server RSA public key"
 type CSHandshake struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                        // Token received from account/v1/login
-	ServerId      int64                  `protobuf:"varint,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // Server ID
-	Pub           []byte                 `protobuf:"bytes,3,opt,name=pub,proto3" json:"pub,omitempty"`                            // Client RSA public key
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                          // Token received from account/v1/login
+	ServerId      int64                  `protobuf:"varint,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`   // Server ID
+	Pub           []byte                 `protobuf:"bytes,3,opt,name=pub,proto3" json:"pub,omitempty"`                              // Client RSA public key
+	AccountId     string                 `protobuf:"bytes,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // Account ID received from account/v1/login, required by the remote authentication
+	Session       string                 `protobuf:"bytes,5,opt,name=session,proto3" json:"session,omitempty"`                      // Account session received from account/v1/login, required by the remote authentication
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -190,6 +339,20 @@ func (x *CSHandshake) GetPub() []byte {
 	return nil
 }
 
+func (x *CSHandshake) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CSHandshake) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
 // Handshake response. Client decrypts with "client RSA private key"
 type SCHandshake struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -404,6 +567,8 @@ func (x *SCServerUnknownErr) GetMsg() string {
 type SCServerLogout struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          SCServerLogout_Code    `protobuf:"varint,1,opt,name=code,proto3,enum=message.SCServerLogout_Code" json:"code,omitempty"`
+	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`  // Message shown to the user, e.g. the maintenance notice
+	Eta           int64                  `protobuf:"varint,3,opt,name=eta,proto3" json:"eta,omitempty"` // Unix seconds when the server is expected back, 0 if unknown
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -445,59 +610,615 @@ func (x *SCServerLogout) GetCode() SCServerLogout_Code {
 	return SCServerLogout_Server
 }
 
+func (x *SCServerLogout) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *SCServerLogout) GetEta() int64 {
+	if x != nil {
+		return x.Eta
+	}
+	return 0
+}
+
+// Subscribe to topics, e.g. world chat. Messages published on a topic are pushed to its subscribers
+type CSSubscribe struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topics        []string               `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"` // Topic names, at most 16 per request
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CSSubscribe) Reset() {
+	*x = CSSubscribe{}
+	mi := &file_message_system_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CSSubscribe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CSSubscribe) ProtoMessage() {}
+
+func (x *CSSubscribe) ProtoReflect() protoreflect.Message {
+	mi := &file_message_system_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CSSubscribe.ProtoReflect.Descriptor instead.
+func (*CSSubscribe) Descriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CSSubscribe) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+// Subscribe response. Nothing is subscribed unless the code is Success
+type SCSubscribe struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topics        []string               `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"` // Topics subscribed
+	Code          SCSubscribe_Code       `protobuf:"varint,2,opt,name=code,proto3,enum=message.SCSubscribe_Code" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCSubscribe) Reset() {
+	*x = SCSubscribe{}
+	mi := &file_message_system_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCSubscribe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCSubscribe) ProtoMessage() {}
+
+func (x *SCSubscribe) ProtoReflect() protoreflect.Message {
+	mi := &file_message_system_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCSubscribe.ProtoReflect.Descriptor instead.
+func (*SCSubscribe) Descriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SCSubscribe) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *SCSubscribe) GetCode() SCSubscribe_Code {
+	if x != nil {
+		return x.Code
+	}
+	return SCSubscribe_ErrServer
+}
+
+// Unsubscribe from topics
+type CSUnsubscribe struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topics        []string               `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"` // Topic names
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CSUnsubscribe) Reset() {
+	*x = CSUnsubscribe{}
+	mi := &file_message_system_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CSUnsubscribe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CSUnsubscribe) ProtoMessage() {}
+
+func (x *CSUnsubscribe) ProtoReflect() protoreflect.Message {
+	mi := &file_message_system_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CSUnsubscribe.ProtoReflect.Descriptor instead.
+func (*CSUnsubscribe) Descriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CSUnsubscribe) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+// Unsubscribe response. Nothing is unsubscribed unless the code is Success
+type SCUnsubscribe struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topics        []string               `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"` // Topics unsubscribed
+	Code          SCUnsubscribe_Code     `protobuf:"varint,2,opt,name=code,proto3,enum=message.SCUnsubscribe_Code" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCUnsubscribe) Reset() {
+	*x = SCUnsubscribe{}
+	mi := &file_message_system_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCUnsubscribe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCUnsubscribe) ProtoMessage() {}
+
+func (x *SCUnsubscribe) ProtoReflect() protoreflect.Message {
+	mi := &file_message_system_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCUnsubscribe.ProtoReflect.Descriptor instead.
+func (*SCUnsubscribe) Descriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SCUnsubscribe) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *SCUnsubscribe) GetCode() SCUnsubscribe_Code {
+	if x != nil {
+		return x.Code
+	}
+	return SCUnsubscribe_ErrServer
+}
+
+// Acknowledge the offline messages delivered after the handshake. They are deleted from the server and never delivered again.
+// It may be sent while the messages are being received, so fewer of them are delivered again after a broken connection
+type CSOfflineAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // SC index of the last offline message received
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CSOfflineAck) Reset() {
+	*x = CSOfflineAck{}
+	mi := &file_message_system_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CSOfflineAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CSOfflineAck) ProtoMessage() {}
+
+func (x *CSOfflineAck) ProtoReflect() protoreflect.Message {
+	mi := &file_message_system_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CSOfflineAck.ProtoReflect.Descriptor instead.
+func (*CSOfflineAck) Descriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CSOfflineAck) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+// Notice pushed at its start time, and again at its repeat interval until the end time
+type SCNotice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                // Notice ID, the repeated pushes of a notice have the same ID
+	Kind          SCNotice_Kind          `protobuf:"varint,2,opt,name=kind,proto3,enum=message.SCNotice_Kind" json:"kind,omitempty"` // Notice kind
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`                           // Title
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`                       // Content
+	EndTime       int64                  `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // End display time, unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCNotice) Reset() {
+	*x = SCNotice{}
+	mi := &file_message_system_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCNotice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCNotice) ProtoMessage() {}
+
+func (x *SCNotice) ProtoReflect() protoreflect.Message {
+	mi := &file_message_system_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCNotice.ProtoReflect.Descriptor instead.
+func (*SCNotice) Descriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SCNotice) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SCNotice) GetKind() SCNotice_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return SCNotice_Marquee
+}
+
+func (x *SCNotice) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SCNotice) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SCNotice) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+// Login queue position pushed after the handshake while the server is at capacity. The client keeps the connection and waits, the tunnels are usable once the queue is passed
+type SCQueue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      int64                  `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"` // Position in the queue, starting from 1
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`       // Clients waiting in the queue
+	Eta           int64                  `protobuf:"varint,3,opt,name=eta,proto3" json:"eta,omitempty"`           // Estimated wait in seconds, 0 if unknown
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCQueue) Reset() {
+	*x = SCQueue{}
+	mi := &file_message_system_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCQueue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCQueue) ProtoMessage() {}
+
+func (x *SCQueue) ProtoReflect() protoreflect.Message {
+	mi := &file_message_system_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCQueue.ProtoReflect.Descriptor instead.
+func (*SCQueue) Descriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SCQueue) GetPosition() int64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *SCQueue) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SCQueue) GetEta() int64 {
+	if x != nil {
+		return x.Eta
+	}
+	return 0
+}
+
+// Refresh the token of the session without reconnecting. The credential fields are the same as the CSHandshake
+type CSReauth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                          // Token received from account/v1/login
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // Account ID, required by the remote authentication
+	Session       string                 `protobuf:"bytes,3,opt,name=session,proto3" json:"session,omitempty"`                      // Account session, required by the remote authentication
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CSReauth) Reset() {
+	*x = CSReauth{}
+	mi := &file_message_system_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CSReauth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CSReauth) ProtoMessage() {}
+
+func (x *CSReauth) ProtoReflect() protoreflect.Message {
+	mi := &file_message_system_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CSReauth.ProtoReflect.Descriptor instead.
+func (*CSReauth) Descriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CSReauth) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CSReauth) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CSReauth) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+// Re-auth response. The session is logged out instead if the credential is rejected
+type SCReauth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenTimeout  int64                  `protobuf:"varint,1,opt,name=token_timeout,json=tokenTimeout,proto3" json:"token_timeout,omitempty"` // Unix seconds when the refreshed token expires
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCReauth) Reset() {
+	*x = SCReauth{}
+	mi := &file_message_system_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCReauth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCReauth) ProtoMessage() {}
+
+func (x *SCReauth) ProtoReflect() protoreflect.Message {
+	mi := &file_message_system_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCReauth.ProtoReflect.Descriptor instead.
+func (*SCReauth) Descriptor() ([]byte, []int) {
+	return file_message_system_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SCReauth) GetTokenTimeout() int64 {
+	if x != nil {
+		return x.TokenTimeout
+	}
+	return 0
+}
+
 var File_message_system_proto protoreflect.FileDescriptor
 
 var file_message_system_proto_rawDesc = string([]byte{
 	0x0a, 0x14, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a,
 	0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x52, 0x0a,
-	0x0b, 0x43, 0x53, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x10, 0x0a, 0x03, 0x70, 0x75, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x70, 0x75,
-	0x62, 0x22, 0x40, 0x0a, 0x0b, 0x53, 0x43, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65,
-	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x22, 0x2e, 0x0a, 0x0b, 0x43, 0x53, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65,
-	0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54,
-	0x69, 0x6d, 0x65, 0x22, 0x8e, 0x01, 0x0a, 0x0b, 0x53, 0x43, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42,
-	0x65, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x69,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x54, 0x69, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x43, 0x48,
-	0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63,
-	0x6f, 0x64, 0x65, 0x22, 0x2f, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x45,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8b, 0x01,
+	0x0a, 0x0b, 0x43, 0x53, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x70, 0x75, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x70,
+	0x75, 0x62, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x40, 0x0a, 0x0b, 0x53,
+	0x43, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x2e, 0x0a,
+	0x0b, 0x43, 0x53, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x8e, 0x01,
+	0x0a, 0x0b, 0x53, 0x43, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2d,
+	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x43, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65,
+	0x61, 0x74, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x2f, 0x0a,
+	0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x10,
+	0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x72, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x10, 0x02, 0x22, 0x4a,
+	0x0a, 0x12, 0x53, 0x43, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x45, 0x72, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x6d, 0x6f, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0xc2, 0x01, 0x0a, 0x0e, 0x53,
+	0x43, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x12, 0x30, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x43, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4c, 0x6f,
+	0x67, 0x6f, 0x75, 0x74, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73,
+	0x67, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x65, 0x74, 0x61, 0x22, 0x5a, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x61, 0x69, 0x74, 0x69,
+	0x6e, 0x67, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x41, 0x75, 0x74, 0x68, 0x10, 0x02, 0x12, 0x14,
+	0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x67,
+	0x69, 0x6e, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x69, 0x63, 0x6b, 0x65, 0x64, 0x4f, 0x75,
+	0x74, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x10, 0x05, 0x22,
+	0x25, 0x0a, 0x0b, 0x43, 0x53, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x22, 0x96, 0x01, 0x0a, 0x0b, 0x53, 0x43, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x2d,
+	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x43, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x40, 0x0a,
+	0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x10,
+	0x01, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x72, 0x72, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x10, 0x02, 0x12,
+	0x0e, 0x0a, 0x0a, 0x45, 0x72, 0x72, 0x54, 0x6f, 0x6f, 0x4d, 0x61, 0x6e, 0x79, 0x10, 0x03, 0x22,
+	0x27, 0x0a, 0x0d, 0x43, 0x53, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x0d, 0x53, 0x43, 0x55,
+	0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69,
+	0x63, 0x73, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x43, 0x55, 0x6e, 0x73,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x22, 0x30, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x45,
 	0x72, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x72, 0x72, 0x54, 0x69,
-	0x6d, 0x65, 0x10, 0x02, 0x22, 0x4a, 0x0a, 0x12, 0x53, 0x43, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x45, 0x72, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x6f,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x6f, 0x64, 0x12, 0x10, 0x0a, 0x03,
-	0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x10,
-	0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67,
-	0x22, 0x9e, 0x01, 0x0a, 0x0e, 0x53, 0x43, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4c, 0x6f, 0x67,
-	0x6f, 0x75, 0x74, 0x12, 0x30, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x1c, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x43, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52,
-	0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x5a, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0a, 0x0a,
-	0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x61, 0x69,
-	0x74, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x41, 0x75, 0x74, 0x68, 0x10, 0x02,
-	0x12, 0x14, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x4c,
-	0x6f, 0x67, 0x69, 0x6e, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x69, 0x63, 0x6b, 0x65, 0x64,
-	0x4f, 0x75, 0x74, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x10,
-	0x05, 0x32, 0x69, 0x0a, 0x10, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54, 0x43, 0x50, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65,
-	0x61, 0x74, 0x12, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x53, 0x48,
-	0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x1a, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x2e, 0x53, 0x43, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x22, 0x1c,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x3a, 0x01, 0x2a, 0x22, 0x11, 0x2f, 0x73, 0x79, 0x73, 0x74,
-	0x65, 0x6d, 0x2f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x42, 0x1b, 0x5a, 0x19,
-	0x61, 0x70, 0x69, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x3b, 0x63, 0x6c, 0x69, 0x6d, 0x73, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x72, 0x72, 0x54, 0x6f,
+	0x70, 0x69, 0x63, 0x10, 0x02, 0x22, 0x24, 0x0a, 0x0c, 0x43, 0x53, 0x4f, 0x66, 0x66, 0x6c, 0x69,
+	0x6e, 0x65, 0x41, 0x63, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xb7, 0x01, 0x0a, 0x08,
+	0x53, 0x43, 0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2a, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x53, 0x43, 0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0x24, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x61, 0x72, 0x71, 0x75,
+	0x65, 0x65, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x10, 0x01, 0x22, 0x4d, 0x0a, 0x07, 0x53, 0x43, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x65, 0x74, 0x61, 0x22, 0x59, 0x0a, 0x08, 0x43, 0x53, 0x52, 0x65, 0x61, 0x75, 0x74, 0x68,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0x2f, 0x0a, 0x08, 0x53, 0x43, 0x52, 0x65, 0x61, 0x75, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x32, 0x69, 0x0a, 0x10, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54, 0x43, 0x50, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61,
+	0x74, 0x12, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x53, 0x48, 0x65,
+	0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x1a, 0x14, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2e, 0x53, 0x43, 0x48, 0x65, 0x61, 0x72, 0x74, 0x42, 0x65, 0x61, 0x74, 0x22, 0x1c, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x16, 0x3a, 0x01, 0x2a, 0x22, 0x11, 0x2f, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x2f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x42, 0x1b, 0x5a, 0x19, 0x61,
+	0x70, 0x69, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x3b, 0x63, 0x6c, 0x69, 0x6d, 0x73, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -512,28 +1233,43 @@ func file_message_system_proto_rawDescGZIP() []byte {
 	return file_message_system_proto_rawDescData
 }
 
-var file_message_system_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_message_system_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_message_system_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_message_system_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_message_system_proto_goTypes = []any{
 	(SCHeartBeat_Code)(0),      // 0: message.SCHeartBeat.Code
 	(SCServerLogout_Code)(0),   // 1: message.SCServerLogout.Code
-	(*CSHandshake)(nil),        // 2: message.CSHandshake
-	(*SCHandshake)(nil),        // 3: message.SCHandshake
-	(*CSHeartBeat)(nil),        // 4: message.CSHeartBeat
-	(*SCHeartBeat)(nil),        // 5: message.SCHeartBeat
-	(*SCServerUnknownErr)(nil), // 6: message.SCServerUnknownErr
-	(*SCServerLogout)(nil),     // 7: message.SCServerLogout
+	(SCSubscribe_Code)(0),      // 2: message.SCSubscribe.Code
+	(SCUnsubscribe_Code)(0),    // 3: message.SCUnsubscribe.Code
+	(SCNotice_Kind)(0),         // 4: message.SCNotice.Kind
+	(*CSHandshake)(nil),        // 5: message.CSHandshake
+	(*SCHandshake)(nil),        // 6: message.SCHandshake
+	(*CSHeartBeat)(nil),        // 7: message.CSHeartBeat
+	(*SCHeartBeat)(nil),        // 8: message.SCHeartBeat
+	(*SCServerUnknownErr)(nil), // 9: message.SCServerUnknownErr
+	(*SCServerLogout)(nil),     // 10: message.SCServerLogout
+	(*CSSubscribe)(nil),        // 11: message.CSSubscribe
+	(*SCSubscribe)(nil),        // 12: message.SCSubscribe
+	(*CSUnsubscribe)(nil),      // 13: message.CSUnsubscribe
+	(*SCUnsubscribe)(nil),      // 14: message.SCUnsubscribe
+	(*CSOfflineAck)(nil),       // 15: message.CSOfflineAck
+	(*SCNotice)(nil),           // 16: message.SCNotice
+	(*SCQueue)(nil),            // 17: message.SCQueue
+	(*CSReauth)(nil),           // 18: message.CSReauth
+	(*SCReauth)(nil),           // 19: message.SCReauth
 }
 var file_message_system_proto_depIdxs = []int32{
 	0, // 0: message.SCHeartBeat.code:type_name -> message.SCHeartBeat.Code
 	1, // 1: message.SCServerLogout.code:type_name -> message.SCServerLogout.Code
-	4, // 2: message.SystemTCPService.HeartBeat:input_type -> message.CSHeartBeat
-	5, // 3: message.SystemTCPService.HeartBeat:output_type -> message.SCHeartBeat
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 2: message.SCSubscribe.code:type_name -> message.SCSubscribe.Code
+	3, // 3: message.SCUnsubscribe.code:type_name -> message.SCUnsubscribe.Code
+	4, // 4: message.SCNotice.kind:type_name -> message.SCNotice.Kind
+	7, // 5: message.SystemTCPService.HeartBeat:input_type -> message.CSHeartBeat
+	8, // 6: message.SystemTCPService.HeartBeat:output_type -> message.SCHeartBeat
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_message_system_proto_init() }
@@ -546,8 +1282,8 @@ func file_message_system_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_system_proto_rawDesc), len(file_message_system_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   6,
+			NumEnums:      5,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -63,6 +63,10 @@ func (m *CSHandshake) validate(all bool) error {
 
 	// no validation rules for Pub
 
+	// no validation rules for AccountId
+
+	// no validation rules for Session
+
 	if len(errors) > 0 {
 		return CSHandshakeMultiError(errors)
 	}
@@ -579,6 +583,10 @@ func (m *SCServerLogout) validate(all bool) error {
 
 	// no validation rules for Code
 
+	// no validation rules for Msg
+
+	// no validation rules for Eta
+
 	if len(errors) > 0 {
 		return SCServerLogoutMultiError(errors)
 	}
@@ -656,3 +664,925 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = SCServerLogoutValidationError{}
+
+// Validate checks the field values on CSSubscribe with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CSSubscribe) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CSSubscribe with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CSSubscribeMultiError, or
+// nil if none found.
+func (m *CSSubscribe) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CSSubscribe) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return CSSubscribeMultiError(errors)
+	}
+
+	return nil
+}
+
+// CSSubscribeMultiError is an error wrapping multiple validation errors
+// returned by CSSubscribe.ValidateAll() if the designated constraints aren't met.
+type CSSubscribeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CSSubscribeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CSSubscribeMultiError) AllErrors() []error { return m }
+
+// CSSubscribeValidationError is the validation error returned by
+// CSSubscribe.Validate if the designated constraints aren't met.
+type CSSubscribeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CSSubscribeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CSSubscribeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CSSubscribeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CSSubscribeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CSSubscribeValidationError) ErrorName() string { return "CSSubscribeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CSSubscribeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCSSubscribe.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CSSubscribeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CSSubscribeValidationError{}
+
+// Validate checks the field values on SCSubscribe with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SCSubscribe) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SCSubscribe with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SCSubscribeMultiError, or
+// nil if none found.
+func (m *SCSubscribe) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SCSubscribe) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Code
+
+	if len(errors) > 0 {
+		return SCSubscribeMultiError(errors)
+	}
+
+	return nil
+}
+
+// SCSubscribeMultiError is an error wrapping multiple validation errors
+// returned by SCSubscribe.ValidateAll() if the designated constraints aren't met.
+type SCSubscribeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SCSubscribeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SCSubscribeMultiError) AllErrors() []error { return m }
+
+// SCSubscribeValidationError is the validation error returned by
+// SCSubscribe.Validate if the designated constraints aren't met.
+type SCSubscribeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SCSubscribeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SCSubscribeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SCSubscribeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SCSubscribeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SCSubscribeValidationError) ErrorName() string { return "SCSubscribeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SCSubscribeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSCSubscribe.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SCSubscribeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SCSubscribeValidationError{}
+
+// Validate checks the field values on CSUnsubscribe with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CSUnsubscribe) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CSUnsubscribe with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CSUnsubscribeMultiError, or
+// nil if none found.
+func (m *CSUnsubscribe) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CSUnsubscribe) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return CSUnsubscribeMultiError(errors)
+	}
+
+	return nil
+}
+
+// CSUnsubscribeMultiError is an error wrapping multiple validation errors
+// returned by CSUnsubscribe.ValidateAll() if the designated constraints
+// aren't met.
+type CSUnsubscribeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CSUnsubscribeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CSUnsubscribeMultiError) AllErrors() []error { return m }
+
+// CSUnsubscribeValidationError is the validation error returned by
+// CSUnsubscribe.Validate if the designated constraints aren't met.
+type CSUnsubscribeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CSUnsubscribeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CSUnsubscribeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CSUnsubscribeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CSUnsubscribeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CSUnsubscribeValidationError) ErrorName() string { return "CSUnsubscribeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CSUnsubscribeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCSUnsubscribe.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CSUnsubscribeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CSUnsubscribeValidationError{}
+
+// Validate checks the field values on SCUnsubscribe with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SCUnsubscribe) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SCUnsubscribe with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SCUnsubscribeMultiError, or
+// nil if none found.
+func (m *SCUnsubscribe) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SCUnsubscribe) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Code
+
+	if len(errors) > 0 {
+		return SCUnsubscribeMultiError(errors)
+	}
+
+	return nil
+}
+
+// SCUnsubscribeMultiError is an error wrapping multiple validation errors
+// returned by SCUnsubscribe.ValidateAll() if the designated constraints
+// aren't met.
+type SCUnsubscribeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SCUnsubscribeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SCUnsubscribeMultiError) AllErrors() []error { return m }
+
+// SCUnsubscribeValidationError is the validation error returned by
+// SCUnsubscribe.Validate if the designated constraints aren't met.
+type SCUnsubscribeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SCUnsubscribeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SCUnsubscribeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SCUnsubscribeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SCUnsubscribeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SCUnsubscribeValidationError) ErrorName() string { return "SCUnsubscribeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SCUnsubscribeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSCUnsubscribe.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SCUnsubscribeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SCUnsubscribeValidationError{}
+
+// Validate checks the field values on CSOfflineAck with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CSOfflineAck) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CSOfflineAck with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CSOfflineAckMultiError, or
+// nil if none found.
+func (m *CSOfflineAck) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CSOfflineAck) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Index
+
+	if len(errors) > 0 {
+		return CSOfflineAckMultiError(errors)
+	}
+
+	return nil
+}
+
+// CSOfflineAckMultiError is an error wrapping multiple validation errors
+// returned by CSOfflineAck.ValidateAll() if the designated constraints aren't met.
+type CSOfflineAckMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CSOfflineAckMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CSOfflineAckMultiError) AllErrors() []error { return m }
+
+// CSOfflineAckValidationError is the validation error returned by
+// CSOfflineAck.Validate if the designated constraints aren't met.
+type CSOfflineAckValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CSOfflineAckValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CSOfflineAckValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CSOfflineAckValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CSOfflineAckValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CSOfflineAckValidationError) ErrorName() string { return "CSOfflineAckValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CSOfflineAckValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCSOfflineAck.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CSOfflineAckValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CSOfflineAckValidationError{}
+
+// Validate checks the field values on SCNotice with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SCNotice) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SCNotice with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SCNoticeMultiError, or nil
+// if none found.
+func (m *SCNotice) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SCNotice) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Kind
+
+	// no validation rules for Title
+
+	// no validation rules for Content
+
+	// no validation rules for EndTime
+
+	if len(errors) > 0 {
+		return SCNoticeMultiError(errors)
+	}
+
+	return nil
+}
+
+// SCNoticeMultiError is an error wrapping multiple validation errors returned
+// by SCNotice.ValidateAll() if the designated constraints aren't met.
+type SCNoticeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SCNoticeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SCNoticeMultiError) AllErrors() []error { return m }
+
+// SCNoticeValidationError is the validation error returned by
+// SCNotice.Validate if the designated constraints aren't met.
+type SCNoticeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SCNoticeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SCNoticeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SCNoticeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SCNoticeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SCNoticeValidationError) ErrorName() string { return "SCNoticeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SCNoticeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSCNotice.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SCNoticeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SCNoticeValidationError{}
+
+// Validate checks the field values on SCQueue with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SCQueue) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SCQueue with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in SCQueueMultiError, or nil if none found.
+func (m *SCQueue) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SCQueue) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Position
+
+	// no validation rules for Total
+
+	// no validation rules for Eta
+
+	if len(errors) > 0 {
+		return SCQueueMultiError(errors)
+	}
+
+	return nil
+}
+
+// SCQueueMultiError is an error wrapping multiple validation errors returned
+// by SCQueue.ValidateAll() if the designated constraints aren't met.
+type SCQueueMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SCQueueMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SCQueueMultiError) AllErrors() []error { return m }
+
+// SCQueueValidationError is the validation error returned by SCQueue.Validate
+// if the designated constraints aren't met.
+type SCQueueValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SCQueueValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SCQueueValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SCQueueValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SCQueueValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SCQueueValidationError) ErrorName() string { return "SCQueueValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SCQueueValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSCQueue.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SCQueueValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SCQueueValidationError{}
+
+// Validate checks the field values on CSReauth with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CSReauth) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CSReauth with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CSReauthMultiError, or nil
+// if none found.
+func (m *CSReauth) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CSReauth) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Token
+
+	// no validation rules for AccountId
+
+	// no validation rules for Session
+
+	if len(errors) > 0 {
+		return CSReauthMultiError(errors)
+	}
+
+	return nil
+}
+
+// CSReauthMultiError is an error wrapping multiple validation errors returned
+// by CSReauth.ValidateAll() if the designated constraints aren't met.
+type CSReauthMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CSReauthMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CSReauthMultiError) AllErrors() []error { return m }
+
+// CSReauthValidationError is the validation error returned by
+// CSReauth.Validate if the designated constraints aren't met.
+type CSReauthValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CSReauthValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CSReauthValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CSReauthValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CSReauthValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CSReauthValidationError) ErrorName() string { return "CSReauthValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CSReauthValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCSReauth.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CSReauthValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CSReauthValidationError{}
+
+// Validate checks the field values on SCReauth with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SCReauth) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SCReauth with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SCReauthMultiError, or nil
+// if none found.
+func (m *SCReauth) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SCReauth) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for TokenTimeout
+
+	if len(errors) > 0 {
+		return SCReauthMultiError(errors)
+	}
+
+	return nil
+}
+
+// SCReauthMultiError is an error wrapping multiple validation errors returned
+// by SCReauth.ValidateAll() if the designated constraints aren't met.
+type SCReauthMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SCReauthMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SCReauthMultiError) AllErrors() []error { return m }
+
+// SCReauthValidationError is the validation error returned by
+// SCReauth.Validate if the designated constraints aren't met.
+type SCReauthValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SCReauthValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SCReauthValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SCReauthValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SCReauthValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SCReauthValidationError) ErrorName() string { return "SCReauthValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SCReauthValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSCReauth.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SCReauthValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SCReauthValidationError{}
@@ -34,16 +34,34 @@ const (
 	SystemSeq_ServerUnknownErr SystemSeq = 3
 	// Server trigger logout
 	SystemSeq_ServerLogout SystemSeq = 4
+	// Subscribe to topics
+	SystemSeq_Subscribe SystemSeq = 5
+	// Unsubscribe from topics
+	SystemSeq_Unsubscribe SystemSeq = 6
+	// Acknowledge the offline messages
+	SystemSeq_OfflineAck SystemSeq = 7
+	// Notice pushed by the server
+	SystemSeq_Notice SystemSeq = 8
+	// Login queue position
+	SystemSeq_Queue SystemSeq = 9
+	// Refresh the token of the session without reconnecting
+	SystemSeq_Reauth SystemSeq = 10
 )
 
 // Enum value maps for SystemSeq.
 var (
 	SystemSeq_name = map[int32]string{
-		0: "SystemUnknown",
-		1: "Handshake",
-		2: "Heartbeat",
-		3: "ServerUnknownErr",
-		4: "ServerLogout",
+		0:  "SystemUnknown",
+		1:  "Handshake",
+		2:  "Heartbeat",
+		3:  "ServerUnknownErr",
+		4:  "ServerLogout",
+		5:  "Subscribe",
+		6:  "Unsubscribe",
+		7:  "OfflineAck",
+		8:  "Notice",
+		9:  "Queue",
+		10: "Reauth",
 	}
 	SystemSeq_value = map[string]int32{
 		"SystemUnknown":    0,
@@ -51,6 +69,12 @@ var (
 		"Heartbeat":        2,
 		"ServerUnknownErr": 3,
 		"ServerLogout":     4,
+		"Subscribe":        5,
+		"Unsubscribe":      6,
+		"OfflineAck":       7,
+		"Notice":           8,
+		"Queue":            9,
+		"Reauth":           10,
 	}
 )
 
@@ -86,15 +110,21 @@ var File_sequence_system_proto protoreflect.FileDescriptor
 var file_sequence_system_proto_rawDesc = string([]byte{
 	0x0a, 0x15, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x2f, 0x73, 0x79, 0x73, 0x74, 0x65,
 	0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
-	0x65, 0x2a, 0x64, 0x0a, 0x09, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x71, 0x12, 0x11,
-	0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10,
-	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x10, 0x01,
-	0x12, 0x0d, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x10, 0x02, 0x12,
-	0x14, 0x0a, 0x10, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e,
-	0x45, 0x72, 0x72, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4c,
-	0x6f, 0x67, 0x6f, 0x75, 0x74, 0x10, 0x04, 0x42, 0x1c, 0x5a, 0x1a, 0x61, 0x70, 0x69, 0x2f, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x3b, 0x63,
-	0x6c, 0x69, 0x73, 0x65, 0x71, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x2a, 0xb7, 0x01, 0x0a, 0x09, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x71, 0x12,
+	0x11, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e,
+	0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x10,
+	0x01, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x10, 0x02,
+	0x12, 0x14, 0x0a, 0x10, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x45, 0x72, 0x72, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x10, 0x05, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x73, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x10, 0x06, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x66, 0x66, 0x6c,
+	0x69, 0x6e, 0x65, 0x41, 0x63, 0x6b, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x6f, 0x74, 0x69,
+	0x63, 0x65, 0x10, 0x08, 0x12, 0x09, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x75, 0x65, 0x10, 0x09, 0x12,
+	0x0a, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x75, 0x74, 0x68, 0x10, 0x0a, 0x42, 0x1c, 0x5a, 0x1a, 0x61,
+	0x70, 0x69, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x3b, 0x63, 0x6c, 0x69, 0x73, 0x65, 0x71, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 })
 
 var (
@@ -22,10 +22,112 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type PushBody_Priority int32
+
+const (
+	PushBody_NORMAL PushBody_Priority = 0
+	PushBody_HIGH   PushBody_Priority = 1 // Written ahead of the normal and low ones, e.g. combat events
+	PushBody_LOW    PushBody_Priority = 2 // Written after the normal ones, e.g. inventory syncs
+)
+
+// Enum value maps for PushBody_Priority.
+var (
+	PushBody_Priority_name = map[int32]string{
+		0: "NORMAL",
+		1: "HIGH",
+		2: "LOW",
+	}
+	PushBody_Priority_value = map[string]int32{
+		"NORMAL": 0,
+		"HIGH":   1,
+		"LOW":    2,
+	}
+)
+
+func (x PushBody_Priority) Enum() *PushBody_Priority {
+	p := new(PushBody_Priority)
+	*p = x
+	return p
+}
+
+func (x PushBody_Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PushBody_Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_gate_service_push_v1_push_proto_enumTypes[0].Descriptor()
+}
+
+func (PushBody_Priority) Type() protoreflect.EnumType {
+	return &file_gate_service_push_v1_push_proto_enumTypes[0]
+}
+
+func (x PushBody_Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PushBody_Priority.Descriptor instead.
+func (PushBody_Priority) EnumDescriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{14, 0}
+}
+
+type PushReceipt_Status int32
+
+const (
+	PushReceipt_QUEUED  PushReceipt_Status = 0 // Still queued when the receipt is returned
+	PushReceipt_WRITTEN PushReceipt_Status = 1 // Written to the socket
+	PushReceipt_DROPPED PushReceipt_Status = 2 // Dropped for the reason
+	PushReceipt_STORED  PushReceipt_Status = 3 // Stored for the offline user
+)
+
+// Enum value maps for PushReceipt_Status.
+var (
+	PushReceipt_Status_name = map[int32]string{
+		0: "QUEUED",
+		1: "WRITTEN",
+		2: "DROPPED",
+		3: "STORED",
+	}
+	PushReceipt_Status_value = map[string]int32{
+		"QUEUED":  0,
+		"WRITTEN": 1,
+		"DROPPED": 2,
+		"STORED":  3,
+	}
+)
+
+func (x PushReceipt_Status) Enum() *PushReceipt_Status {
+	p := new(PushReceipt_Status)
+	*p = x
+	return p
+}
+
+func (x PushReceipt_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PushReceipt_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_gate_service_push_v1_push_proto_enumTypes[1].Descriptor()
+}
+
+func (PushReceipt_Status) Type() protoreflect.EnumType {
+	return &file_gate_service_push_v1_push_proto_enumTypes[1]
+}
+
+func (x PushReceipt_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PushReceipt_Status.Descriptor instead.
+func (PushReceipt_Status) EnumDescriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{15, 0}
+}
+
 type PushRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
 	Bodies        []*PushBody            `protobuf:"bytes,2,rep,name=bodies,proto3" json:"bodies,omitempty"`
+	Receipt       bool                   `protobuf:"varint,3,opt,name=receipt,proto3" json:"receipt,omitempty"` // Wait for the bodies to be written or dropped and return their receipts
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,8 +176,16 @@ func (x *PushRequest) GetBodies() []*PushBody {
 	return nil
 }
 
+func (x *PushRequest) GetReceipt() bool {
+	if x != nil {
+		return x.Receipt
+	}
+	return false
+}
+
 type PushResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipts      []*PushReceipt         `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"` // In the order of the bodies, set if the receipt is requested or a persist body fails to be stored
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -110,6 +220,13 @@ func (*PushResponse) Descriptor() ([]byte, []int) {
 	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *PushResponse) GetReceipts() []*PushReceipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
 type MulticastRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Uid           []int64                `protobuf:"varint,1,rep,packed,name=uid,proto3" json:"uid,omitempty"`
@@ -201,6 +318,7 @@ func (*MulticastResponse) Descriptor() ([]byte, []int) {
 type BroadcastRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Bodies        []*PushBody            `protobuf:"bytes,1,rep,name=bodies,proto3" json:"bodies,omitempty"`
+	Filter        string                 `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"` // Session filter expression, e.g. `sid in (1, 2) && status == ONLINE_STATUS_GATE`, empty matches all sessions
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -242,8 +360,17 @@ func (x *BroadcastRequest) GetBodies() []*PushBody {
 	return nil
 }
 
+func (x *BroadcastRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
 type BroadcastResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matched       int32                  `protobuf:"varint,1,opt,name=matched,proto3" json:"matched,omitempty"`     // Sessions on this gate matched by the filter, summed over the bodies
+	Delivered     int32                  `protobuf:"varint,2,opt,name=delivered,proto3" json:"delivered,omitempty"` // Matched sessions the bodies are queued to, summed over the bodies
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -278,19 +405,412 @@ func (*BroadcastResponse) Descriptor() ([]byte, []int) {
 	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{5}
 }
 
+func (x *BroadcastResponse) GetMatched() int32 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *BroadcastResponse) GetDelivered() int32 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+type PushByTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"` // Sessions with the tag on this gate
+	Bodies        []*PushBody            `protobuf:"bytes,2,rep,name=bodies,proto3" json:"bodies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushByTagRequest) Reset() {
+	*x = PushByTagRequest{}
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushByTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushByTagRequest) ProtoMessage() {}
+
+func (x *PushByTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushByTagRequest.ProtoReflect.Descriptor instead.
+func (*PushByTagRequest) Descriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PushByTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *PushByTagRequest) GetBodies() []*PushBody {
+	if x != nil {
+		return x.Bodies
+	}
+	return nil
+}
+
+type PushByTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"` // Sessions pushed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushByTagResponse) Reset() {
+	*x = PushByTagResponse{}
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushByTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushByTagResponse) ProtoMessage() {}
+
+func (x *PushByTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushByTagResponse.ProtoReflect.Descriptor instead.
+func (*PushByTagResponse) Descriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PushByTagResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type PushByTagExprRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expr          string                 `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"` // Tag expression, e.g. guild:1 && !(region:eu || region:us)
+	Bodies        []*PushBody            `protobuf:"bytes,2,rep,name=bodies,proto3" json:"bodies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushByTagExprRequest) Reset() {
+	*x = PushByTagExprRequest{}
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushByTagExprRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushByTagExprRequest) ProtoMessage() {}
+
+func (x *PushByTagExprRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushByTagExprRequest.ProtoReflect.Descriptor instead.
+func (*PushByTagExprRequest) Descriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PushByTagExprRequest) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *PushByTagExprRequest) GetBodies() []*PushBody {
+	if x != nil {
+		return x.Bodies
+	}
+	return nil
+}
+
+type PushByTagExprResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"` // Sessions pushed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushByTagExprResponse) Reset() {
+	*x = PushByTagExprResponse{}
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushByTagExprResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushByTagExprResponse) ProtoMessage() {}
+
+func (x *PushByTagExprResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushByTagExprResponse.ProtoReflect.Descriptor instead.
+func (*PushByTagExprResponse) Descriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PushByTagExprResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type UpdateTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	AddTags       []string               `protobuf:"bytes,2,rep,name=add_tags,json=addTags,proto3" json:"add_tags,omitempty"`
+	RemoveTags    []string               `protobuf:"bytes,3,rep,name=remove_tags,json=removeTags,proto3" json:"remove_tags,omitempty"` // Removed after the added ones
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTagsRequest) Reset() {
+	*x = UpdateTagsRequest{}
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTagsRequest) ProtoMessage() {}
+
+func (x *UpdateTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTagsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTagsRequest) Descriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateTagsRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *UpdateTagsRequest) GetAddTags() []string {
+	if x != nil {
+		return x.AddTags
+	}
+	return nil
+}
+
+func (x *UpdateTagsRequest) GetRemoveTags() []string {
+	if x != nil {
+		return x.RemoveTags
+	}
+	return nil
+}
+
+type UpdateTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTagsResponse) Reset() {
+	*x = UpdateTagsResponse{}
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTagsResponse) ProtoMessage() {}
+
+func (x *UpdateTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTagsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTagsResponse) Descriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{11}
+}
+
+type PublishRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"` // Pushed to the subscribers on all gates
+	Bodies        []*PushBody            `protobuf:"bytes,2,rep,name=bodies,proto3" json:"bodies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishRequest) Reset() {
+	*x = PublishRequest{}
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishRequest) ProtoMessage() {}
+
+func (x *PublishRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishRequest.ProtoReflect.Descriptor instead.
+func (*PublishRequest) Descriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PublishRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetBodies() []*PushBody {
+	if x != nil {
+		return x.Bodies
+	}
+	return nil
+}
+
+type PublishResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishResponse) Reset() {
+	*x = PublishResponse{}
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishResponse) ProtoMessage() {}
+
+func (x *PublishResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishResponse.ProtoReflect.Descriptor instead.
+func (*PublishResponse) Descriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{13}
+}
+
 type PushBody struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Mod           int32                  `protobuf:"varint,1,opt,name=mod,proto3" json:"mod,omitempty"`  // Module ID, globally unique
-	Seq           int32                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`  // Message ID within the module, unique within the module
-	Obj           int64                  `protobuf:"varint,3,opt,name=obj,proto3" json:"obj,omitempty"`  // Object ID, according to the business agreement
-	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"` // Message body proto bytes array
+	Mod           int32                  `protobuf:"varint,1,opt,name=mod,proto3" json:"mod,omitempty"`         // Module ID, globally unique
+	Seq           int32                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`         // Message ID within the module, unique within the module
+	Obj           int64                  `protobuf:"varint,3,opt,name=obj,proto3" json:"obj,omitempty"`         // Object ID, according to the business agreement
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`        // Message body proto bytes array
+	Persist       bool                   `protobuf:"varint,5,opt,name=persist,proto3" json:"persist,omitempty"` // Stored if the user is offline and delivered on the next login, only honored by Push
+	Priority      PushBody_Priority      `protobuf:"varint,6,opt,name=priority,proto3,enum=gate.service.push.v1.PushBody_Priority" json:"priority,omitempty"`
+	TtlMs         int32                  `protobuf:"varint,7,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"` // Milliseconds the body may be queued on the gate before it is dropped, 0 means no limit
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PushBody) Reset() {
 	*x = PushBody{}
-	mi := &file_gate_service_push_v1_push_proto_msgTypes[6]
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -302,7 +822,7 @@ func (x *PushBody) String() string {
 func (*PushBody) ProtoMessage() {}
 
 func (x *PushBody) ProtoReflect() protoreflect.Message {
-	mi := &file_gate_service_push_v1_push_proto_msgTypes[6]
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,7 +835,7 @@ func (x *PushBody) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PushBody.ProtoReflect.Descriptor instead.
 func (*PushBody) Descriptor() ([]byte, []int) {
-	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{6}
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PushBody) GetMod() int32 {
@@ -346,6 +866,79 @@ func (x *PushBody) GetData() []byte {
 	return nil
 }
 
+func (x *PushBody) GetPersist() bool {
+	if x != nil {
+		return x.Persist
+	}
+	return false
+}
+
+func (x *PushBody) GetPriority() PushBody_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return PushBody_NORMAL
+}
+
+func (x *PushBody) GetTtlMs() int32 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+type PushReceipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        PushReceipt_Status     `protobuf:"varint,1,opt,name=status,proto3,enum=gate.service.push.v1.PushReceipt_Status" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Why the body is dropped
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushReceipt) Reset() {
+	*x = PushReceipt{}
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushReceipt) ProtoMessage() {}
+
+func (x *PushReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_gate_service_push_v1_push_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushReceipt.ProtoReflect.Descriptor instead.
+func (*PushReceipt) Descriptor() ([]byte, []int) {
+	return file_gate_service_push_v1_push_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PushReceipt) GetStatus() PushReceipt_Status {
+	if x != nil {
+		return x.Status
+	}
+	return PushReceipt_QUEUED
+}
+
+func (x *PushReceipt) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 var File_gate_service_push_v1_push_proto protoreflect.FileDescriptor
 
 var file_gate_service_push_v1_push_proto_rawDesc = string([]byte{
@@ -354,57 +947,150 @@ var file_gate_service_push_v1_push_proto_rawDesc = string([]byte{
 	0x6f, 0x12, 0x14, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
 	0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
 	0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x57, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x71, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73,
 	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65,
 	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75,
-	0x73, 0x68, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x22, 0x0e,
-	0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5c,
-	0x0a, 0x10, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52,
-	0x03, 0x75, 0x69, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68,
-	0x42, 0x6f, 0x64, 0x79, 0x52, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x22, 0x13, 0x0a, 0x11,
-	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x4a, 0x0a, 0x10, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73,
-	0x68, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x22, 0x13, 0x0a,
-	0x11, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x54, 0x0a, 0x08, 0x50, 0x75, 0x73, 0x68, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6d, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x6f, 0x64,
-	0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73,
-	0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x03, 0x6f, 0x62, 0x6a, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0xd8, 0x02, 0x0a, 0x0b, 0x50, 0x75, 0x73,
-	0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5f, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68,
-	0x12, 0x21, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x10, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x3a,
-	0x01, 0x2a, 0x22, 0x05, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x12, 0x73, 0x0a, 0x09, 0x4d, 0x75, 0x6c,
-	0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x12, 0x26, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x75,
-	0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27,
+	0x73, 0x68, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x22, 0x4d, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x67, 0x61, 0x74,
+	0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x08, 0x72,
+	0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x73, 0x22, 0x5c, 0x0a, 0x10, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x36, 0x0a,
+	0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x06, 0x62,
+	0x6f, 0x64, 0x69, 0x65, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x62, 0x0a, 0x10, 0x42, 0x72,
+	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36,
+	0x0a, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
 	0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75,
-	0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x3a,
-	0x01, 0x2a, 0x22, 0x0a, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x12, 0x73,
-	0x0a, 0x09, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x26, 0x2e, 0x67, 0x61,
+	0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x06,
+	0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x4b,
+	0x0a, 0x11, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x22, 0x5c, 0x0a, 0x10, 0x50,
+	0x75, 0x73, 0x68, 0x42, 0x79, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61,
+	0x67, 0x12, 0x36, 0x0a, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x6f, 0x64,
+	0x79, 0x52, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x22, 0x29, 0x0a, 0x11, 0x50, 0x75, 0x73,
+	0x68, 0x42, 0x79, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x62, 0x0a, 0x14, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x54, 0x61,
+	0x67, 0x45, 0x78, 0x70, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x65, 0x78, 0x70, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72,
+	0x12, 0x36, 0x0a, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x6f, 0x64, 0x79,
+	0x52, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73, 0x22, 0x2d, 0x0a, 0x15, 0x50, 0x75, 0x73, 0x68,
+	0x42, 0x79, 0x54, 0x61, 0x67, 0x45, 0x78, 0x70, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x61, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x61, 0x64, 0x64, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
+	0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x5e, 0x0a, 0x0e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x36, 0x0a, 0x06, 0x62, 0x6f, 0x64, 0x69,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x75, 0x73, 0x68, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x06, 0x62, 0x6f, 0x64, 0x69, 0x65, 0x73,
+	0x22, 0x11, 0x0a, 0x0f, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0xf5, 0x01, 0x0a, 0x08, 0x50, 0x75, 0x73, 0x68, 0x42, 0x6f, 0x64, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d,
+	0x6f, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x73, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65,
+	0x72, 0x73, 0x69, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x65, 0x72,
+	0x73, 0x69, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75,
+	0x73, 0x68, 0x42, 0x6f, 0x64, 0x79, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x74, 0x6c,
+	0x5f, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x74, 0x6c, 0x4d, 0x73,
+	0x22, 0x29, 0x0a, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x0a, 0x0a, 0x06,
+	0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x47, 0x48,
+	0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x57, 0x10, 0x02, 0x22, 0xa3, 0x01, 0x0a, 0x0b,
+	0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x67, 0x61,
 	0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e,
-	0x76, 0x31, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64,
-	0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x0f, 0x3a, 0x01, 0x2a, 0x22, 0x0a, 0x2f, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63,
-	0x61, 0x73, 0x74, 0x42, 0x2b, 0x5a, 0x29, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70,
-	0x75, 0x73, 0x68, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x76, 0x31,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x3a, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x0a, 0x0a, 0x06, 0x51, 0x55, 0x45, 0x55, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x57,
+	0x52, 0x49, 0x54, 0x54, 0x45, 0x4e, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x52, 0x4f, 0x50,
+	0x50, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x44, 0x10,
+	0x03, 0x32, 0xb2, 0x06, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x5f, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x21, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67,
+	0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x10, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x3a, 0x01, 0x2a, 0x22, 0x05, 0x2f, 0x70, 0x75,
+	0x73, 0x68, 0x12, 0x73, 0x0a, 0x09, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x12,
+	0x26, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x3a, 0x01, 0x2a, 0x22, 0x0a, 0x2f, 0x6d, 0x75,
+	0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x12, 0x73, 0x0a, 0x09, 0x42, 0x72, 0x6f, 0x61, 0x64,
+	0x63, 0x61, 0x73, 0x74, 0x12, 0x26, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x6f, 0x61,
+	0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x67,
+	0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x3a, 0x01, 0x2a,
+	0x22, 0x0a, 0x2f, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x72, 0x0a, 0x09,
+	0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x54, 0x61, 0x67, 0x12, 0x26, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x27, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x54,
+	0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x0e, 0x3a, 0x01, 0x2a, 0x22, 0x09, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x74, 0x61, 0x67,
+	0x12, 0x83, 0x01, 0x0a, 0x0d, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x54, 0x61, 0x67, 0x45, 0x78,
+	0x70, 0x72, 0x12, 0x2a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79,
+	0x54, 0x61, 0x67, 0x45, 0x78, 0x70, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b,
+	0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75,
+	0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x79, 0x54, 0x61, 0x67, 0x45,
+	0x78, 0x70, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x19, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x13, 0x3a, 0x01, 0x2a, 0x22, 0x0e, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x74, 0x61,
+	0x67, 0x2f, 0x65, 0x78, 0x70, 0x72, 0x12, 0x71, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x54, 0x61, 0x67, 0x73, 0x12, 0x27, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x10, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x3a,
+	0x01, 0x2a, 0x22, 0x05, 0x2f, 0x74, 0x61, 0x67, 0x73, 0x12, 0x6b, 0x0a, 0x07, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x12, 0x24, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x67, 0x61, 0x74,
+	0x65, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x3a, 0x01, 0x2a, 0x22, 0x08, 0x2f, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x42, 0x2b, 0x5a, 0x29, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -419,31 +1105,57 @@ func file_gate_service_push_v1_push_proto_rawDescGZIP() []byte {
 	return file_gate_service_push_v1_push_proto_rawDescData
 }
 
-var file_gate_service_push_v1_push_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_gate_service_push_v1_push_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_gate_service_push_v1_push_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_gate_service_push_v1_push_proto_goTypes = []any{
-	(*PushRequest)(nil),       // 0: gate.service.push.v1.PushRequest
-	(*PushResponse)(nil),      // 1: gate.service.push.v1.PushResponse
-	(*MulticastRequest)(nil),  // 2: gate.service.push.v1.MulticastRequest
-	(*MulticastResponse)(nil), // 3: gate.service.push.v1.MulticastResponse
-	(*BroadcastRequest)(nil),  // 4: gate.service.push.v1.BroadcastRequest
-	(*BroadcastResponse)(nil), // 5: gate.service.push.v1.BroadcastResponse
-	(*PushBody)(nil),          // 6: gate.service.push.v1.PushBody
+	(PushBody_Priority)(0),        // 0: gate.service.push.v1.PushBody.Priority
+	(PushReceipt_Status)(0),       // 1: gate.service.push.v1.PushReceipt.Status
+	(*PushRequest)(nil),           // 2: gate.service.push.v1.PushRequest
+	(*PushResponse)(nil),          // 3: gate.service.push.v1.PushResponse
+	(*MulticastRequest)(nil),      // 4: gate.service.push.v1.MulticastRequest
+	(*MulticastResponse)(nil),     // 5: gate.service.push.v1.MulticastResponse
+	(*BroadcastRequest)(nil),      // 6: gate.service.push.v1.BroadcastRequest
+	(*BroadcastResponse)(nil),     // 7: gate.service.push.v1.BroadcastResponse
+	(*PushByTagRequest)(nil),      // 8: gate.service.push.v1.PushByTagRequest
+	(*PushByTagResponse)(nil),     // 9: gate.service.push.v1.PushByTagResponse
+	(*PushByTagExprRequest)(nil),  // 10: gate.service.push.v1.PushByTagExprRequest
+	(*PushByTagExprResponse)(nil), // 11: gate.service.push.v1.PushByTagExprResponse
+	(*UpdateTagsRequest)(nil),     // 12: gate.service.push.v1.UpdateTagsRequest
+	(*UpdateTagsResponse)(nil),    // 13: gate.service.push.v1.UpdateTagsResponse
+	(*PublishRequest)(nil),        // 14: gate.service.push.v1.PublishRequest
+	(*PublishResponse)(nil),       // 15: gate.service.push.v1.PublishResponse
+	(*PushBody)(nil),              // 16: gate.service.push.v1.PushBody
+	(*PushReceipt)(nil),           // 17: gate.service.push.v1.PushReceipt
 }
 var file_gate_service_push_v1_push_proto_depIdxs = []int32{
-	6, // 0: gate.service.push.v1.PushRequest.bodies:type_name -> gate.service.push.v1.PushBody
-	6, // 1: gate.service.push.v1.MulticastRequest.bodies:type_name -> gate.service.push.v1.PushBody
-	6, // 2: gate.service.push.v1.BroadcastRequest.bodies:type_name -> gate.service.push.v1.PushBody
-	0, // 3: gate.service.push.v1.PushService.Push:input_type -> gate.service.push.v1.PushRequest
-	2, // 4: gate.service.push.v1.PushService.Multicast:input_type -> gate.service.push.v1.MulticastRequest
-	4, // 5: gate.service.push.v1.PushService.Broadcast:input_type -> gate.service.push.v1.BroadcastRequest
-	1, // 6: gate.service.push.v1.PushService.Push:output_type -> gate.service.push.v1.PushResponse
-	3, // 7: gate.service.push.v1.PushService.Multicast:output_type -> gate.service.push.v1.MulticastResponse
-	5, // 8: gate.service.push.v1.PushService.Broadcast:output_type -> gate.service.push.v1.BroadcastResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	16, // 0: gate.service.push.v1.PushRequest.bodies:type_name -> gate.service.push.v1.PushBody
+	17, // 1: gate.service.push.v1.PushResponse.receipts:type_name -> gate.service.push.v1.PushReceipt
+	16, // 2: gate.service.push.v1.MulticastRequest.bodies:type_name -> gate.service.push.v1.PushBody
+	16, // 3: gate.service.push.v1.BroadcastRequest.bodies:type_name -> gate.service.push.v1.PushBody
+	16, // 4: gate.service.push.v1.PushByTagRequest.bodies:type_name -> gate.service.push.v1.PushBody
+	16, // 5: gate.service.push.v1.PushByTagExprRequest.bodies:type_name -> gate.service.push.v1.PushBody
+	16, // 6: gate.service.push.v1.PublishRequest.bodies:type_name -> gate.service.push.v1.PushBody
+	0,  // 7: gate.service.push.v1.PushBody.priority:type_name -> gate.service.push.v1.PushBody.Priority
+	1,  // 8: gate.service.push.v1.PushReceipt.status:type_name -> gate.service.push.v1.PushReceipt.Status
+	2,  // 9: gate.service.push.v1.PushService.Push:input_type -> gate.service.push.v1.PushRequest
+	4,  // 10: gate.service.push.v1.PushService.Multicast:input_type -> gate.service.push.v1.MulticastRequest
+	6,  // 11: gate.service.push.v1.PushService.Broadcast:input_type -> gate.service.push.v1.BroadcastRequest
+	8,  // 12: gate.service.push.v1.PushService.PushByTag:input_type -> gate.service.push.v1.PushByTagRequest
+	10, // 13: gate.service.push.v1.PushService.PushByTagExpr:input_type -> gate.service.push.v1.PushByTagExprRequest
+	12, // 14: gate.service.push.v1.PushService.UpdateTags:input_type -> gate.service.push.v1.UpdateTagsRequest
+	14, // 15: gate.service.push.v1.PushService.Publish:input_type -> gate.service.push.v1.PublishRequest
+	3,  // 16: gate.service.push.v1.PushService.Push:output_type -> gate.service.push.v1.PushResponse
+	5,  // 17: gate.service.push.v1.PushService.Multicast:output_type -> gate.service.push.v1.MulticastResponse
+	7,  // 18: gate.service.push.v1.PushService.Broadcast:output_type -> gate.service.push.v1.BroadcastResponse
+	9,  // 19: gate.service.push.v1.PushService.PushByTag:output_type -> gate.service.push.v1.PushByTagResponse
+	11, // 20: gate.service.push.v1.PushService.PushByTagExpr:output_type -> gate.service.push.v1.PushByTagExprResponse
+	13, // 21: gate.service.push.v1.PushService.UpdateTags:output_type -> gate.service.push.v1.UpdateTagsResponse
+	15, // 22: gate.service.push.v1.PushService.Publish:output_type -> gate.service.push.v1.PublishResponse
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_gate_service_push_v1_push_proto_init() }
@@ -456,13 +1168,14 @@ func file_gate_service_push_v1_push_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gate_service_push_v1_push_proto_rawDesc), len(file_gate_service_push_v1_push_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_gate_service_push_v1_push_proto_goTypes,
 		DependencyIndexes: file_gate_service_push_v1_push_proto_depIdxs,
+		EnumInfos:         file_gate_service_push_v1_push_proto_enumTypes,
 		MessageInfos:      file_gate_service_push_v1_push_proto_msgTypes,
 	}.Build()
 	File_gate_service_push_v1_push_proto = out.File
@@ -93,6 +93,8 @@ func (m *PushRequest) validate(all bool) error {
 
 	}
 
+	// no validation rules for Receipt
+
 	if len(errors) > 0 {
 		return PushRequestMultiError(errors)
 	}
@@ -192,6 +194,40 @@ func (m *PushResponse) validate(all bool) error {
 
 	var errors []error
 
+	for idx, item := range m.GetReceipts() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PushResponseValidationError{
+						field:  fmt.Sprintf("Receipts[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PushResponseValidationError{
+						field:  fmt.Sprintf("Receipts[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PushResponseValidationError{
+					field:  fmt.Sprintf("Receipts[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return PushResponseMultiError(errors)
 	}
@@ -561,6 +597,8 @@ func (m *BroadcastRequest) validate(all bool) error {
 
 	}
 
+	// no validation rules for Filter
+
 	if len(errors) > 0 {
 		return BroadcastRequestMultiError(errors)
 	}
@@ -661,6 +699,10 @@ func (m *BroadcastResponse) validate(all bool) error {
 
 	var errors []error
 
+	// no validation rules for Matched
+
+	// no validation rules for Delivered
+
 	if len(errors) > 0 {
 		return BroadcastResponseMultiError(errors)
 	}
@@ -741,49 +783,78 @@ var _ interface {
 	ErrorName() string
 } = BroadcastResponseValidationError{}
 
-// Validate checks the field values on PushBody with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *PushBody) Validate() error {
+// Validate checks the field values on PushByTagRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PushByTagRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on PushBody with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in PushBodyMultiError, or nil
-// if none found.
-func (m *PushBody) ValidateAll() error {
+// ValidateAll checks the field values on PushByTagRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PushByTagRequestMultiError, or nil if none found.
+func (m *PushByTagRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *PushBody) validate(all bool) error {
+func (m *PushByTagRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Mod
+	// no validation rules for Tag
 
-	// no validation rules for Seq
+	for idx, item := range m.GetBodies() {
+		_, _ = idx, item
 
-	// no validation rules for Obj
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PushByTagRequestValidationError{
+						field:  fmt.Sprintf("Bodies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PushByTagRequestValidationError{
+						field:  fmt.Sprintf("Bodies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PushByTagRequestValidationError{
+					field:  fmt.Sprintf("Bodies[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
 
-	// no validation rules for Data
+	}
 
 	if len(errors) > 0 {
-		return PushBodyMultiError(errors)
+		return PushByTagRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// PushBodyMultiError is an error wrapping multiple validation errors returned
-// by PushBody.ValidateAll() if the designated constraints aren't met.
-type PushBodyMultiError []error
+// PushByTagRequestMultiError is an error wrapping multiple validation errors
+// returned by PushByTagRequest.ValidateAll() if the designated constraints
+// aren't met.
+type PushByTagRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m PushBodyMultiError) Error() string {
+func (m PushByTagRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -792,11 +863,11 @@ func (m PushBodyMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m PushBodyMultiError) AllErrors() []error { return m }
+func (m PushByTagRequestMultiError) AllErrors() []error { return m }
 
-// PushBodyValidationError is the validation error returned by
-// PushBody.Validate if the designated constraints aren't met.
-type PushBodyValidationError struct {
+// PushByTagRequestValidationError is the validation error returned by
+// PushByTagRequest.Validate if the designated constraints aren't met.
+type PushByTagRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -804,22 +875,22 @@ type PushBodyValidationError struct {
 }
 
 // Field function returns field value.
-func (e PushBodyValidationError) Field() string { return e.field }
+func (e PushByTagRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e PushBodyValidationError) Reason() string { return e.reason }
+func (e PushByTagRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e PushBodyValidationError) Cause() error { return e.cause }
+func (e PushByTagRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e PushBodyValidationError) Key() bool { return e.key }
+func (e PushByTagRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e PushBodyValidationError) ErrorName() string { return "PushBodyValidationError" }
+func (e PushByTagRequestValidationError) ErrorName() string { return "PushByTagRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e PushBodyValidationError) Error() string {
+func (e PushByTagRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -831,14 +902,14 @@ func (e PushBodyValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sPushBody.%s: %s%s",
+		"invalid %sPushByTagRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = PushBodyValidationError{}
+var _ error = PushByTagRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -846,4 +917,1008 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = PushBodyValidationError{}
+} = PushByTagRequestValidationError{}
+
+// Validate checks the field values on PushByTagResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PushByTagResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PushByTagResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PushByTagResponseMultiError, or nil if none found.
+func (m *PushByTagResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PushByTagResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Count
+
+	if len(errors) > 0 {
+		return PushByTagResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PushByTagResponseMultiError is an error wrapping multiple validation errors
+// returned by PushByTagResponse.ValidateAll() if the designated constraints
+// aren't met.
+type PushByTagResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PushByTagResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PushByTagResponseMultiError) AllErrors() []error { return m }
+
+// PushByTagResponseValidationError is the validation error returned by
+// PushByTagResponse.Validate if the designated constraints aren't met.
+type PushByTagResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PushByTagResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PushByTagResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PushByTagResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PushByTagResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PushByTagResponseValidationError) ErrorName() string {
+	return "PushByTagResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PushByTagResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPushByTagResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PushByTagResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PushByTagResponseValidationError{}
+
+// Validate checks the field values on PushByTagExprRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PushByTagExprRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PushByTagExprRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PushByTagExprRequestMultiError, or nil if none found.
+func (m *PushByTagExprRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PushByTagExprRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Expr
+
+	for idx, item := range m.GetBodies() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PushByTagExprRequestValidationError{
+						field:  fmt.Sprintf("Bodies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PushByTagExprRequestValidationError{
+						field:  fmt.Sprintf("Bodies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PushByTagExprRequestValidationError{
+					field:  fmt.Sprintf("Bodies[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return PushByTagExprRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PushByTagExprRequestMultiError is an error wrapping multiple validation
+// errors returned by PushByTagExprRequest.ValidateAll() if the designated
+// constraints aren't met.
+type PushByTagExprRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PushByTagExprRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PushByTagExprRequestMultiError) AllErrors() []error { return m }
+
+// PushByTagExprRequestValidationError is the validation error returned by
+// PushByTagExprRequest.Validate if the designated constraints aren't met.
+type PushByTagExprRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PushByTagExprRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PushByTagExprRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PushByTagExprRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PushByTagExprRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PushByTagExprRequestValidationError) ErrorName() string {
+	return "PushByTagExprRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PushByTagExprRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPushByTagExprRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PushByTagExprRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PushByTagExprRequestValidationError{}
+
+// Validate checks the field values on PushByTagExprResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PushByTagExprResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PushByTagExprResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PushByTagExprResponseMultiError, or nil if none found.
+func (m *PushByTagExprResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PushByTagExprResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Count
+
+	if len(errors) > 0 {
+		return PushByTagExprResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PushByTagExprResponseMultiError is an error wrapping multiple validation
+// errors returned by PushByTagExprResponse.ValidateAll() if the designated
+// constraints aren't met.
+type PushByTagExprResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PushByTagExprResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PushByTagExprResponseMultiError) AllErrors() []error { return m }
+
+// PushByTagExprResponseValidationError is the validation error returned by
+// PushByTagExprResponse.Validate if the designated constraints aren't met.
+type PushByTagExprResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PushByTagExprResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PushByTagExprResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PushByTagExprResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PushByTagExprResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PushByTagExprResponseValidationError) ErrorName() string {
+	return "PushByTagExprResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PushByTagExprResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPushByTagExprResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PushByTagExprResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PushByTagExprResponseValidationError{}
+
+// Validate checks the field values on UpdateTagsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UpdateTagsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateTagsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateTagsRequestMultiError, or nil if none found.
+func (m *UpdateTagsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateTagsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Uid
+
+	if len(errors) > 0 {
+		return UpdateTagsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateTagsRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateTagsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateTagsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateTagsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateTagsRequestMultiError) AllErrors() []error { return m }
+
+// UpdateTagsRequestValidationError is the validation error returned by
+// UpdateTagsRequest.Validate if the designated constraints aren't met.
+type UpdateTagsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateTagsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateTagsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateTagsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateTagsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateTagsRequestValidationError) ErrorName() string {
+	return "UpdateTagsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateTagsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateTagsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateTagsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateTagsRequestValidationError{}
+
+// Validate checks the field values on UpdateTagsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateTagsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateTagsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateTagsResponseMultiError, or nil if none found.
+func (m *UpdateTagsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateTagsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return UpdateTagsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateTagsResponseMultiError is an error wrapping multiple validation errors
+// returned by UpdateTagsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateTagsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateTagsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateTagsResponseMultiError) AllErrors() []error { return m }
+
+// UpdateTagsResponseValidationError is the validation error returned by
+// UpdateTagsResponse.Validate if the designated constraints aren't met.
+type UpdateTagsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateTagsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateTagsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateTagsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateTagsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateTagsResponseValidationError) ErrorName() string {
+	return "UpdateTagsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateTagsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateTagsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateTagsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateTagsResponseValidationError{}
+
+// Validate checks the field values on PublishRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PublishRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PublishRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PublishRequestMultiError,
+// or nil if none found.
+func (m *PublishRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PublishRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Topic
+
+	for idx, item := range m.GetBodies() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PublishRequestValidationError{
+						field:  fmt.Sprintf("Bodies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PublishRequestValidationError{
+						field:  fmt.Sprintf("Bodies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PublishRequestValidationError{
+					field:  fmt.Sprintf("Bodies[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return PublishRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PublishRequestMultiError is an error wrapping multiple validation errors
+// returned by PublishRequest.ValidateAll() if the designated constraints
+// aren't met.
+type PublishRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PublishRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PublishRequestMultiError) AllErrors() []error { return m }
+
+// PublishRequestValidationError is the validation error returned by
+// PublishRequest.Validate if the designated constraints aren't met.
+type PublishRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PublishRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PublishRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PublishRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PublishRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PublishRequestValidationError) ErrorName() string { return "PublishRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PublishRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPublishRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PublishRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PublishRequestValidationError{}
+
+// Validate checks the field values on PublishResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PublishResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PublishResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PublishResponseMultiError, or nil if none found.
+func (m *PublishResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PublishResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return PublishResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PublishResponseMultiError is an error wrapping multiple validation errors
+// returned by PublishResponse.ValidateAll() if the designated constraints
+// aren't met.
+type PublishResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PublishResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PublishResponseMultiError) AllErrors() []error { return m }
+
+// PublishResponseValidationError is the validation error returned by
+// PublishResponse.Validate if the designated constraints aren't met.
+type PublishResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PublishResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PublishResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PublishResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PublishResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PublishResponseValidationError) ErrorName() string { return "PublishResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PublishResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPublishResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PublishResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PublishResponseValidationError{}
+
+// Validate checks the field values on PushBody with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PushBody) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PushBody with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PushBodyMultiError, or nil
+// if none found.
+func (m *PushBody) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PushBody) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Mod
+
+	// no validation rules for Seq
+
+	// no validation rules for Obj
+
+	// no validation rules for Data
+
+	// no validation rules for Persist
+
+	// no validation rules for Priority
+
+	// no validation rules for TtlMs
+
+	if len(errors) > 0 {
+		return PushBodyMultiError(errors)
+	}
+
+	return nil
+}
+
+// PushBodyMultiError is an error wrapping multiple validation errors returned
+// by PushBody.ValidateAll() if the designated constraints aren't met.
+type PushBodyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PushBodyMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PushBodyMultiError) AllErrors() []error { return m }
+
+// PushBodyValidationError is the validation error returned by
+// PushBody.Validate if the designated constraints aren't met.
+type PushBodyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PushBodyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PushBodyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PushBodyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PushBodyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PushBodyValidationError) ErrorName() string { return "PushBodyValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PushBodyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPushBody.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PushBodyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PushBodyValidationError{}
+
+// Validate checks the field values on PushReceipt with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PushReceipt) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PushReceipt with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PushReceiptMultiError, or
+// nil if none found.
+func (m *PushReceipt) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PushReceipt) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Status
+
+	// no validation rules for Reason
+
+	if len(errors) > 0 {
+		return PushReceiptMultiError(errors)
+	}
+
+	return nil
+}
+
+// PushReceiptMultiError is an error wrapping multiple validation errors
+// returned by PushReceipt.ValidateAll() if the designated constraints aren't met.
+type PushReceiptMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PushReceiptMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PushReceiptMultiError) AllErrors() []error { return m }
+
+// PushReceiptValidationError is the validation error returned by
+// PushReceipt.Validate if the designated constraints aren't met.
+type PushReceiptValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PushReceiptValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PushReceiptValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PushReceiptValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PushReceiptValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PushReceiptValidationError) ErrorName() string { return "PushReceiptValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PushReceiptValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPushReceipt.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PushReceiptValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PushReceiptValidationError{}
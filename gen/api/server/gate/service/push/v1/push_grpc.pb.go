@@ -19,9 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PushService_Push_FullMethodName      = "/gate.service.push.v1.PushService/Push"
-	PushService_Multicast_FullMethodName = "/gate.service.push.v1.PushService/Multicast"
-	PushService_Broadcast_FullMethodName = "/gate.service.push.v1.PushService/Broadcast"
+	PushService_Push_FullMethodName          = "/gate.service.push.v1.PushService/Push"
+	PushService_Multicast_FullMethodName     = "/gate.service.push.v1.PushService/Multicast"
+	PushService_Broadcast_FullMethodName     = "/gate.service.push.v1.PushService/Broadcast"
+	PushService_PushByTag_FullMethodName     = "/gate.service.push.v1.PushService/PushByTag"
+	PushService_PushByTagExpr_FullMethodName = "/gate.service.push.v1.PushService/PushByTagExpr"
+	PushService_UpdateTags_FullMethodName    = "/gate.service.push.v1.PushService/UpdateTags"
+	PushService_Publish_FullMethodName       = "/gate.service.push.v1.PushService/Publish"
 )
 
 // PushServiceClient is the client API for PushService service.
@@ -35,6 +39,10 @@ type PushServiceClient interface {
 	Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*PushResponse, error)
 	Multicast(ctx context.Context, in *MulticastRequest, opts ...grpc.CallOption) (*MulticastResponse, error)
 	Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+	PushByTag(ctx context.Context, in *PushByTagRequest, opts ...grpc.CallOption) (*PushByTagResponse, error)
+	PushByTagExpr(ctx context.Context, in *PushByTagExprRequest, opts ...grpc.CallOption) (*PushByTagExprResponse, error)
+	UpdateTags(ctx context.Context, in *UpdateTagsRequest, opts ...grpc.CallOption) (*UpdateTagsResponse, error)
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
 }
 
 type pushServiceClient struct {
@@ -75,6 +83,46 @@ func (c *pushServiceClient) Broadcast(ctx context.Context, in *BroadcastRequest,
 	return out, nil
 }
 
+func (c *pushServiceClient) PushByTag(ctx context.Context, in *PushByTagRequest, opts ...grpc.CallOption) (*PushByTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushByTagResponse)
+	err := c.cc.Invoke(ctx, PushService_PushByTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pushServiceClient) PushByTagExpr(ctx context.Context, in *PushByTagExprRequest, opts ...grpc.CallOption) (*PushByTagExprResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushByTagExprResponse)
+	err := c.cc.Invoke(ctx, PushService_PushByTagExpr_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pushServiceClient) UpdateTags(ctx context.Context, in *UpdateTagsRequest, opts ...grpc.CallOption) (*UpdateTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTagsResponse)
+	err := c.cc.Invoke(ctx, PushService_UpdateTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pushServiceClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishResponse)
+	err := c.cc.Invoke(ctx, PushService_Publish_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PushServiceServer is the server API for PushService service.
 // All implementations must embed UnimplementedPushServiceServer
 // for forward compatibility.
@@ -86,6 +134,10 @@ type PushServiceServer interface {
 	Push(context.Context, *PushRequest) (*PushResponse, error)
 	Multicast(context.Context, *MulticastRequest) (*MulticastResponse, error)
 	Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error)
+	PushByTag(context.Context, *PushByTagRequest) (*PushByTagResponse, error)
+	PushByTagExpr(context.Context, *PushByTagExprRequest) (*PushByTagExprResponse, error)
+	UpdateTags(context.Context, *UpdateTagsRequest) (*UpdateTagsResponse, error)
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
 	mustEmbedUnimplementedPushServiceServer()
 }
 
@@ -105,6 +157,18 @@ func (UnimplementedPushServiceServer) Multicast(context.Context, *MulticastReque
 func (UnimplementedPushServiceServer) Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
 }
+func (UnimplementedPushServiceServer) PushByTag(context.Context, *PushByTagRequest) (*PushByTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushByTag not implemented")
+}
+func (UnimplementedPushServiceServer) PushByTagExpr(context.Context, *PushByTagExprRequest) (*PushByTagExprResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushByTagExpr not implemented")
+}
+func (UnimplementedPushServiceServer) UpdateTags(context.Context, *UpdateTagsRequest) (*UpdateTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTags not implemented")
+}
+func (UnimplementedPushServiceServer) Publish(context.Context, *PublishRequest) (*PublishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
 func (UnimplementedPushServiceServer) mustEmbedUnimplementedPushServiceServer() {}
 func (UnimplementedPushServiceServer) testEmbeddedByValue()                     {}
 
@@ -180,6 +244,78 @@ func _PushService_Broadcast_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PushService_PushByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).PushByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_PushByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).PushByTag(ctx, req.(*PushByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PushService_PushByTagExpr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushByTagExprRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).PushByTagExpr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_PushByTagExpr_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).PushByTagExpr(ctx, req.(*PushByTagExprRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PushService_UpdateTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).UpdateTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_UpdateTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).UpdateTags(ctx, req.(*UpdateTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PushService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_Publish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).Publish(ctx, req.(*PublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PushService_ServiceDesc is the grpc.ServiceDesc for PushService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -199,6 +335,22 @@ var PushService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Broadcast",
 			Handler:    _PushService_Broadcast_Handler,
 		},
+		{
+			MethodName: "PushByTag",
+			Handler:    _PushService_PushByTag_Handler,
+		},
+		{
+			MethodName: "PushByTagExpr",
+			Handler:    _PushService_PushByTagExpr_Handler,
+		},
+		{
+			MethodName: "UpdateTags",
+			Handler:    _PushService_UpdateTags_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _PushService_Publish_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "gate/service/push/v1/push.proto",
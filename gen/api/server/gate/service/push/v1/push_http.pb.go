@@ -21,12 +21,20 @@ const _ = http.SupportPackageIsVersion1
 
 const OperationPushServiceBroadcast = "/gate.service.push.v1.PushService/Broadcast"
 const OperationPushServiceMulticast = "/gate.service.push.v1.PushService/Multicast"
+const OperationPushServicePublish = "/gate.service.push.v1.PushService/Publish"
 const OperationPushServicePush = "/gate.service.push.v1.PushService/Push"
+const OperationPushServicePushByTag = "/gate.service.push.v1.PushService/PushByTag"
+const OperationPushServicePushByTagExpr = "/gate.service.push.v1.PushService/PushByTagExpr"
+const OperationPushServiceUpdateTags = "/gate.service.push.v1.PushService/UpdateTags"
 
 type PushServiceHTTPServer interface {
 	Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error)
 	Multicast(context.Context, *MulticastRequest) (*MulticastResponse, error)
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
 	Push(context.Context, *PushRequest) (*PushResponse, error)
+	PushByTag(context.Context, *PushByTagRequest) (*PushByTagResponse, error)
+	PushByTagExpr(context.Context, *PushByTagExprRequest) (*PushByTagExprResponse, error)
+	UpdateTags(context.Context, *UpdateTagsRequest) (*UpdateTagsResponse, error)
 }
 
 func RegisterPushServiceHTTPServer(s *http.Server, srv PushServiceHTTPServer) {
@@ -34,6 +42,10 @@ func RegisterPushServiceHTTPServer(s *http.Server, srv PushServiceHTTPServer) {
 	r.POST("/push", _PushService_Push0_HTTP_Handler(srv))
 	r.POST("/multicast", _PushService_Multicast0_HTTP_Handler(srv))
 	r.POST("/broadcast", _PushService_Broadcast0_HTTP_Handler(srv))
+	r.POST("/push/tag", _PushService_PushByTag0_HTTP_Handler(srv))
+	r.POST("/push/tag/expr", _PushService_PushByTagExpr0_HTTP_Handler(srv))
+	r.POST("/tags", _PushService_UpdateTags0_HTTP_Handler(srv))
+	r.POST("/publish", _PushService_Publish0_HTTP_Handler(srv))
 }
 
 func _PushService_Push0_HTTP_Handler(srv PushServiceHTTPServer) func(ctx http.Context) error {
@@ -102,10 +114,102 @@ func _PushService_Broadcast0_HTTP_Handler(srv PushServiceHTTPServer) func(ctx ht
 	}
 }
 
+func _PushService_PushByTag0_HTTP_Handler(srv PushServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in PushByTagRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPushServicePushByTag)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.PushByTag(ctx, req.(*PushByTagRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PushByTagResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _PushService_PushByTagExpr0_HTTP_Handler(srv PushServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in PushByTagExprRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPushServicePushByTagExpr)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.PushByTagExpr(ctx, req.(*PushByTagExprRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PushByTagExprResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _PushService_UpdateTags0_HTTP_Handler(srv PushServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UpdateTagsRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPushServiceUpdateTags)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UpdateTags(ctx, req.(*UpdateTagsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UpdateTagsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _PushService_Publish0_HTTP_Handler(srv PushServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in PublishRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationPushServicePublish)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.Publish(ctx, req.(*PublishRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PublishResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 type PushServiceHTTPClient interface {
 	Broadcast(ctx context.Context, req *BroadcastRequest, opts ...http.CallOption) (rsp *BroadcastResponse, err error)
 	Multicast(ctx context.Context, req *MulticastRequest, opts ...http.CallOption) (rsp *MulticastResponse, err error)
+	Publish(ctx context.Context, req *PublishRequest, opts ...http.CallOption) (rsp *PublishResponse, err error)
 	Push(ctx context.Context, req *PushRequest, opts ...http.CallOption) (rsp *PushResponse, err error)
+	PushByTag(ctx context.Context, req *PushByTagRequest, opts ...http.CallOption) (rsp *PushByTagResponse, err error)
+	PushByTagExpr(ctx context.Context, req *PushByTagExprRequest, opts ...http.CallOption) (rsp *PushByTagExprResponse, err error)
+	UpdateTags(ctx context.Context, req *UpdateTagsRequest, opts ...http.CallOption) (rsp *UpdateTagsResponse, err error)
 }
 
 type PushServiceHTTPClientImpl struct {
@@ -142,6 +246,19 @@ func (c *PushServiceHTTPClientImpl) Multicast(ctx context.Context, in *Multicast
 	return &out, nil
 }
 
+func (c *PushServiceHTTPClientImpl) Publish(ctx context.Context, in *PublishRequest, opts ...http.CallOption) (*PublishResponse, error) {
+	var out PublishResponse
+	pattern := "/publish"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPushServicePublish))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *PushServiceHTTPClientImpl) Push(ctx context.Context, in *PushRequest, opts ...http.CallOption) (*PushResponse, error) {
 	var out PushResponse
 	pattern := "/push"
@@ -154,3 +271,42 @@ func (c *PushServiceHTTPClientImpl) Push(ctx context.Context, in *PushRequest, o
 	}
 	return &out, nil
 }
+
+func (c *PushServiceHTTPClientImpl) PushByTag(ctx context.Context, in *PushByTagRequest, opts ...http.CallOption) (*PushByTagResponse, error) {
+	var out PushByTagResponse
+	pattern := "/push/tag"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPushServicePushByTag))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *PushServiceHTTPClientImpl) PushByTagExpr(ctx context.Context, in *PushByTagExprRequest, opts ...http.CallOption) (*PushByTagExprResponse, error) {
+	var out PushByTagExprResponse
+	pattern := "/push/tag/expr"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPushServicePushByTagExpr))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *PushServiceHTTPClientImpl) UpdateTags(ctx context.Context, in *UpdateTagsRequest, opts ...http.CallOption) (*UpdateTagsResponse, error) {
+	var out UpdateTagsResponse
+	pattern := "/tags"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationPushServiceUpdateTags))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
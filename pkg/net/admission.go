@@ -0,0 +1,12 @@
+package net
+
+import "context"
+
+// Admission limits the sessions admitted after the handshake, the excess ones wait in the login queue with the connection kept.
+// Admit is called after the challenge and the authentication, so the session is the authenticated one.
+// it blocks until the session is admitted or ctx is done, ctx is done when the client leaves or waits longer than the queue timeout,
+// push writes the queue position messages to the waiting client.
+// release is called once when the admitted session is disconnected
+type Admission interface {
+	Admit(ctx context.Context, ss Session, push func(out []byte) error) (release func(), err error)
+}
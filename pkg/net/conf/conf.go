@@ -68,6 +68,16 @@ func Default(env Env) *Config {
 		RequestIdleTimeout:    time.Second * 60,
 		WaitMainTunnelTimeout: time.Second * 30,
 		StopTimeout:           time.Second * 3,
+		MaxSessionAge:         0,
+		SessionGracePeriod:    time.Minute,
+		EnforceTokenExpiry:    false,
+		ChallengeMode:         ChallengeModeOff,
+		ChallengeThreshold:    256,
+		ChallengeDifficulty:   0,
+		TunnelIdleTimeout:     0,
+		MaxTunnelsPerType:     0,
+		TunnelFailureTTL:      time.Second,
+		QueueTimeout:          time.Minute * 10,
 	}
 	bucket := &Bucket{
 		BucketSize: 32,
@@ -117,6 +127,39 @@ type Worker struct {
 	RequestIdleTimeout    time.Duration
 	WaitMainTunnelTimeout time.Duration
 	StopTimeout           time.Duration
+	MaxSessionAge         time.Duration // 0 means the session age is unlimited
+	SessionGracePeriod    time.Duration // the session lives this long after the token expires or the max age is reached
+	EnforceTokenExpiry    bool          // the session is logged out when the token expires, otherwise the token is only checked on handshake
+	ChallengeMode         ChallengeMode
+	ChallengeThreshold    int           // in auto mode the challenge is required when the in-flight handshakes exceed it
+	ChallengeDifficulty   int           // the leading zero bits of the proof-of-work, 0 means cookie only
+	ChallengeTTL          time.Duration // 0 means HandshakeTimeout
+	TunnelIdleTimeout     time.Duration // the auxiliary tunnels idle longer than it are closed, 0 means never
+	MaxTunnelsPerType     int           // the least recently used auxiliary tunnel is closed when the cap is reached, 0 means unlimited
+	TunnelFailureTTL      time.Duration // the tunnel creation of the same type fails fast for it after a failure, 0 means never
+	QueueTimeout          time.Duration // the client waiting in the login queue longer than it is disconnected
+}
+
+// ChallengeMode decides when the client must pass the challenge before the handshake
+type ChallengeMode int
+
+const (
+	ChallengeModeOff ChallengeMode = iota
+	ChallengeModeAuto
+	ChallengeModeAlways
+)
+
+func ParseChallengeMode(s string) (ChallengeMode, bool) {
+	switch strings.ToLower(s) {
+	case "", "off":
+		return ChallengeModeOff, true
+	case "auto":
+		return ChallengeModeAuto, true
+	case "always":
+		return ChallengeModeAlways, true
+	default:
+		return ChallengeModeOff, false
+	}
 }
 
 type Bucket struct {
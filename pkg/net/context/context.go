@@ -25,6 +25,7 @@ const (
 	CtxReferer     = "x-md-global-referer" // example: gate:10.0.1.31 or player:10.0.2.31
 	CtxClientIP    = "x-md-global-client-ip"
 	CtxGateReferer = "x-md-global-gate-referer" // example: 10.0.1.31:9100#10001
+	CtxReattach    = "x-md-local-reattach"      // the attempt number when the gate rebuilds a broken tunnel stream
 )
 
 var Keys = []string{CtxSID, CtxUID, CtxOID, CtxStatus, CtxColor, CtxReferer, CtxClientIP, CtxGateReferer}
@@ -114,6 +115,20 @@ func Status(ctx context.Context) int64 {
 	return 0
 }
 
+// SetReattach tells the backend that the tunnel stream replaces a broken one of the same session
+func SetReattach(ctx context.Context, attempt int) context.Context {
+	return metadata.AppendToClientContext(ctx, CtxReattach, strconv.Itoa(attempt))
+}
+
+// Reattach returns the attempt number of the reattached stream, 0 means the stream is a new one
+func Reattach(ctx context.Context) int {
+	if md, ok := metadata.FromServerContext(ctx); ok {
+		attempt, _ := strconv.Atoi(md.Get(CtxReattach))
+		return attempt
+	}
+	return 0
+}
+
 func SetClientIP(ctx context.Context, ip string) context.Context {
 	if len(ip) == 0 {
 		return ctx
@@ -153,6 +168,15 @@ func RemoteAddr(conn net.Conn) string {
 	return addr.String()
 }
 
+// RemoteIP is the remote address without the port
+func RemoteIP(conn net.Conn) string {
+	addr := RemoteAddr(conn)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
 func LocalAddr(conn net.Conn) string {
 	if conn == nil {
 		return ""
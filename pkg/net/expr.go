@@ -0,0 +1,149 @@
+package net
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// exprParser parses the operands combined by ! && || and the parentheses, && binds tighter than ||.
+// the tag expressions and the session filters share it and differ only in the tokens and the operands
+type exprParser[T any] struct {
+	name string // names the expression in the errors
+	src  string
+	pos  int
+	tok  string // the peeked token
+
+	ops    []string // the operator tokens, the longer ones first
+	stops  string   // the characters which end a bare word
+	quoted bool     // the quoted strings are single tokens
+
+	operand func(tok string) (T, error)
+	not     func(x T) T
+	and     func(l, r T) T
+	or      func(l, r T) T
+}
+
+func (p *exprParser[T]) parse() (x T, err error) {
+	if x, err = p.parseOr(); err != nil {
+		return
+	}
+	if tok := p.next(); tok != "" {
+		err = p.unexpected(tok)
+	}
+	return
+}
+
+func (p *exprParser[T]) errorf(format string, args ...any) error {
+	return errors.Errorf("%s invalid. %s expr=%s", p.name, fmt.Sprintf(format, args...), p.src)
+}
+
+func (p *exprParser[T]) unexpected(tok string) error {
+	return p.errorf("unexpected %q at %d", tok, p.pos)
+}
+
+func (p *exprParser[T]) peek() string {
+	if p.tok == "" {
+		p.tok = p.scan()
+	}
+	return p.tok
+}
+
+func (p *exprParser[T]) next() string {
+	tok := p.peek()
+	p.tok = ""
+	return tok
+}
+
+func (p *exprParser[T]) scan() string {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+	if p.pos >= len(p.src) {
+		return ""
+	}
+
+	rest := p.src[p.pos:]
+	for _, op := range p.ops {
+		if strings.HasPrefix(rest, op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+
+	if p.quoted && rest[0] == '"' {
+		// the quoted string keeps its quotes, an unterminated one runs to the end and fails to unquote
+		end := strings.IndexByte(rest[1:], '"')
+		if end < 0 {
+			p.pos = len(p.src)
+			return rest
+		}
+		p.pos += end + 2
+		return rest[:end+2]
+	}
+
+	end := strings.IndexAny(rest, p.stops)
+	if end < 0 {
+		end = len(rest)
+	}
+	if end == 0 {
+		// a single & or |
+		p.pos++
+		return rest[:1]
+	}
+	p.pos += end
+	return rest[:end]
+}
+
+func (p *exprParser[T]) parseOr() (T, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return l, err
+	}
+	for p.peek() == "||" {
+		p.next()
+		r, err := p.parseAnd()
+		if err != nil {
+			return r, err
+		}
+		l = p.or(l, r)
+	}
+	return l, nil
+}
+
+func (p *exprParser[T]) parseAnd() (T, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return l, err
+	}
+	for p.peek() == "&&" {
+		p.next()
+		r, err := p.parseUnary()
+		if err != nil {
+			return r, err
+		}
+		l = p.and(l, r)
+	}
+	return l, nil
+}
+
+func (p *exprParser[T]) parseUnary() (x T, err error) {
+	switch tok := p.next(); tok {
+	case "!":
+		if x, err = p.parseUnary(); err != nil {
+			return
+		}
+		return p.not(x), nil
+	case "(":
+		if x, err = p.parseOr(); err != nil {
+			return
+		}
+		if p.next() != ")" {
+			err = p.errorf("missing ) at %d", p.pos)
+		}
+		return
+	default:
+		return p.operand(tok)
+	}
+}
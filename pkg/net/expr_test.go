@@ -0,0 +1,18 @@
+package net
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExprParserErrors(t *testing.T) {
+	_, err := ParseTagExpr("(guild:1")
+	assert.EqualError(t, err, "tag expression invalid. missing ) at 8 expr=(guild:1")
+
+	_, err = ParseSessionFilter("sid == 1 & uid == 2", nil)
+	assert.EqualError(t, err, `session filter invalid. unexpected "&" at 10 expr=sid == 1 & uid == 2`)
+
+	_, err = ParseSessionFilter("name == a", nil)
+	assert.EqualError(t, err, `session filter invalid. unknown field "name" at 4 expr=name == a`)
+}
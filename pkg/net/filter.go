@@ -0,0 +1,235 @@
+package net
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SessionFilter matches the session fields, e.g. `sid in (1, 2) && color == canary && login < "2026-10-01T00:00:00Z"`.
+// the fields are uid, sid, status, login(the unix seconds of the handshake) and color, the comparisons are == != < <= > >= and in,
+// combined by ! && || and the parentheses, && binds tighter than ||
+type SessionFilter interface {
+	Match(ss Session) bool
+}
+
+// SessionFilterFunc adapts the function to the SessionFilter
+type SessionFilterFunc func(ss Session) bool
+
+func (f SessionFilterFunc) Match(ss Session) bool { return f(ss) }
+
+var (
+	intFields = map[string]func(Session) int64{
+		"uid":    Session.UID,
+		"sid":    Session.SID,
+		"status": Session.Status,
+		"login":  Session.StartTime,
+	}
+	strFields = map[string]func(Session) string{
+		"color": Session.Color,
+	}
+)
+
+type matchAll struct{}
+
+func (matchAll) Match(Session) bool { return true }
+
+type notFilter struct{ x SessionFilter }
+
+func (f notFilter) Match(ss Session) bool { return !f.x.Match(ss) }
+
+type andFilter struct{ l, r SessionFilter }
+
+func (f andFilter) Match(ss Session) bool { return f.l.Match(ss) && f.r.Match(ss) }
+
+type orFilter struct{ l, r SessionFilter }
+
+func (f orFilter) Match(ss Session) bool { return f.l.Match(ss) || f.r.Match(ss) }
+
+type intFilter struct {
+	field  func(Session) int64
+	op     string
+	values []int64
+}
+
+func (f intFilter) Match(ss Session) bool {
+	v := f.field(ss)
+	switch f.op {
+	case "==":
+		return v == f.values[0]
+	case "!=":
+		return v != f.values[0]
+	case "<":
+		return v < f.values[0]
+	case "<=":
+		return v <= f.values[0]
+	case ">":
+		return v > f.values[0]
+	case ">=":
+		return v >= f.values[0]
+	default:
+		return slices.Contains(f.values, v)
+	}
+}
+
+type strFilter struct {
+	field  func(Session) string
+	op     string
+	values []string
+}
+
+func (f strFilter) Match(ss Session) bool {
+	v := f.field(ss)
+	switch f.op {
+	case "==":
+		return v == f.values[0]
+	case "!=":
+		return v != f.values[0]
+	default:
+		return slices.Contains(f.values, v)
+	}
+}
+
+// ParseSessionFilter parses the session filter, the empty one matches all sessions.
+// the values of the int fields are the numbers or the names in consts, such as the online status names,
+// the login also takes a quoted RFC3339 time. the values of the color are the quoted strings or the bare words
+func ParseSessionFilter(s string, consts map[string]int64) (SessionFilter, error) {
+	if strings.TrimSpace(s) == "" {
+		return matchAll{}, nil
+	}
+
+	p := &filterParser{consts: consts}
+	p.exprParser = exprParser[SessionFilter]{
+		name:    "session filter",
+		src:     s,
+		ops:     []string{"&&", "||", "==", "!=", "<=", ">=", "!", "<", ">", "(", ")", ","},
+		stops:   " &|!=<>(),\"",
+		quoted:  true,
+		operand: p.compare,
+		not:     func(x SessionFilter) SessionFilter { return notFilter{x: x} },
+		and:     func(l, r SessionFilter) SessionFilter { return andFilter{l: l, r: r} },
+		or:      func(l, r SessionFilter) SessionFilter { return orFilter{l: l, r: r} },
+	}
+	return p.parse()
+}
+
+type filterParser struct {
+	exprParser[SessionFilter]
+
+	consts map[string]int64
+}
+
+func (p *filterParser) compare(field string) (SessionFilter, error) {
+	intField, isInt := intFields[field]
+	strField, isStr := strFields[field]
+	if !isInt && !isStr {
+		return nil, p.errorf("unknown field %q at %d", field, p.pos)
+	}
+
+	op := p.next()
+	var raw []string
+	switch op {
+	case "==", "!=":
+		raw = []string{p.next()}
+	case "<", "<=", ">", ">=":
+		if isStr {
+			return nil, p.errorf("%s does not support %s", field, op)
+		}
+		raw = []string{p.next()}
+	case "in":
+		values, err := p.list()
+		if err != nil {
+			return nil, err
+		}
+		raw = values
+	default:
+		return nil, p.unexpected(op)
+	}
+
+	if isStr {
+		values := make([]string, 0, len(raw))
+		for _, tok := range raw {
+			v, err := p.str(tok)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return strFilter{field: strField, op: op, values: values}, nil
+	}
+
+	values := make([]int64, 0, len(raw))
+	for _, tok := range raw {
+		v, err := p.int(field, tok)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return intFilter{field: intField, op: op, values: values}, nil
+}
+
+// list parses the values of in, e.g. `(1, 2, 3)`
+func (p *filterParser) list() ([]string, error) {
+	if tok := p.next(); tok != "(" {
+		return nil, p.unexpected(tok)
+	}
+
+	var values []string
+	for {
+		values = append(values, p.next())
+		switch tok := p.next(); tok {
+		case ",":
+		case ")":
+			return values, nil
+		default:
+			return nil, p.unexpected(tok)
+		}
+	}
+}
+
+func isValue(tok string) bool {
+	return tok != "" && !strings.ContainsAny(tok[:1], "&|!=<>(),")
+}
+
+func (p *filterParser) str(tok string) (string, error) {
+	if !isValue(tok) {
+		return "", p.unexpected(tok)
+	}
+	if tok[0] != '"' {
+		return tok, nil
+	}
+	v, err := strconv.Unquote(tok)
+	if err != nil {
+		return "", p.errorf("bad string %s", tok)
+	}
+	return v, nil
+}
+
+func (p *filterParser) int(field, tok string) (int64, error) {
+	if !isValue(tok) {
+		return 0, p.unexpected(tok)
+	}
+	if tok[0] == '"' {
+		if field != "login" {
+			return 0, p.errorf("%s takes a number", field)
+		}
+		s, err := p.str(tok)
+		if err != nil {
+			return 0, err
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return 0, p.errorf("bad time %s", tok)
+		}
+		return t.Unix(), nil
+	}
+	if v, err := strconv.ParseInt(tok, 10, 64); err == nil {
+		return v, nil
+	}
+	if v, ok := p.consts[tok]; ok {
+		return v, nil
+	}
+	return 0, p.errorf("unknown value %q of %s", tok, field)
+}
@@ -0,0 +1,45 @@
+package net
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSessionFilter(t *testing.T) {
+	login := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC).Unix()
+	ss := NewSession(100, 7, login-60, nil, nil, false, "canary", 1)
+	consts := map[string]int64{"ONLINE_STATUS_GATE": 1, "ONLINE_STATUS_ADMIN": 2}
+
+	cases := []struct {
+		filter string
+		match  bool
+	}{
+		{"", true},
+		{"sid == 7", true},
+		{"sid != 7", false},
+		{"sid in (1, 7, 9)", true},
+		{"sid in (1,2)", false},
+		{"color == canary", true},
+		{`color == "canary" && uid >= 100`, true},
+		{"color in (blue, green)", false},
+		{"status == ONLINE_STATUS_GATE && !(status == ONLINE_STATUS_ADMIN)", true},
+		{`login < "2026-10-01T00:00:00Z"`, true},
+		{`login >= "2026-10-01T00:00:00Z" || sid > 10`, false},
+		{"uid < 50 || sid == 7 && color != blue", true},
+	}
+	for _, c := range cases {
+		f, err := ParseSessionFilter(c.filter, consts)
+		assert.Nil(t, err, c.filter)
+		assert.Equal(t, c.match, f.Match(ss), c.filter)
+	}
+
+	for _, filter := range []string{
+		"sid", "sid == ", "sid == x", "name == a", "color < a", "sid in 1", "sid in (1, 2",
+		"(sid == 1", "sid == 1)", "sid == 1 & uid == 2", `login < "yesterday"`, `sid == "1"`, `color == "a`,
+	} {
+		_, err := ParseSessionFilter(filter, consts)
+		assert.NotNil(t, err, filter)
+	}
+}
@@ -0,0 +1,8 @@
+package net
+
+// Guard decides whether a remote ip is allowed to connect, it is told about every failed handshake.
+// the sessions of an ip that is banned after the handshake are logged out
+type Guard interface {
+	Allow(ip string) bool
+	OnHandshakeFailed(ip string, err error)
+}
@@ -0,0 +1,41 @@
+package net
+
+import "github.com/pkg/errors"
+
+// HandshakeKeyIDMagic starts the handshake frame carrying the key id of the RSA key in plain text before the secret,
+// the frame is magic | len(id) | id | secret. the legacy frame is the bare secret, the RSA ciphertext of exactly the modulus size,
+// so the gate tells them apart by the length and never by the first byte, which a legacy ciphertext can start with too
+const HandshakeKeyIDMagic byte = 0xFF
+
+// MaxHandshakeKeyIDLen is the longest key id the frame carries in its one byte length
+const MaxHandshakeKeyIDLen = 0xFF
+
+// HandshakeKeyIDFrame prefixes the encrypted handshake with the key id, it is sent by the client
+func HandshakeKeyIDFrame(id string, secret []byte) ([]byte, error) {
+	if len(id) == 0 || len(id) > MaxHandshakeKeyIDLen {
+		return nil, errors.Errorf("handshake key id length invalid. len=%d", len(id))
+	}
+
+	out := make([]byte, 0, 2+len(id)+len(secret))
+	out = append(out, HandshakeKeyIDMagic, byte(len(id)))
+	out = append(out, id...)
+	return append(out, secret...), nil
+}
+
+// ParseHandshakeKeyIDFrame returns the key id and the secret of the frame built by HandshakeKeyIDFrame
+func ParseHandshakeKeyIDFrame(frame []byte) (id string, secret []byte, err error) {
+	if len(frame) < 2 || frame[0] != HandshakeKeyIDMagic {
+		return "", nil, errors.New("handshake key id frame invalid")
+	}
+
+	n := int(frame[1])
+	if n == 0 || len(frame) < 2+n {
+		return "", nil, errors.Errorf("handshake key id truncated. len=%d", n)
+	}
+	return string(frame[2 : 2+n]), frame[2+n:], nil
+}
+
+// HandshakeKeyIDFrameSize is the size of the key id frame of the id and the RSA ciphertext of the modulus size
+func HandshakeKeyIDFrameSize(id string, modulusSize int) int {
+	return 2 + len(id) + modulusSize
+}
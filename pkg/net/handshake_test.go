@@ -0,0 +1,29 @@
+package net
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandshakeKeyIDFrame(t *testing.T) {
+	out, err := HandshakeKeyIDFrame("h2", []byte("secret"))
+	assert.Nil(t, err)
+	assert.Equal(t, HandshakeKeyIDFrameSize("h2", 6), len(out))
+
+	id, secret, err := ParseHandshakeKeyIDFrame(out)
+	assert.Nil(t, err)
+	assert.Equal(t, "h2", id)
+	assert.Equal(t, []byte("secret"), secret)
+
+	_, err = HandshakeKeyIDFrame("", nil)
+	assert.NotNil(t, err)
+	_, err = HandshakeKeyIDFrame(strings.Repeat("h", MaxHandshakeKeyIDLen+1), nil)
+	assert.NotNil(t, err)
+
+	_, _, err = ParseHandshakeKeyIDFrame([]byte("secret"))
+	assert.NotNil(t, err)
+	_, _, err = ParseHandshakeKeyIDFrame([]byte{HandshakeKeyIDMagic, 3, 'h'})
+	assert.NotNil(t, err)
+}
@@ -1,23 +1,216 @@
 package health
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	khttp "github.com/go-kratos/kratos/v2/transport/http"
 	"github.com/go-kratos/swagger-api/openapiv2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+const (
+	probeReady = "ready"
+	probeLive  = "live"
+
+	statusOK       = "ok"
+	statusFailed   = "failed"
+	statusDraining = "draining"
+
+	checkTimeout = time.Second * 2
+)
+
+var checkUpGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "vulcan",
+	Subsystem: "health",
+	Name:      "check_up",
+	Help:      "1 when the last run of the check by probe(ready, live) passed, 0 when it failed",
+}, []string{"probe", "check"})
+
+var checkLatencyGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "vulcan",
+	Subsystem: "health",
+	Name:      "check_latency_seconds",
+	Help:      "the latency of the last run of the check by probe(ready, live)",
+}, []string{"probe", "check"})
+
+var drainingGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: "vulcan",
+	Subsystem: "health",
+	Name:      "draining",
+	Help:      "1 when the server is draining and not ready for the new connections",
+})
+
+// Check returns an error when the dependency or the component checked is not healthy
+type Check func(ctx context.Context) error
+
+type check struct {
+	name string
+	fn   Check
+}
+
+// Result is the JSON detail of a probe
+type Result struct {
+	Status   string        `json:"status"`
+	Draining bool          `json:"draining,omitempty"`
+	Checks   []CheckResult `json:"checks"`
+}
+
+type CheckResult struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// Server serves the metrics and the probes.
+// /health is always 200, /ready fails when a readiness check fails or the server is draining,
+// /live fails when a liveness check fails, the orchestrator restarts the process then
 type Server struct {
 	*khttp.Server
+
+	mu       sync.RWMutex
+	ready    []check
+	live     []check
+	draining func() bool
 }
 
 func NewServer(addr string) *Server {
-	s := khttp.NewServer(khttp.Address(addr))
+	s := &Server{
+		Server: khttp.NewServer(khttp.Address(addr)),
+	}
 	s.HandlePrefix("/q/", openapiv2.NewHandler())
 	s.Handle("/metrics", promhttp.Handler())
 	s.HandleFunc("/health", func(w khttp.ResponseWriter, r *khttp.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
-	return &Server{s}
+	s.HandleFunc("/ready", func(w khttp.ResponseWriter, r *khttp.Request) {
+		write(w, s.Ready(r.Context()))
+	})
+	s.HandleFunc("/live", func(w khttp.ResponseWriter, r *khttp.Request) {
+		write(w, s.Live(r.Context()))
+	})
+	return s
+}
+
+// Readiness adds the check run by /ready
+func (s *Server) Readiness(name string, fn Check) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ready = append(s.ready, check{name: name, fn: fn})
+}
+
+// Liveness adds the check run by /live
+func (s *Server) Liveness(name string, fn Check) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.live = append(s.live, check{name: name, fn: fn})
+}
+
+// Draining sets the func reporting whether the server is draining, the draining server is not ready
+func (s *Server) Draining(fn func() bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.draining = fn
+}
+
+// Ready runs the readiness checks
+func (s *Server) Ready(ctx context.Context) *Result {
+	s.mu.RLock()
+	checks, draining := s.ready, s.draining
+	s.mu.RUnlock()
+
+	ret := run(ctx, probeReady, checks)
+	if draining != nil && draining() {
+		ret.Draining = true
+		ret.Status = statusDraining
+		drainingGauge.Set(1)
+	} else {
+		drainingGauge.Set(0)
+	}
+	return ret
+}
+
+// Live runs the liveness checks
+func (s *Server) Live(ctx context.Context) *Result {
+	s.mu.RLock()
+	checks := s.live
+	s.mu.RUnlock()
+
+	return run(ctx, probeLive, checks)
+}
+
+// run runs the checks concurrently, each of them is limited by checkTimeout
+func run(ctx context.Context, probe string, checks []check) *Result {
+	ret := &Result{
+		Status: statusOK,
+		Checks: make([]CheckResult, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ret.Checks[i] = runCheck(ctx, probe, c)
+		}()
+	}
+	wg.Wait()
+
+	for _, c := range ret.Checks {
+		if c.Status != statusOK {
+			ret.Status = statusFailed
+			break
+		}
+	}
+	return ret
+}
+
+func runCheck(ctx context.Context, probe string, c check) (ret CheckResult) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	ret.Name = c.name
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			ret.Status, ret.Error = statusFailed, fmt.Sprintf("check panic: %v", r)
+		}
+
+		latency := time.Since(start)
+		ret.LatencyMs = latency.Milliseconds()
+		checkLatencyGauge.WithLabelValues(probe, c.name).Set(latency.Seconds())
+		if ret.Status == statusOK {
+			checkUpGauge.WithLabelValues(probe, c.name).Set(1)
+		} else {
+			checkUpGauge.WithLabelValues(probe, c.name).Set(0)
+		}
+	}()
+
+	if err := c.fn(ctx); err != nil {
+		ret.Status, ret.Error = statusFailed, err.Error()
+		return
+	}
+	ret.Status = statusOK
+	return
+}
+
+func write(w http.ResponseWriter, ret *Result) {
+	code := http.StatusOK
+	if ret.Status != statusOK {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(ret)
 }
@@ -0,0 +1,78 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReady(t *testing.T) {
+	s := NewServer(":0")
+	s.Readiness("ok", func(ctx context.Context) error { return nil })
+
+	ret := s.Ready(context.Background())
+	assert.Equal(t, statusOK, ret.Status)
+	assert.Equal(t, []string{"ok"}, names(ret))
+
+	failed := errors.New("unreachable")
+	s.Readiness("redis", func(ctx context.Context) error { return failed })
+	s.Readiness("panic", func(ctx context.Context) error { panic("boom") })
+	ret = s.Ready(context.Background())
+	assert.Equal(t, statusFailed, ret.Status)
+	assert.Equal(t, []string{"ok", "redis", "panic"}, names(ret))
+	assert.Equal(t, statusOK, ret.Checks[0].Status)
+	assert.Equal(t, "unreachable", ret.Checks[1].Error)
+	assert.Equal(t, statusFailed, ret.Checks[2].Status)
+
+	// the live probe is not affected by the readiness checks
+	assert.Equal(t, statusOK, s.Live(context.Background()).Status)
+}
+
+func TestDraining(t *testing.T) {
+	s := NewServer(":0")
+	draining := false
+	s.Draining(func() bool { return draining })
+
+	assert.Equal(t, statusOK, s.Ready(context.Background()).Status)
+	draining = true
+	ret := s.Ready(context.Background())
+	assert.Equal(t, statusDraining, ret.Status)
+	assert.True(t, ret.Draining)
+}
+
+func TestHandler(t *testing.T) {
+	s := NewServer(":0")
+	live := error(nil)
+	s.Liveness("accept", func(ctx context.Context) error { return live })
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/live", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	live = errors.New("stuck")
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/live", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var ret Result
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &ret))
+	assert.Equal(t, statusFailed, ret.Status)
+	assert.Equal(t, "stuck", ret.Checks[0].Error)
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func names(ret *Result) []string {
+	names := make([]string, 0, len(ret.Checks))
+	for _, c := range ret.Checks {
+		names = append(names, c.Name)
+	}
+	return names
+}
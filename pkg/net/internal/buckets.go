@@ -4,6 +4,7 @@ import (
 	"maps"
 	"sync"
 
+	vnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net/conf"
 )
 
@@ -14,12 +15,14 @@ var (
 type Buckets struct {
 	buckets    []*Bucket
 	bucketSize uint32
+	tags       *tagIndex
 }
 
 func NewBuckets(c *conf.Bucket) *Buckets {
 	bs := &Buckets{
 		buckets:    make([]*Bucket, c.BucketSize),
 		bucketSize: uint32(c.BucketSize),
+		tags:       newTagIndex(),
 	}
 
 	for i := 0; i < c.BucketSize; i++ {
@@ -38,17 +41,36 @@ func (bs *Buckets) Worker(key uint64) *Worker {
 	return bs.Bucket(key).get(key)
 }
 
+// Put indexes the tags set before and watches the later tag updates of the worker
 func (bs *Buckets) Put(w *Worker) *Worker {
 	b := bs.Bucket(w.WID())
-	return b.put(w)
+	old := b.put(w)
+
+	// the observer is set before the snapshot, so no update is lost between them
+	w.Session().SetTagsObserver(func(_ vnet.Session, added, removed []string) {
+		bs.tags.update(w, added, removed)
+	})
+	bs.tags.update(w, w.Session().Tags(), nil)
+	return old
 }
 
 func (bs *Buckets) Del(w *Worker) {
+	w.Session().SetTagsObserver(nil)
+	bs.tags.update(w, nil, w.Session().Tags())
+
 	if b := bs.Bucket(w.WID()); b != nil {
 		b.del(w)
 	}
 }
 
+// Len returns the count of the workers held by the buckets
+func (bs *Buckets) Len() (n int) {
+	for _, b := range bs.buckets {
+		n += b.len()
+	}
+	return
+}
+
 func (bs *Buckets) Walk(f func(w *Worker) bool) {
 	for _, b := range bs.buckets {
 		b.walk(f)
@@ -73,6 +95,19 @@ func (bs *Buckets) GetByUIDs(uids []int64) []*Worker {
 	return workers
 }
 
+// GetByTag returns the workers held by the buckets whose sessions have the tag
+func (bs *Buckets) GetByTag(tag string) []*Worker {
+	ws := bs.tags.get(tag)
+	workers := ws[:0]
+	for _, w := range ws {
+		// the observer may be called after the worker is deleted
+		if bs.GetByUID(w.UID()) == w {
+			workers = append(workers, w)
+		}
+	}
+	return workers
+}
+
 type Bucket struct {
 	sync.RWMutex
 
@@ -107,7 +142,8 @@ func (b *Bucket) del(dw *Worker) {
 	if w, ok = b.workers[dw.WID()]; ok {
 		if w == dw {
 			delete(b.workers, w.WID())
-			uidWidMap.Delete(w.UID())
+			// the uid may be held by the new worker which replaced this one
+			uidWidMap.CompareAndDelete(w.UID(), w.WID())
 		}
 	}
 }
@@ -120,6 +156,13 @@ func (b *Bucket) get(key uint64) (w *Worker) {
 	return
 }
 
+func (b *Bucket) len() int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return len(b.workers)
+}
+
 func (b *Bucket) walk(f func(w *Worker) (continued bool)) {
 	snapshot := b.snapshot()
 	for _, w := range snapshot {
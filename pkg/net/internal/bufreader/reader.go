@@ -111,6 +111,19 @@ func (r *Reader) ReadFull(n int) ([]byte, error) {
 	return result, nil
 }
 
+// Wait blocks until a byte can be read or the underlying reader fails, the byte is kept for the next read
+func (r *Reader) Wait() error {
+	if r.unreadBytes() > 0 {
+		return nil
+	}
+	if r.cleanedUp {
+		return ErrBufReaderAlreadyClosed
+	}
+
+	r.r, r.w = 0, 0
+	return r.readAtLeast(1)
+}
+
 func (r *Reader) readAtLeast(bytes int) error {
 	if n, err := io.ReadAtLeast(r.reader, r.buf[r.w:], bytes); err != nil {
 		return err
@@ -154,6 +154,20 @@ func TestEdgeCases(t *testing.T) {
 	})
 }
 
+func TestWait(t *testing.T) {
+	br := NewReader(bytes.NewReader([]byte{0x01, 0x02}), 2)
+	assert.Nil(t, br.Wait())
+	assert.Nil(t, br.Wait(), "the waited byte is not consumed")
+
+	result, err := br.ReadFull(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x01, 0x02}, result)
+
+	assert.Equal(t, io.EOF, br.Wait())
+	br.Close()
+	assert.Equal(t, ErrBufReaderAlreadyClosed, br.Wait())
+}
+
 func TestClose(t *testing.T) {
 	t.Run("double close", func(t *testing.T) {
 		br := NewReader(bytes.NewReader(nil), 1)
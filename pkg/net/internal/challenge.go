@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/conf"
+	"go.uber.org/atomic"
+)
+
+// The challenge runs before the handshake so that the gate does no RSA work for clients that cannot prove
+// they own their address, or that have not paid for the connection with a proof-of-work.
+//
+// challenge frame (server -> client): ChallengeMagic | cookie
+// response frame  (client -> server): ChallengeResponseMagic | cookie | solution
+//
+// cookie   = version(1) | difficulty(1) | expiry unix seconds(8) | nonce(16) | HMAC-SHA256(secret, ip | cookie without mac)(32)
+// solution = 8 bytes chosen by the client that SHA256(cookie | solution) starts with difficulty zero bits.
+// the difficulty 0 only requires the client to echo the cookie.
+//
+// the client may send the handshake before it sees the challenge, the gate drops that frame,
+// the client must answer the challenge and then send the handshake again.
+var (
+	ChallengeMagic         = []byte("VGCH")
+	ChallengeResponseMagic = []byte("VGCR")
+)
+
+const (
+	challengeVersion     = 1
+	challengeNonceSize   = 16
+	challengeMacSize     = sha256.Size
+	challengeBodySize    = 1 + 1 + 8 + challengeNonceSize
+	challengeCookieSize  = challengeBodySize + challengeMacSize
+	challengeSolSize     = 8
+	challengeMaxDiffBits = 32
+)
+
+var (
+	ErrChallengeInvalid = errors.New("challenge response invalid")
+	ErrChallengeExpired = errors.New("challenge expired")
+)
+
+// Challenger is shared by all workers of a server.
+// it counts the in-flight handshakes to decide whether the challenge is required
+type Challenger struct {
+	mode       conf.ChallengeMode
+	threshold  int64
+	difficulty int
+	ttl        time.Duration
+	secret     []byte
+
+	handshaking *atomic.Int64
+}
+
+// NewChallenger returns nil when the challenge is off
+func NewChallenger(c *conf.Worker) (*Challenger, error) {
+	if c.ChallengeMode == conf.ChallengeModeOff {
+		return nil, nil
+	}
+	if c.ChallengeDifficulty < 0 || c.ChallengeDifficulty > challengeMaxDiffBits {
+		return nil, errors.Errorf("challenge difficulty must be in [0, %d]. difficulty=%d", challengeMaxDiffBits, c.ChallengeDifficulty)
+	}
+
+	secret := make([]byte, sha256.Size)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.Wrap(err, "challenge secret generate failed")
+	}
+
+	ttl := c.ChallengeTTL
+	if ttl <= 0 {
+		ttl = c.HandshakeTimeout
+	}
+	return &Challenger{
+		mode:        c.ChallengeMode,
+		threshold:   int64(c.ChallengeThreshold),
+		difficulty:  c.ChallengeDifficulty,
+		ttl:         ttl,
+		secret:      secret,
+		handshaking: atomic.NewInt64(0),
+	}, nil
+}
+
+// Begin marks a handshake in flight and reports whether this handshake must pass the challenge.
+// done must be called when the handshake is finished
+func (c *Challenger) Begin() (required bool, done func()) {
+	n := c.handshaking.Inc()
+	done = func() { c.handshaking.Dec() }
+
+	switch c.mode {
+	case conf.ChallengeModeAlways:
+		return true, done
+	case conf.ChallengeModeAuto:
+		return n > c.threshold, done
+	default:
+		return false, done
+	}
+}
+
+// Issue builds the challenge frame bound to the client ip
+func (c *Challenger) Issue(ip string, now time.Time) ([]byte, error) {
+	cookie := make([]byte, challengeCookieSize)
+	cookie[0] = challengeVersion
+	cookie[1] = byte(c.difficulty)
+	binary.BigEndian.PutUint64(cookie[2:10], uint64(now.Add(c.ttl).Unix()))
+	if _, err := rand.Read(cookie[10:challengeBodySize]); err != nil {
+		return nil, errors.Wrap(err, "challenge nonce generate failed")
+	}
+	copy(cookie[challengeBodySize:], c.mac(ip, cookie[:challengeBodySize]))
+
+	return append(append(make([]byte, 0, len(ChallengeMagic)+len(cookie)), ChallengeMagic...), cookie...), nil
+}
+
+// IsResponse returns true if the frame looks like a challenge response
+func IsResponse(in []byte) bool {
+	return bytes.HasPrefix(in, ChallengeResponseMagic)
+}
+
+// Verify checks the response without any state, the cookie is authenticated by the mac
+func (c *Challenger) Verify(ip string, in []byte, now time.Time) error {
+	if !IsResponse(in) || len(in) != len(ChallengeResponseMagic)+challengeCookieSize+challengeSolSize {
+		return errors.Wrapf(ErrChallengeInvalid, "bad frame. len=%d", len(in))
+	}
+
+	cookie := in[len(ChallengeResponseMagic) : len(ChallengeResponseMagic)+challengeCookieSize]
+	if cookie[0] != challengeVersion {
+		return errors.Wrapf(ErrChallengeInvalid, "version=%d", cookie[0])
+	}
+	if !hmac.Equal(cookie[challengeBodySize:], c.mac(ip, cookie[:challengeBodySize])) {
+		return errors.Wrap(ErrChallengeInvalid, "mac mismatch")
+	}
+	if expiry := int64(binary.BigEndian.Uint64(cookie[2:10])); now.Unix() > expiry {
+		return errors.Wrapf(ErrChallengeExpired, "expiry=%d", expiry)
+	}
+
+	difficulty := int(cookie[1])
+	if difficulty == 0 {
+		return nil
+	}
+
+	sum := sha256.Sum256(in[len(ChallengeResponseMagic):])
+	if leadingZeroBits(sum[:]) < difficulty {
+		return errors.Wrapf(ErrChallengeInvalid, "proof-of-work unsolved. difficulty=%d", difficulty)
+	}
+	return nil
+}
+
+// IsChallenge returns true if the frame sent by the server is a challenge
+func IsChallenge(in []byte) bool {
+	return bytes.HasPrefix(in, ChallengeMagic) && len(in) == len(ChallengeMagic)+challengeCookieSize
+}
+
+// SolveChallenge builds the response frame of the challenge, it is used by the client
+func SolveChallenge(frame []byte) ([]byte, error) {
+	if !IsChallenge(frame) {
+		return nil, errors.Wrapf(ErrChallengeInvalid, "bad challenge frame. len=%d", len(frame))
+	}
+
+	cookie := frame[len(ChallengeMagic):]
+	difficulty := int(cookie[1])
+	if difficulty > challengeMaxDiffBits {
+		return nil, errors.Wrapf(ErrChallengeInvalid, "difficulty=%d", difficulty)
+	}
+
+	resp := make([]byte, 0, len(ChallengeResponseMagic)+challengeCookieSize+challengeSolSize)
+	resp = append(resp, ChallengeResponseMagic...)
+	resp = append(resp, cookie...)
+	resp = append(resp, make([]byte, challengeSolSize)...)
+	if difficulty == 0 {
+		return resp, nil
+	}
+
+	sol := resp[len(resp)-challengeSolSize:]
+	for i := uint64(0); ; i++ {
+		binary.BigEndian.PutUint64(sol, i)
+		sum := sha256.Sum256(resp[len(ChallengeResponseMagic):])
+		if leadingZeroBits(sum[:]) >= difficulty {
+			return resp, nil
+		}
+	}
+}
+
+func (c *Challenger) mac(ip string, body []byte) []byte {
+	h := hmac.New(sha256.New, c.secret)
+	h.Write([]byte(ip))
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+func leadingZeroBits(b []byte) (n int) {
+	for _, v := range b {
+		if v != 0 {
+			return n + bits.LeadingZeros8(v)
+		}
+		n += 8
+	}
+	return n
+}
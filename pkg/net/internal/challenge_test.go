@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/conf"
+)
+
+func newTestChallenger(t *testing.T, mode conf.ChallengeMode, difficulty int) *Challenger {
+	c, err := NewChallenger(&conf.Worker{
+		HandshakeTimeout:    time.Second * 10,
+		ChallengeMode:       mode,
+		ChallengeThreshold:  1,
+		ChallengeDifficulty: difficulty,
+	})
+	assert.Nil(t, err)
+	return c
+}
+
+func TestChallengerVerify(t *testing.T) {
+	now := time.Now()
+	c := newTestChallenger(t, conf.ChallengeModeAlways, 8)
+
+	frame, err := c.Issue("127.0.0.1", now)
+	assert.Nil(t, err)
+
+	resp, err := SolveChallenge(frame)
+	assert.Nil(t, err)
+	assert.Nil(t, c.Verify("127.0.0.1", resp, now))
+
+	err = c.Verify("127.0.0.2", resp, now)
+	assert.True(t, errors.Is(err, ErrChallengeInvalid))
+
+	err = c.Verify("127.0.0.1", resp, now.Add(time.Minute))
+	assert.True(t, errors.Is(err, ErrChallengeExpired))
+
+	tampered := append([]byte{}, resp...)
+	tampered[len(ChallengeResponseMagic)+1] = 0
+	err = c.Verify("127.0.0.1", tampered, now)
+	assert.True(t, errors.Is(err, ErrChallengeInvalid))
+}
+
+func TestChallengerBegin(t *testing.T) {
+	c := newTestChallenger(t, conf.ChallengeModeAuto, 0)
+
+	required, done1 := c.Begin()
+	assert.False(t, required)
+	required, done2 := c.Begin()
+	assert.True(t, required)
+
+	done2()
+	done1()
+	required, done := c.Begin()
+	assert.False(t, required)
+	done()
+
+	off, err := NewChallenger(&conf.Worker{ChallengeMode: conf.ChallengeModeOff})
+	assert.Nil(t, err)
+	assert.Nil(t, off)
+}
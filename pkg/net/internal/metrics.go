@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	vnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+)
+
+const (
+	handshakeStageChallenge = "challenge"
+	handshakeStageAuth      = "auth"
+	handshakeStageAdmission = "admission"
+
+	handshakeResultOK       = "ok"
+	handshakeResultFailed   = "failed"
+	handshakeResultRejected = "rejected"
+
+	dropReasonExpired = "expired"
+	dropReasonStopped = "stopped"
+)
+
+var handshakeCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "vulcan",
+	Subsystem: "net",
+	Name:      "handshake_total",
+	Help:      "handshake count by stage(challenge, auth, admission) and result(ok, failed, rejected)",
+}, []string{"stage", "result"})
+
+var pushDroppedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "vulcan",
+	Subsystem: "net",
+	Name:      "push_dropped_total",
+	Help:      "packets dropped before they are written by reason(expired, stopped)",
+}, []string{"reason"})
+
+func handshakeResult(err error) string {
+	if errors.Is(err, vnet.ErrHandshakeRejected) {
+		return handshakeResultRejected
+	}
+	if err != nil {
+		return handshakeResultFailed
+	}
+	return handshakeResultOK
+}
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"context"
+
+	vnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+)
+
+// outbound is a packet queued to the client
+type outbound struct {
+	pack []byte
+	opts vnet.PushOptions
+}
+
+// replyQueues holds a queue per priority in the write order: high, normal, low
+type replyQueues [3]chan *outbound
+
+func newReplyQueues(size int) (q replyQueues) {
+	for i := range q {
+		q[i] = make(chan *outbound, size)
+	}
+	return
+}
+
+func queueIndex(p vnet.Priority) int {
+	switch p {
+	case vnet.PriorityHigh:
+		return 0
+	case vnet.PriorityLow:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// put waits for the room in the queue until ctx is done, so the pusher is not blocked for ever once the writer is gone
+func (q *replyQueues) put(ctx context.Context, o *outbound) error {
+	select {
+	case q[queueIndex(o.opts.Priority)] <- o:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *replyQueues) close() {
+	for _, c := range q {
+		close(c)
+	}
+}
+
+// drain drops the packets left in the closed queues
+func (q *replyQueues) drain() {
+	for _, c := range q {
+		for o := range c {
+			pushDroppedCounter.WithLabelValues(dropReasonStopped).Inc()
+			o.opts.Finish(vnet.ErrWorkerStopped)
+		}
+	}
+}
+
+// next takes the packet from the highest queue which is not empty, it returns false once all queues are closed and drained.
+// the drained queues are set to nil, so the select skips them
+func (q *replyQueues) next() (*outbound, bool) {
+	for {
+		for i, c := range q {
+			if c == nil {
+				continue
+			}
+			select {
+			case o, ok := <-c:
+				if ok {
+					return o, true
+				}
+				q[i] = nil
+			default:
+			}
+		}
+		if q[0] == nil && q[1] == nil && q[2] == nil {
+			return nil, false
+		}
+
+		select {
+		case o, ok := <-q[0]:
+			if ok {
+				return o, true
+			}
+			q[0] = nil
+		case o, ok := <-q[1]:
+			if ok {
+				return o, true
+			}
+			q[1] = nil
+		case o, ok := <-q[2]:
+			if ok {
+				return o, true
+			}
+			q[2] = nil
+		}
+	}
+}
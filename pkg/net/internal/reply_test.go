@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	vnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/conf"
+	"github.com/vulcan-frame/vulcan-pkg-tool/sync"
+	"go.uber.org/atomic"
+)
+
+func TestReplyQueues(t *testing.T) {
+	q := newReplyQueues(8)
+	for _, o := range []struct {
+		pack string
+		prio vnet.Priority
+	}{
+		{"low-1", vnet.PriorityLow},
+		{"normal-1", vnet.PriorityNormal},
+		{"high-1", vnet.PriorityHigh},
+		{"normal-2", vnet.PriorityNormal},
+		{"high-2", vnet.PriorityHigh},
+	} {
+		q.put(context.Background(), &outbound{pack: []byte(o.pack), opts: vnet.PushOptions{Priority: o.prio}})
+	}
+	q.close()
+
+	var packs []string
+	for {
+		o, ok := q.next()
+		if !ok {
+			break
+		}
+		packs = append(packs, string(o.pack))
+	}
+	assert.Equal(t, []string{"high-1", "high-2", "normal-1", "normal-2", "low-1"}, packs)
+}
+
+func TestReplyQueuesDrain(t *testing.T) {
+	q := newReplyQueues(8)
+
+	var reasons []error
+	done := func(err error) { reasons = append(reasons, err) }
+	q.put(context.Background(), &outbound{pack: []byte("a"), opts: vnet.PushOptions{Done: done}})
+	q.put(context.Background(), &outbound{pack: []byte("b"), opts: vnet.PushOptions{Priority: vnet.PriorityLow, Done: done}})
+	q.close()
+	q.drain()
+
+	assert.Equal(t, []error{vnet.ErrWorkerStopped, vnet.ErrWorkerStopped}, reasons)
+}
+
+// indexService prefixes the packet with its sc index
+type indexService struct {
+	vnet.Service
+}
+
+func (indexService) IndexPack(pack []byte, index int64) ([]byte, error) {
+	return binary.BigEndian.AppendUint64(nil, uint64(index)), nil
+}
+
+func TestWritePackLoopIndex(t *testing.T) {
+	conn, cli := newConnPair(t)
+	w := &Worker{
+		conf:               &conf.Worker{},
+		service:            indexService{},
+		conn:               conn,
+		session:            vnet.NewSession(1, 1, time.Now().Unix(), nil, nil, false, "", 0),
+		replyChanStarted:   atomic.NewBool(false),
+		replyChanCompleted: make(chan struct{}),
+		replyQueues:        newReplyQueues(8),
+	}
+
+	start := w.session.SCIndex()
+	indexes := make(map[string]int64)
+	push := func(name string, opts vnet.PushOptions) {
+		opts.Indexed = func(index int64) { indexes[name] = index }
+		w.replyQueues.put(context.Background(), &outbound{pack: []byte(name), opts: opts})
+	}
+	past := time.Now().Add(-time.Second)
+	push("normal-1", vnet.PushOptions{})
+	push("low-expired", vnet.PushOptions{Priority: vnet.PriorityLow, ExpireAt: past})
+	push("high-1", vnet.PushOptions{Priority: vnet.PriorityHigh})
+	push("normal-expired", vnet.PushOptions{ExpireAt: past})
+	push("low-1", vnet.PushOptions{Priority: vnet.PriorityLow})
+	push("high-2", vnet.PushOptions{Priority: vnet.PriorityHigh})
+	w.replyQueues.close()
+	require.Nil(t, w.writePackLoop(context.Background()))
+	require.Nil(t, cli.SetReadDeadline(time.Now().Add(time.Second)))
+
+	var seen []int64
+	for range 4 {
+		frame := make([]byte, vnet.PackLenSize+8)
+		_, err := io.ReadFull(cli, frame)
+		require.Nil(t, err)
+		seen = append(seen, int64(binary.BigEndian.Uint64(frame[vnet.PackLenSize:]))-start)
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4}, seen, "the indexes are consecutive in the write order")
+	assert.Equal(t, map[string]int64{"high-1": start + 1, "high-2": start + 2, "normal-1": start + 3, "low-1": start + 4}, indexes,
+		"the expired packets take no index")
+}
+
+// connectedService pushes more packets than the reply queue holds when the session is connected
+type connectedService struct {
+	indexService
+	count int
+	done  chan error
+}
+
+func (s connectedService) OnConnected(ctx context.Context, ss vnet.Session, p vnet.Pusher) error {
+	for i := range s.count {
+		if err := p.PushWith(ctx, []byte{byte(i)}, vnet.PushOptions{}); err != nil {
+			s.done <- err
+			return err
+		}
+	}
+	s.done <- nil
+	return nil
+}
+
+func TestWorkerRunConnected(t *testing.T) {
+	conn, cli := newConnPair(t)
+	svc := connectedService{count: 6, done: make(chan error, 1)}
+	w := NewWorker(1, conn, nil, &conf.Worker{ReplyChanSize: 2, ReaderBufSize: 64, StopTimeout: time.Second}, "", nil, nil, nil, nil, nil, svc)
+	w.session = vnet.NewSession(1, 1, time.Now().Unix(), nil, nil, false, "", 0)
+
+	ran := make(chan error, 1)
+	go func() { ran <- w.Run(context.Background()) }()
+
+	select {
+	case err := <-svc.done:
+		require.Nil(t, err, "the pushes on connected are not limited by the reply queue size")
+	case <-time.After(time.Second):
+		t.Fatal("the pushes on connected are blocked before the writer runs")
+	}
+
+	require.Nil(t, cli.SetReadDeadline(time.Now().Add(time.Second)))
+	frame := make([]byte, (vnet.PackLenSize+8)*svc.count)
+	_, err := io.ReadFull(cli, frame)
+	require.Nil(t, err)
+
+	w.Stop(context.Background())
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("the worker does not stop")
+	}
+}
+
+func TestPushWithContext(t *testing.T) {
+	w := &Worker{Stoppable: sync.NewStopper(time.Second), replyQueues: newReplyQueues(1)}
+	require.Nil(t, w.PushWith(context.Background(), []byte("a"), vnet.PushOptions{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, w.PushWith(ctx, []byte("b"), vnet.PushOptions{}), context.DeadlineExceeded, "the pusher does not wait for ever")
+}
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"sync"
+)
+
+// tagIndex maps the tags onto the workers whose sessions have them
+type tagIndex struct {
+	mu      sync.RWMutex
+	workers map[string]map[int64]*Worker
+}
+
+func newTagIndex() *tagIndex {
+	return &tagIndex{
+		workers: make(map[string]map[int64]*Worker),
+	}
+}
+
+// update adds and then removes the tags of the worker, the worker replaced by a new one of the same uid is not removed
+func (x *tagIndex) update(w *Worker, added, removed []string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, tag := range added {
+		ws, ok := x.workers[tag]
+		if !ok {
+			ws = make(map[int64]*Worker)
+			x.workers[tag] = ws
+		}
+		ws[w.UID()] = w
+	}
+	for _, tag := range removed {
+		ws, ok := x.workers[tag]
+		if !ok || ws[w.UID()] != w {
+			continue
+		}
+		delete(ws, w.UID())
+		if len(ws) == 0 {
+			delete(x.workers, tag)
+		}
+	}
+}
+
+func (x *tagIndex) get(tag string) []*Worker {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	ws := x.workers[tag]
+	workers := make([]*Worker, 0, len(ws))
+	for _, w := range ws {
+		workers = append(workers, w)
+	}
+	return workers
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	vnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/conf"
+)
+
+func newTaggedWorker(wid uint64, uid int64, tags ...string) *Worker {
+	ss := vnet.NewSession(uid, 1, time.Now().Unix(), nil, nil, false, "", 0)
+	ss.UpdateTags(tags, nil)
+	return &Worker{id: wid, session: ss}
+}
+
+func TestBucketsTagIndex(t *testing.T) {
+	bs := NewBuckets(&conf.Bucket{BucketSize: 4, WorkerSize: 4})
+
+	// the tags set before the put are indexed
+	w1 := newTaggedWorker(1, 20001, "guild:1")
+	w2 := newTaggedWorker(2, 20002, "guild:1", "zone:2")
+	bs.Put(w1)
+	bs.Put(w2)
+	assert.ElementsMatch(t, []*Worker{w1, w2}, bs.GetByTag("guild:1"))
+	assert.ElementsMatch(t, []*Worker{w2}, bs.GetByTag("zone:2"))
+
+	// the later updates are indexed by the observer
+	w1.Session().UpdateTags([]string{"zone:2"}, []string{"guild:1"})
+	assert.ElementsMatch(t, []*Worker{w2}, bs.GetByTag("guild:1"))
+	assert.ElementsMatch(t, []*Worker{w1, w2}, bs.GetByTag("zone:2"))
+
+	// the worker replaced by a new one of the same uid does not remove the tags of the new one
+	w3 := newTaggedWorker(3, 20002, "guild:1")
+	bs.Put(w3)
+	bs.Del(w2)
+	assert.ElementsMatch(t, []*Worker{w3}, bs.GetByTag("guild:1"))
+	assert.ElementsMatch(t, []*Worker{w1}, bs.GetByTag("zone:2"))
+
+	// the updates after the delete are not indexed
+	bs.Del(w1)
+	w1.Session().UpdateTags([]string{"guild:1"}, nil)
+	assert.Empty(t, bs.GetByTag("zone:2"))
+	assert.ElementsMatch(t, []*Worker{w3}, bs.GetByTag("guild:1"))
+
+	bs.Del(w3)
+	assert.Empty(t, bs.GetByTag("guild:1"))
+	assert.Empty(t, bs.tags.workers, "the empty tags are dropped")
+}
@@ -2,30 +2,51 @@ package internal
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	"golang.org/x/sync/singleflight"
 )
 
 type CreateTunnelFunc func(ctx context.Context, tp int32, rid int64) (tunnel.Tunnel, error)
 
+// ErrHolderStopped is returned when the tunnel is created after the worker stopped, the late tunnel is stopped at once
+var ErrHolderStopped = errors.New("tunnel holder is stopped")
+
 type tunnelHolder struct {
 	sync.RWMutex
 
+	idleTimeout time.Duration
+	maxPerType  int
+	failureTTL  time.Duration
+
 	tunnelGroups map[int32]map[int64]tunnel.Tunnel // TunnelType -> oid -> tunnel
+	failures     map[int32]time.Time               // TunnelType -> the time until which the creation of the backend fails fast
+	stopped      bool
+
+	creating singleflight.Group
 }
 
-func newTunnelHolder() *tunnelHolder {
+func newTunnelHolder(idleTimeout time.Duration, maxPerType int, failureTTL time.Duration) *tunnelHolder {
 	th := &tunnelHolder{
+		idleTimeout:  idleTimeout,
+		maxPerType:   maxPerType,
+		failureTTL:   failureTTL,
 		tunnelGroups: make(map[int32]map[int64]tunnel.Tunnel, 16),
+		failures:     make(map[int32]time.Time),
 	}
 	return th
 }
 
+// stop stops the tunnels, the tunnels created after it are stopped on put
 func (h *tunnelHolder) stop() {
 	h.Lock()
 	defer h.Unlock()
 
+	h.stopped = true
 	for _, tg := range h.tunnelGroups {
 		for _, t := range tg {
 			t.TriggerStop()
@@ -49,26 +70,154 @@ func (h *tunnelHolder) tunnel(tp int32, oid int64) tunnel.Tunnel {
 	return t
 }
 
+// createTunnel creates the tunnel without holding the lock, so the slow creation does not block the other tunnels.
+// the concurrent creations of the same type and oid are merged.
+// the stream creation only fails when the backend is unreachable, so the creations of the same type fail fast for a while after a failure
 func (h *tunnelHolder) createTunnel(ctx context.Context, tp int32, oid int64, create CreateTunnelFunc) (tunnel.Tunnel, error) {
+	if until, ok := h.failedUntil(tp); ok {
+		return nil, errors.Wrapf(tunnel.ErrCreateFailed, "type=%d oid=%d until=%s", tp, oid, until)
+	}
+
+	v, err, _ := h.creating.Do(fmt.Sprintf("%d-%d", tp, oid), func() (interface{}, error) {
+		if t := h.tunnel(tp, oid); t != nil {
+			return t, nil
+		}
+
+		t, err := create(ctx, tp, oid)
+		if err != nil {
+			h.markFailed(tp)
+			return nil, err
+		}
+		if !h.put(tp, oid, t) {
+			t.TriggerStop()
+			return nil, errors.Wrapf(ErrHolderStopped, "type=%d oid=%d", tp, oid)
+		}
+		return t, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(tunnel.Tunnel), nil
+}
+
+// put returns false if the holder is stopped, the caller stops the tunnel
+func (h *tunnelHolder) put(tp int32, oid int64, t tunnel.Tunnel) bool {
 	h.Lock()
 	defer h.Unlock()
 
+	if h.stopped {
+		return false
+	}
+
 	tg, ok := h.tunnelGroups[tp]
 	if !ok {
 		tg = make(map[int64]tunnel.Tunnel, 16)
 		h.tunnelGroups[tp] = tg
 	}
+	delete(tg, oid)
+
+	if h.maxPerType > 0 {
+		h.evict(tg, h.maxPerType-1)
+	}
+	tg[oid] = t
+	return true
+}
+
+func (h *tunnelHolder) failedUntil(tp int32) (time.Time, bool) {
+	h.RLock()
+	defer h.RUnlock()
+
+	until, ok := h.failures[tp]
+	if !ok || time.Now().After(until) {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+func (h *tunnelHolder) markFailed(tp int32) {
+	if h.failureTTL <= 0 {
+		return
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	h.failures[tp] = time.Now().Add(h.failureTTL)
+}
+
+// closeTunnel stops and removes the auxiliary tunnel, it returns false if the tunnel is not found or is the main one
+func (h *tunnelHolder) closeTunnel(tp int32, oid int64) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	tg, ok := h.tunnelGroups[tp]
+	if !ok {
+		return false
+	}
 
 	t, ok := tg[oid]
-	if ok && !t.IsStopping() {
-		return t, nil
+	if !ok || t.IsMain() {
+		return false
 	}
+	t.TriggerStop()
+	delete(tg, oid)
+	return true
+}
 
-	t, err := create(ctx, tp, oid)
-	if err != nil {
-		return nil, err
+// evict removes the stopped tunnels, and closes the least recently used auxiliary tunnels until the group size is not more than size.
+// the pinned tunnels are not closed, so the group may stay larger than size. it must be called with the lock held
+func (h *tunnelHolder) evict(tg map[int64]tunnel.Tunnel, size int) {
+	for oid, t := range tg {
+		if t.IsStopping() {
+			delete(tg, oid)
+		}
 	}
 
-	tg[oid] = t
-	return t, nil
+	for len(tg) > size {
+		var (
+			lruOID int64
+			lru    tunnel.Tunnel
+		)
+		for oid, t := range tg {
+			if t.IsMain() || t.IsPinned() {
+				continue
+			}
+			if lru == nil || t.LastActive().Before(lru.LastActive()) {
+				lruOID, lru = oid, t
+			}
+		}
+		if lru == nil {
+			return
+		}
+		lru.TriggerStop()
+		delete(tg, lruOID)
+	}
+}
+
+// reclaim closes the auxiliary tunnels idle longer than the idle timeout unless they are pinned, and drops the expired failures
+func (h *tunnelHolder) reclaim(now time.Time) {
+	h.Lock()
+	defer h.Unlock()
+
+	for tp, until := range h.failures {
+		if now.After(until) {
+			delete(h.failures, tp)
+		}
+	}
+	if h.idleTimeout <= 0 {
+		return
+	}
+
+	for _, tg := range h.tunnelGroups {
+		for oid, t := range tg {
+			if t.IsStopping() {
+				delete(tg, oid)
+				continue
+			}
+			if !t.IsMain() && !t.IsPinned() && now.Sub(t.LastActive()) > h.idleTimeout {
+				t.TriggerStop()
+				delete(tg, oid)
+			}
+		}
+	}
 }
@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
+	"github.com/vulcan-frame/vulcan-pkg-tool/sync"
+)
+
+type fakeTunnel struct {
+	sync.Stoppable
+
+	main       bool
+	pinned     bool
+	stopped    bool
+	lastActive time.Time
+}
+
+func (t *fakeTunnel) Push(ctx context.Context, pack []byte) error                  { return nil }
+func (t *fakeTunnel) Type() int32                                                  { return 0 }
+func (t *fakeTunnel) Forward(ctx context.Context, msg tunnel.ForwardMessage) error { return nil }
+func (t *fakeTunnel) IsMain() bool                                                 { return t.main }
+func (t *fakeTunnel) IsPinned() bool                                               { return t.pinned }
+func (t *fakeTunnel) LastActive() time.Time                                        { return t.lastActive }
+func (t *fakeTunnel) IsStopping() bool                                             { return t.stopped }
+func (t *fakeTunnel) TriggerStop()                                                 { t.stopped = true }
+
+func TestTunnelHolderEvict(t *testing.T) {
+	now := time.Now()
+	h := newTunnelHolder(time.Minute, 3, 0)
+
+	main := &fakeTunnel{main: true, lastActive: now.Add(-time.Hour)}
+	pinned := &fakeTunnel{pinned: true, lastActive: now.Add(-time.Hour)}
+	older := &fakeTunnel{lastActive: now.Add(-time.Second * 2)}
+	newer := &fakeTunnel{lastActive: now.Add(-time.Second)}
+
+	create := func(ft *fakeTunnel) CreateTunnelFunc {
+		return func(ctx context.Context, tp int32, oid int64) (tunnel.Tunnel, error) {
+			return ft, nil
+		}
+	}
+
+	_, _ = h.createTunnel(context.Background(), 0, 1, create(main))
+	_, _ = h.createTunnel(context.Background(), 0, 4, create(pinned))
+	_, _ = h.createTunnel(context.Background(), 0, 2, create(older))
+	assert.False(t, main.stopped)
+	assert.False(t, older.stopped)
+
+	_, _ = h.createTunnel(context.Background(), 0, 3, create(newer))
+	assert.False(t, main.stopped)
+	assert.False(t, pinned.stopped)
+	assert.True(t, older.stopped)
+	assert.NotNil(t, h.tunnel(0, 1))
+	assert.Nil(t, h.tunnel(0, 2))
+	assert.NotNil(t, h.tunnel(0, 3))
+	assert.NotNil(t, h.tunnel(0, 4))
+}
+
+func TestTunnelHolderReclaim(t *testing.T) {
+	now := time.Now()
+	h := newTunnelHolder(time.Minute, 0, 0)
+
+	main := &fakeTunnel{main: true, lastActive: now.Add(-time.Hour)}
+	idle := &fakeTunnel{lastActive: now.Add(-time.Hour)}
+	active := &fakeTunnel{lastActive: now}
+	pinned := &fakeTunnel{pinned: true, lastActive: now.Add(-time.Hour)}
+
+	for oid, ft := range map[int64]*fakeTunnel{1: main, 2: idle, 3: active, 4: pinned} {
+		ft := ft
+		_, _ = h.createTunnel(context.Background(), 1, oid, func(ctx context.Context, tp int32, oid int64) (tunnel.Tunnel, error) {
+			return ft, nil
+		})
+	}
+
+	h.reclaim(now)
+	assert.False(t, main.stopped)
+	assert.True(t, idle.stopped)
+	assert.False(t, active.stopped)
+	assert.False(t, pinned.stopped)
+}
+
+func TestTunnelHolderCloseTunnel(t *testing.T) {
+	h := newTunnelHolder(0, 0, 0)
+
+	main := &fakeTunnel{main: true}
+	aux := &fakeTunnel{}
+
+	for oid, ft := range map[int64]*fakeTunnel{1: main, 2: aux} {
+		ft := ft
+		_, _ = h.createTunnel(context.Background(), 1, oid, func(ctx context.Context, tp int32, oid int64) (tunnel.Tunnel, error) {
+			return ft, nil
+		})
+	}
+
+	assert.False(t, h.closeTunnel(1, 1))
+	assert.False(t, main.stopped)
+	assert.True(t, h.closeTunnel(1, 2))
+	assert.True(t, aux.stopped)
+	assert.Nil(t, h.tunnel(1, 2))
+	assert.False(t, h.closeTunnel(1, 3))
+}
+
+func TestTunnelHolderFailureTTL(t *testing.T) {
+	h := newTunnelHolder(0, 0, time.Minute)
+
+	calls := 0
+	create := func(ctx context.Context, tp int32, oid int64) (tunnel.Tunnel, error) {
+		calls++
+		return nil, errors.New("backend down")
+	}
+
+	_, err := h.createTunnel(context.Background(), 1, 1, create)
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, tunnel.ErrCreateFailed))
+
+	_, err = h.createTunnel(context.Background(), 1, 1, create)
+	assert.True(t, errors.Is(err, tunnel.ErrCreateFailed))
+	assert.Equal(t, 1, calls)
+
+	// the failure is of the backend, so the other oids of the type fail fast too
+	_, err = h.createTunnel(context.Background(), 1, 2, create)
+	assert.True(t, errors.Is(err, tunnel.ErrCreateFailed))
+	assert.Equal(t, 1, calls)
+
+	_, err = h.createTunnel(context.Background(), 2, 1, create)
+	assert.False(t, errors.Is(err, tunnel.ErrCreateFailed))
+	assert.Equal(t, 2, calls)
+
+	h.reclaim(time.Now().Add(time.Hour))
+	_, _ = h.createTunnel(context.Background(), 1, 1, create)
+	assert.Equal(t, 3, calls)
+}
+
+func TestTunnelHolderPutAfterStop(t *testing.T) {
+	h := newTunnelHolder(0, 0, 0)
+
+	created := make(chan struct{})
+	release := make(chan struct{})
+	late := &fakeTunnel{}
+	done := make(chan error, 1)
+	go func() {
+		_, err := h.createTunnel(context.Background(), 1, 1, func(ctx context.Context, tp int32, oid int64) (tunnel.Tunnel, error) {
+			close(created)
+			<-release
+			return late, nil
+		})
+		done <- err
+	}()
+
+	<-created
+	h.stop()
+	close(release)
+
+	assert.True(t, errors.Is(<-done, ErrHolderStopped))
+	assert.True(t, late.stopped, "the tunnel created after the stop is stopped")
+	assert.Nil(t, h.tunnel(1, 1))
+}
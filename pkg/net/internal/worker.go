@@ -24,6 +24,13 @@ import (
 var _ tunnel.Holder = (*Worker)(nil)
 var _ sync.Stoppable = (*Worker)(nil)
 
+var (
+	ErrSessionExpired = errors.New("session expired")
+	ErrSessionBanned  = errors.New("session banned")
+	// ErrAdmissionFailed is returned when the client leaves the login queue or the queue fails, it is not a handshake failure
+	ErrAdmissionFailed = errors.New("admission failed")
+)
+
 type Worker struct {
 	*tunnelHolder
 	sync.Stoppable
@@ -33,6 +40,9 @@ type Worker struct {
 	reader           *bufreader.Reader
 	service          vnet.Service
 	createTunnelFunc CreateTunnelFunc
+	challenger       *Challenger
+	guard            vnet.Guard
+	admission        vnet.Admission
 	referer          string
 
 	readFilter  middleware.Middleware
@@ -42,27 +52,33 @@ type Worker struct {
 	conn    *net.TCPConn
 	started *atomic.Bool
 	session vnet.Session
+	ctx     context.Context // the session context, it is set before the session is connected
+	release func()
 
 	replyChanStarted   *atomic.Bool
 	replyChanCompleted chan struct{}
-	replyChan          chan []byte
+	replyQueues        replyQueues
 }
 
-func NewWorker(wid uint64, conn *net.TCPConn, logger log.Logger, conf *conf.Worker, referer string,
-	readFilter, writeFilter middleware.Middleware, handler vnet.Service) *Worker {
+func NewWorker(wid uint64, conn *net.TCPConn, logger log.Logger, conf *conf.Worker, referer string, challenger *Challenger, guard vnet.Guard,
+	admission vnet.Admission, readFilter, writeFilter middleware.Middleware, handler vnet.Service) *Worker {
 	w := &Worker{
-		tunnelHolder:       newTunnelHolder(),
+		tunnelHolder:       newTunnelHolder(conf.TunnelIdleTimeout, conf.MaxTunnelsPerType, conf.TunnelFailureTTL),
 		Stoppable:          sync.NewStopper(conf.StopTimeout),
 		CountdownStopper:   sync.NewCountdownStopper(),
 		conf:               conf,
 		service:            handler,
 		referer:            referer,
+		challenger:         challenger,
+		guard:              guard,
+		admission:          admission,
 		readFilter:         readFilter,
 		writeFilter:        writeFilter,
 		id:                 wid,
 		conn:               conn,
 		started:            atomic.NewBool(false),
 		session:            vnet.DefaultSession(),
+		ctx:                context.Background(),
 		replyChanStarted:   atomic.NewBool(false),
 		replyChanCompleted: make(chan struct{}),
 	}
@@ -75,7 +91,7 @@ func NewWorker(wid uint64, conn *net.TCPConn, logger log.Logger, conf *conf.Work
 		return t, nil
 	}
 
-	w.replyChan = make(chan []byte, conf.ReplyChanSize)
+	w.replyQueues = newReplyQueues(conf.ReplyChanSize)
 	w.reader = bufreader.NewReader(conn, conf.ReaderBufSize)
 	return w
 }
@@ -88,9 +104,14 @@ func (w *Worker) Start(ctx context.Context) (err error) {
 	if err = w.handshake(ctx); err != nil {
 		return err
 	}
-	if err = w.service.OnConnected(ctx, w.session); err != nil {
-		return err
+	if w.admission != nil {
+		err = w.admit(ctx)
+		handshakeCounter.WithLabelValues(handshakeStageAdmission, handshakeResult(err)).Inc()
+		if err != nil {
+			return err
+		}
 	}
+	w.ctx = w.withSession(ctx)
 
 	if err = w.Conn().SetDeadline(time.Now().Add(w.conf.RequestIdleTimeout)); err != nil {
 		return errors.Wrap(err, "set conn deadline after handshake failed")
@@ -101,14 +122,7 @@ func (w *Worker) Start(ctx context.Context) (err error) {
 }
 
 func (w *Worker) Run(ctx context.Context) error {
-	ctx = vctx.SetUID(ctx, w.UID())
-	ctx = vctx.SetSID(ctx, w.SID())
-	ctx = vctx.SetColor(ctx, w.Color())
-	ctx = vctx.SetStatus(ctx, w.Status())
-	ctx = vctx.SetGateReferer(ctx, w.referer, w.WID())
-	ctx = vctx.SetClientIP(ctx, w.session.ClientIP())
-
-	eg, ctx := errgroup.WithContext(ctx)
+	eg, ctx := errgroup.WithContext(w.withSession(ctx))
 	eg.Go(func() error {
 		select {
 		case <-w.StopTriggered():
@@ -125,6 +139,11 @@ func (w *Worker) Run(ctx context.Context) error {
 		return err
 	})
 	eg.Go(func() error {
+		// the service pushes on connected with the writer running, so they are not limited by the queue size,
+		// and the client packets are read after them, so they are written ahead of the tunnel traffic
+		if err := w.service.OnConnected(ctx, w.session, w); err != nil {
+			return err
+		}
 		// cs loop
 		err := sync.RunSafe(func() error {
 			return w.readPackLoop(ctx)
@@ -142,6 +161,22 @@ func (w *Worker) Run(ctx context.Context) error {
 	return nil
 }
 
+// withSession sets the session metadata to the context
+func (w *Worker) withSession(ctx context.Context) context.Context {
+	ctx = vctx.SetUID(ctx, w.UID())
+	ctx = vctx.SetSID(ctx, w.SID())
+	ctx = vctx.SetColor(ctx, w.Color())
+	ctx = vctx.SetStatus(ctx, w.Status())
+	ctx = vctx.SetGateReferer(ctx, w.referer, w.WID())
+	ctx = vctx.SetClientIP(ctx, w.session.ClientIP())
+	return ctx
+}
+
+// Context returns the session context, the tunnels opened out of a client request use it instead of the context of another tunnel
+func (w *Worker) Context() context.Context {
+	return w.ctx
+}
+
 func (w *Worker) Stop(ctx context.Context) {
 	w.DoStop(func() {
 		if w.IsStarted() {
@@ -151,11 +186,15 @@ func (w *Worker) Stop(ctx context.Context) {
 				log.Errorf("[xnet.Worker] onDisconnect failed. wid=%d uid=%d color=%s %+v", w.WID(), w.UID(), w.Color(), err)
 			}
 		}
+		if w.release != nil {
+			w.release()
+		}
 
-		close(w.replyChan)
+		w.replyQueues.close()
 		if w.replyChanStarted.Load() {
 			<-w.replyChanCompleted
 		}
+		w.replyQueues.drain()
 
 		if err := w.reader.Close(); err != nil {
 			log.Errorf("[xnet.Worker] reader close failed. wid=%d uid=%d color=%s %+v", w.WID(), w.UID(), w.Color(), err)
@@ -179,10 +218,30 @@ func (w *Worker) handshake(ctx context.Context) error {
 		err error
 	)
 
+	if w.challenger != nil {
+		required, done := w.challenger.Begin()
+		defer done()
+
+		if required {
+			err = w.challenge()
+			handshakeCounter.WithLabelValues(handshakeStageChallenge, handshakeResult(err)).Inc()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	if in, err = w.read(); err != nil {
 		return err
 	}
-	if out, ss, err = w.service.Auth(ctx, in); err != nil {
+	out, ss, err = w.service.Auth(vctx.SetClientIP(ctx, vctx.RemoteIP(w.conn)), in)
+	handshakeCounter.WithLabelValues(handshakeStageAuth, handshakeResult(err)).Inc()
+	if err != nil {
+		if errors.Is(err, vnet.ErrHandshakeRejected) && len(out) > 0 {
+			if err0 := w.write(out); err0 != nil {
+				log.Errorf("[xnet.Worker] handshake rejection write failed. wid=%d %+v", w.WID(), err0)
+			}
+		}
 		return err
 	}
 	if err = w.write(out); err != nil {
@@ -194,6 +253,79 @@ func (w *Worker) handshake(ctx context.Context) error {
 	return nil
 }
 
+// admit waits in the login queue after the handshake, the client only receives the queue positions until it is admitted.
+// the client does not send anything in the queue, so the write deadline is extended by every push instead of the read one
+func (w *Worker) admit(ctx context.Context) (err error) {
+	wctx, stop := w.watch(ctx)
+	w.release, err = w.admission.Admit(wctx, w.session, func(out []byte) error {
+		if err := w.conn.SetWriteDeadline(time.Now().Add(w.conf.HandshakeTimeout)); err != nil {
+			return errors.Wrap(err, "set conn write deadline in the login queue failed")
+		}
+		return w.writeIndexed(ctx, out, vnet.PushOptions{})
+	})
+	stop()
+	if err != nil {
+		return errors.Wrapf(ErrAdmissionFailed, "wid=%d uid=%d sid=%d: %v", w.WID(), w.UID(), w.SID(), err)
+	}
+	return nil
+}
+
+// watch cancels the context when the client closes the connection in the login queue, instead of waiting for the next push to fail,
+// or when the client has waited for QueueTimeout. the bytes the client sends in the queue anyway are kept for the next read.
+// stop must be called before the next read
+func (w *Worker) watch(ctx context.Context) (wctx context.Context, stop func()) {
+	deadline := time.Now().Add(w.conf.QueueTimeout)
+	wctx, cancel := context.WithDeadline(ctx, deadline)
+	// the queue goes on without the watching, the read deadline is only left for the read blocked below
+	if err := w.conn.SetReadDeadline(deadline); err != nil {
+		log.Errorf("[xnet.Worker] conn read deadline set failed in the login queue. wid=%d %+v", w.WID(), err)
+		return wctx, cancel
+	}
+
+	done := make(chan struct{})
+	sync.GoSafe(fmt.Sprintf("xnet.Worker.watch-%d", w.WID()), func() error {
+		defer close(done)
+		if err := w.reader.Wait(); err != nil {
+			cancel()
+		}
+		return nil
+	})
+	return wctx, func() {
+		cancel()
+		// interrupt the blocked read, the deadline is set again before the next read
+		_ = w.conn.SetReadDeadline(time.Now())
+		<-done
+	}
+}
+
+// challenge must be passed before the handshake is read, it costs the gate no RSA work
+func (w *Worker) challenge() error {
+	ip := vctx.RemoteIP(w.conn)
+
+	frame, err := w.challenger.Issue(ip, time.Now())
+	if err != nil {
+		return err
+	}
+	if err = w.write(frame); err != nil {
+		return err
+	}
+
+	in, err := w.read()
+	if err != nil {
+		return err
+	}
+	// the client sent the handshake before it received the challenge, drop it and wait for the response
+	if !IsResponse(in) {
+		if in, err = w.read(); err != nil {
+			return err
+		}
+	}
+	if err = w.challenger.Verify(ip, in, time.Now()); err != nil {
+		return errors.WithMessagef(err, "wid=%d remote=%s", w.WID(), ip)
+	}
+	return nil
+}
+
 func (w *Worker) Tunnel(ctx context.Context, mod int32, oid int64) (t tunnel.Tunnel, err error) {
 	tp, err := w.service.TunnelType(mod)
 	if err != nil {
@@ -205,7 +337,21 @@ func (w *Worker) Tunnel(ctx context.Context, mod int32, oid int64) (t tunnel.Tun
 	return w.createTunnel(ctx, tp, oid, w.createTunnelFunc)
 }
 
+// CloseTunnel stops the auxiliary tunnel of the module and oid, it is a no-op for the main tunnel
+func (w *Worker) CloseTunnel(mod int32, oid int64) {
+	tp, err := w.service.TunnelType(mod)
+	if err != nil {
+		return
+	}
+	w.closeTunnel(tp, oid)
+}
+
 func (w *Worker) Push(ctx context.Context, out []byte) error {
+	return w.PushWith(ctx, out, vnet.PushOptions{})
+}
+
+// PushWith queues the packet to the queue of its priority, the options are applied when it is written
+func (w *Worker) PushWith(ctx context.Context, out []byte, opts vnet.PushOptions) error {
 	if w.IsStopping() {
 		return errors.New("worker is stopping")
 	}
@@ -213,36 +359,91 @@ func (w *Worker) Push(ctx context.Context, out []byte) error {
 		return errors.New("push msg len <= 0")
 	}
 
-	w.replyChan <- out
+	if err := w.replyQueues.put(ctx, &outbound{pack: out, opts: opts}); err != nil {
+		return errors.Wrap(err, "push queue wait failed")
+	}
 	return nil
 }
 
 func (w *Worker) tickStopSign(ctx context.Context) (err error) {
 	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			if t := w.CountdownStopper.ExpiryTime(); !t.IsZero() && time.Now().After(t) {
+			now := time.Now()
+			if t := w.CountdownStopper.ExpiryTime(); !t.IsZero() && now.After(t) {
 				w.TriggerStop()
 				return errors.Wrapf(sync.ErrCountdownTimerExpired, "wid=%d", w.WID())
 			}
-			// TODO: check black list
+			if t := w.sessionDeadline(); !t.IsZero() && now.After(t) {
+				w.Logout(ctx, vnet.LogoutCodeAuth)
+				return errors.Wrapf(ErrSessionExpired, "wid=%d deadline=%s", w.WID(), t)
+			}
+			if w.guard != nil && !w.guard.Allow(vctx.RemoteIP(w.conn)) {
+				w.Logout(ctx, vnet.LogoutCodeBanned)
+				return errors.Wrapf(ErrSessionBanned, "wid=%d remote=%s", w.WID(), vctx.RemoteIP(w.conn))
+			}
+			w.tunnelHolder.reclaim(now)
+		}
+	}
+}
+
+// sessionDeadline is the earlier one of the token timeout and the max session age, plus the grace period.
+// the token timeout counts only if the expiry is enforced.
+// the client can present a refreshed token to extend the token timeout, but not the max session age
+func (w *Worker) sessionDeadline() (deadline time.Time) {
+	if timeout := w.session.TokenTimeout(); timeout > 0 && w.conf.EnforceTokenExpiry {
+		deadline = time.Unix(timeout, 0)
+	}
+	if w.conf.MaxSessionAge > 0 {
+		if t := time.Unix(w.session.StartTime(), 0).Add(w.conf.MaxSessionAge); deadline.IsZero() || t.Before(deadline) {
+			deadline = t
 		}
 	}
+	if deadline.IsZero() {
+		return
+	}
+	return deadline.Add(w.conf.SessionGracePeriod)
+}
+
+// Logout sends the logout message to the client and closes the worker
+// the message is queued before the stop, so it is written before the connection is closed
+func (w *Worker) Logout(ctx context.Context, code vnet.LogoutCode) {
+	pack, err := w.service.LogoutPack(ctx, w.session, code)
+	if err != nil {
+		log.Errorf("[xnet.Worker] logout pack failed. wid=%d uid=%d color=%s code=%d %+v", w.WID(), w.UID(), w.Color(), code, err)
+	} else if err = w.Push(ctx, pack); err != nil {
+		log.Errorf("[xnet.Worker] logout push failed. wid=%d uid=%d color=%s code=%d %+v", w.WID(), w.UID(), w.Color(), code, err)
+	}
+	w.TriggerStop()
 }
 
 func (w *Worker) writePackLoop(ctx context.Context) (err error) {
 	defer close(w.replyChanCompleted)
 
 	w.replyChanStarted.Store(true)
-	for pack := range w.replyChan {
-		if err = w.writePack(ctx, pack); err != nil {
+	queues := w.replyQueues
+	for {
+		o, ok := queues.next()
+		if !ok {
+			return nil
+		}
+		if o.opts.Expired(time.Now()) {
+			pushDroppedCounter.WithLabelValues(dropReasonExpired).Inc()
+			o.opts.Finish(vnet.ErrPushExpired)
+			continue
+		}
+
+		err = w.writeIndexed(ctx, o.pack, o.opts)
+		o.opts.Finish(err)
+		if err != nil {
 			return err
 		}
 	}
-	return nil
 }
 
 func (w *Worker) readPackLoop(ctx context.Context) (err error) {
@@ -264,6 +465,17 @@ func writeNext(ctx context.Context, pk interface{}) (interface{}, error) {
 	return pk, nil
 }
 
+// writeIndexed takes the next sc index for the packet and writes it, the index is only taken by the packet written
+func (w *Worker) writeIndexed(ctx context.Context, pack []byte, opts vnet.PushOptions) error {
+	index := w.session.IncreaseSCIndex()
+	pack, err := w.service.IndexPack(pack, index)
+	if err != nil {
+		return errors.WithMessagef(err, "index=%d", index)
+	}
+	opts.Index(index)
+	return w.writePack(ctx, pack)
+}
+
 func (w *Worker) writePack(ctx context.Context, pack []byte) (err error) {
 	next := writeNext
 	if w.writeFilter != nil {
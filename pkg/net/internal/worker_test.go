@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	vnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/conf"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/internal/bufreader"
+)
+
+func TestWorkerSessionDeadline(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	ss := vnet.NewSession(1, 1, start.Unix(), nil, nil, false, "", 0)
+	ss.SetTokenTimeout(start.Add(time.Hour).Unix())
+
+	w := &Worker{conf: &conf.Worker{SessionGracePeriod: time.Minute}, session: ss}
+	assert.True(t, w.sessionDeadline().IsZero(), "the token expiry is not enforced by default")
+
+	w.conf.EnforceTokenExpiry = true
+	assert.Equal(t, start.Add(time.Hour+time.Minute), w.sessionDeadline())
+
+	w.conf.MaxSessionAge = time.Minute * 30
+	assert.Equal(t, start.Add(time.Minute*31), w.sessionDeadline(), "the max session age comes first")
+
+	w.conf.EnforceTokenExpiry = false
+	assert.Equal(t, start.Add(time.Minute*31), w.sessionDeadline(), "the max session age is enforced on its own")
+}
+
+// newConnPair returns the server side of a tcp connection and the client side
+func newConnPair(t *testing.T) (*net.TCPConn, net.Conn) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer lis.Close()
+
+	cli, err := net.Dial("tcp", lis.Addr().String())
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = cli.Close() })
+
+	conn, err := lis.Accept()
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn.(*net.TCPConn), cli
+}
+
+func TestWorkerWatch(t *testing.T) {
+	conn, cli := newConnPair(t)
+	w := &Worker{conn: conn, reader: bufreader.NewReader(conn, 64), conf: &conf.Worker{QueueTimeout: time.Minute}}
+
+	// the bytes sent in the queue are kept for the read after it
+	ctx, stop := w.watch(context.Background())
+	_, err := cli.Write([]byte{0x01, 0x02})
+	require.Nil(t, err)
+	time.Sleep(20 * time.Millisecond)
+	assert.Nil(t, ctx.Err())
+	stop()
+
+	require.Nil(t, conn.SetReadDeadline(time.Time{}))
+	out, err := w.reader.ReadFull(2)
+	require.Nil(t, err)
+	assert.Equal(t, []byte{0x01, 0x02}, out)
+
+	// stop interrupts the watching without reading anything
+	ctx, stop = w.watch(context.Background())
+	stop()
+	assert.NotNil(t, ctx.Err())
+
+	// the client waiting longer than the queue timeout is dropped though it sends nothing
+	w.conf.QueueTimeout = 50 * time.Millisecond
+	ctx, stop = w.watch(context.Background())
+	select {
+	case <-ctx.Done():
+		assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		t.Fatal("the queue wait is not bounded")
+	}
+	stop()
+
+	// the client closing the connection cancels the queue at once
+	w.conf.QueueTimeout = time.Minute
+	ctx, stop = w.watch(context.Background())
+	defer stop()
+	require.Nil(t, cli.Close())
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the closed connection is not noticed in the queue")
+	}
+}
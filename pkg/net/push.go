@@ -0,0 +1,57 @@
+package net
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrPushExpired   = errors.New("push expired")
+	ErrWorkerStopped = errors.New("worker stopped")
+)
+
+// Priority orders the packets queued to the client, the high ones are written before the normal ones and the normal ones before the low ones.
+// the sc index is taken when the packet is written, so the indexes the client sees are consecutive in the write order
+type Priority int32
+
+const (
+	PriorityNormal Priority = iota
+	PriorityHigh
+	PriorityLow
+)
+
+// PushOptions are applied to the packet queued to the client
+type PushOptions struct {
+	Priority Priority
+	ExpireAt time.Time // the packet is dropped instead of written after it, zero means never
+	// Done is called with nil once the packet is written to the socket, or with the reason once it is dropped.
+	// it is called on the write loop, so it must not block
+	Done func(err error)
+	// Indexed is called with the sc index taken by the packet right before it is written, it is called on the write loop, so it must not block
+	Indexed func(index int64)
+}
+
+// Pusher queues the packets to the session with the options
+type Pusher interface {
+	PushWith(ctx context.Context, out []byte, opts PushOptions) error
+}
+
+// Finish reports the packet is written or dropped
+func (o PushOptions) Finish(err error) {
+	if o.Done != nil {
+		o.Done(err)
+	}
+}
+
+// Index reports the sc index taken by the packet
+func (o PushOptions) Index(index int64) {
+	if o.Indexed != nil {
+		o.Indexed(index)
+	}
+}
+
+func (o PushOptions) Expired(now time.Time) bool {
+	return !o.ExpireAt.IsZero() && now.After(o.ExpireAt)
+}
@@ -0,0 +1,10 @@
+package net
+
+// the registry metadata of the gate load, refreshed by the gate while it is registered.
+// the client dispatcher, e.g. the account service, hands out the gate with the least connections per weight
+const (
+	MetadataConnections = "connections" // the sessions served by the gate
+	MetadataCapacity    = "capacity"    // the sessions the gate is sized for, 0 is unknown
+	MetadataWeight      = "weight"      // the load balancing weight
+	MetadataAddrs       = "addrs"       // the public ips of the gate, separated by commas
+)
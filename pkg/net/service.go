@@ -3,6 +3,7 @@ package net
 import (
 	"context"
 
+	"github.com/pkg/errors"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net/tunnel"
 )
 
@@ -11,11 +12,35 @@ const (
 	MaxBodySize = int32(1 << 14)
 )
 
+// LogoutCode is the reason why the server closes the session, the Service maps it onto the client logout message
+type LogoutCode int32
+
+const (
+	LogoutCodeServer LogoutCode = iota
+	LogoutCodeWaiting
+	LogoutCodeAuth
+	LogoutCodeConflictingLogin
+	LogoutCodeKickedOut
+	LogoutCodeBanned
+)
+
+// ErrHandshakeRejected is returned by Auth when the handshake is valid but the login is refused, e.g. the maintenance.
+// it is not counted as a failure by the guard
+var ErrHandshakeRejected = errors.New("handshake rejected")
+
 type Service interface {
+	// Auth returns the handshake reply and the session, the out returned with ErrHandshakeRejected is written
+	// to the client before the connection is closed, so the client knows why it is rejected
 	Auth(ctx context.Context, in []byte) (out []byte, ss Session, err error)
 	TunnelType(mod int32) (int32, error)
 	CreateTunnel(ctx context.Context, ss Session, tp int32, routerId int64, worker tunnel.Worker) (tunnel.Tunnel, error)
-	OnConnected(ctx context.Context, ss Session) (err error)
+	// OnConnected is called after the session is served by the server, once its writer runs and before its packets are read,
+	// the packets pushed by it are written ahead of the tunnel traffic and may outnumber the reply queue
+	OnConnected(ctx context.Context, ss Session, p Pusher) (err error)
 	OnDisconnect(ctx context.Context, ss Session) (err error)
 	Handle(ctx context.Context, ss Session, h tunnel.Holder, in []byte) (err error)
+	LogoutPack(ctx context.Context, ss Session, code LogoutCode) (out []byte, err error)
+	// IndexPack stamps the sc index on the packet built by the service, the packets are built without it.
+	// it is called right before the packet is written, so the client sees the indexes in the write order
+	IndexPack(pack []byte, index int64) (out []byte, err error)
 }
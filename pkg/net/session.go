@@ -2,6 +2,8 @@ package net
 
 import (
 	"crypto/cipher"
+	"sort"
+	"sync"
 
 	"go.uber.org/atomic"
 )
@@ -15,15 +17,28 @@ type Session interface {
 	Status() int64
 	StartTime() int64
 
+	TokenTimeout() int64
+	SetTokenTimeout(timeout int64)
+
 	ClientIP() string
 	SetClientIP(ip string)
 
+	// Tags are attached by the backends, such as the guild ID or the region channel
+	Tags() []string
+	HasTag(tag string) bool
+	UpdateTags(add, remove []string)
+	// SetTagsObserver sets the observer told about the tags really added or removed, nil clears it
+	SetTagsObserver(o TagsObserver)
+
 	CSIndex() int64
 	SCIndex() int64
 	IncreaseCSIndex() int64
 	IncreaseSCIndex() int64
 }
 
+// TagsObserver is called after the tags of the session are updated, it keeps the tag index of the server
+type TagsObserver func(ss Session, added, removed []string)
+
 type Encryptor interface {
 	IsCrypto() bool
 	Block() cipher.Block
@@ -42,15 +57,22 @@ type session struct {
 	status    int64
 	startTime int64
 
+	tokenTimeout *atomic.Int64
+
+	tagsMu       sync.RWMutex
+	tags         map[string]struct{}
+	tagsObserver TagsObserver
+
 	csIndex *indexInfo
 	scIndex *indexInfo
 }
 
 func DefaultSession() Session {
 	return &session{
-		encryptor: &encryptor{},
-		csIndex:   newIndexInfo(0),
-		scIndex:   newIndexInfo(1),
+		encryptor:    &encryptor{},
+		tokenTimeout: atomic.NewInt64(0),
+		csIndex:      newIndexInfo(0),
+		scIndex:      newIndexInfo(1),
 	}
 }
 
@@ -62,13 +84,14 @@ func NewSession(userId int64, sid int64, st int64, block cipher.Block, key []byt
 			block:   block,
 			key:     key,
 		},
-		userId:    userId,
-		color:     color,
-		status:    status,
-		serverId:  sid,
-		startTime: st,
-		csIndex:   newIndexInfo(0),
-		scIndex:   newIndexInfo(1),
+		userId:       userId,
+		color:        color,
+		status:       status,
+		serverId:     sid,
+		startTime:    st,
+		tokenTimeout: atomic.NewInt64(0),
+		csIndex:      newIndexInfo(0),
+		scIndex:      newIndexInfo(1),
 	}
 	return s
 }
@@ -93,6 +116,16 @@ func (s *session) StartTime() int64 {
 	return s.startTime
 }
 
+// TokenTimeout is the unix time when the token of the session expires, 0 means no expiry
+func (s *session) TokenTimeout() int64 {
+	return s.tokenTimeout.Load()
+}
+
+// SetTokenTimeout is called on handshake and when the client presents a refreshed token
+func (s *session) SetTokenTimeout(timeout int64) {
+	s.tokenTimeout.Store(timeout)
+}
+
 func (s *session) UID() int64 {
 	return s.userId
 }
@@ -120,6 +153,63 @@ func (s *session) SetClientIP(ip string) {
 	s.clientIP = ip
 }
 
+// Tags returns the sorted tags of the session
+func (s *session) Tags() []string {
+	s.tagsMu.RLock()
+	defer s.tagsMu.RUnlock()
+
+	tags := make([]string, 0, len(s.tags))
+	for tag := range s.tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func (s *session) HasTag(tag string) bool {
+	s.tagsMu.RLock()
+	defer s.tagsMu.RUnlock()
+
+	_, ok := s.tags[tag]
+	return ok
+}
+
+// UpdateTags adds and then removes the tags, so a tag in both lists is removed.
+// the observer is called out of the lock with the tags which are really changed
+func (s *session) UpdateTags(add, remove []string) {
+	var added, removed []string
+
+	s.tagsMu.Lock()
+	if s.tags == nil {
+		s.tags = make(map[string]struct{}, len(add))
+	}
+	for _, tag := range add {
+		if _, ok := s.tags[tag]; !ok {
+			s.tags[tag] = struct{}{}
+			added = append(added, tag)
+		}
+	}
+	for _, tag := range remove {
+		if _, ok := s.tags[tag]; ok {
+			delete(s.tags, tag)
+			removed = append(removed, tag)
+		}
+	}
+	observer := s.tagsObserver
+	s.tagsMu.Unlock()
+
+	if observer != nil && (len(added) > 0 || len(removed) > 0) {
+		observer(s, added, removed)
+	}
+}
+
+func (s *session) SetTagsObserver(o TagsObserver) {
+	s.tagsMu.Lock()
+	defer s.tagsMu.Unlock()
+
+	s.tagsObserver = o
+}
+
 type indexInfo struct {
 	start int64
 	index *atomic.Int64
@@ -0,0 +1,61 @@
+package net
+
+// TagExpr matches the session tags, e.g. `guild:1 && !(region:eu || region:us)`.
+// the operators are ! && || and the parentheses, && binds tighter than ||
+type TagExpr interface {
+	Match(hasTag func(tag string) bool) bool
+	// Required returns a tag which every matched session has, so the sessions can be looked up by the tag index
+	Required() (string, bool)
+}
+
+type tagNode string
+
+func (n tagNode) Match(hasTag func(string) bool) bool { return hasTag(string(n)) }
+func (n tagNode) Required() (string, bool)            { return string(n), true }
+
+type notNode struct{ x TagExpr }
+
+func (n notNode) Match(hasTag func(string) bool) bool { return !n.x.Match(hasTag) }
+func (n notNode) Required() (string, bool)            { return "", false }
+
+type andNode struct{ l, r TagExpr }
+
+func (n andNode) Match(hasTag func(string) bool) bool {
+	return n.l.Match(hasTag) && n.r.Match(hasTag)
+}
+
+func (n andNode) Required() (string, bool) {
+	if tag, ok := n.l.Required(); ok {
+		return tag, true
+	}
+	return n.r.Required()
+}
+
+type orNode struct{ l, r TagExpr }
+
+func (n orNode) Match(hasTag func(string) bool) bool {
+	return n.l.Match(hasTag) || n.r.Match(hasTag)
+}
+
+func (n orNode) Required() (string, bool) { return "", false }
+
+// ParseTagExpr parses the tag expression, the tag is any run of the characters except the spaces, the operators and the parentheses
+func ParseTagExpr(s string) (TagExpr, error) {
+	p := &exprParser[TagExpr]{
+		name:  "tag expression",
+		src:   s,
+		ops:   []string{"&&", "||", "!", "(", ")"},
+		stops: " &|!()",
+		not:   func(x TagExpr) TagExpr { return notNode{x: x} },
+		and:   func(l, r TagExpr) TagExpr { return andNode{l: l, r: r} },
+		or:    func(l, r TagExpr) TagExpr { return orNode{l: l, r: r} },
+	}
+	p.operand = func(tok string) (TagExpr, error) {
+		switch tok {
+		case "", "&&", "||", ")", "&", "|":
+			return nil, p.unexpected(tok)
+		}
+		return tagNode(tok), nil
+	}
+	return p.parse()
+}
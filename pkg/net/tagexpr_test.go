@@ -0,0 +1,39 @@
+package net
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTagExpr(t *testing.T) {
+	tags := map[string]bool{"guild:1": true, "region:eu": true, "lv:10-20": true}
+	hasTag := func(tag string) bool { return tags[tag] }
+
+	cases := []struct {
+		expr     string
+		match    bool
+		required string
+	}{
+		{"guild:1", true, "guild:1"},
+		{"guild:2", false, "guild:2"},
+		{"guild:1 && region:eu", true, "guild:1"},
+		{"!guild:1 && region:eu", false, "region:eu"},
+		{"guild:2 || region:eu", true, ""},
+		{"guild:2 || region:eu && lv:10-20", true, ""},
+		{"(guild:2 || region:eu) && !region:us", true, ""},
+		{"guild:1&&!(region:eu||region:us)", false, "guild:1"},
+	}
+	for _, c := range cases {
+		x, err := ParseTagExpr(c.expr)
+		assert.Nil(t, err, c.expr)
+		assert.Equal(t, c.match, x.Match(hasTag), c.expr)
+		tag, _ := x.Required()
+		assert.Equal(t, c.required, tag, c.expr)
+	}
+
+	for _, expr := range []string{"", "guild:1 &&", "(guild:1", "guild:1)", "guild:1 & region:eu", "|| guild:1"} {
+		_, err := ParseTagExpr(expr)
+		assert.NotNil(t, err, expr)
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"encoding/binary"
 	"fmt"
 	"net"
+	gosync "sync"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/pkg/errors"
 	vnet "github.com/vulcan-frame/vulcan-gate/pkg/net"
 	vctx "github.com/vulcan-frame/vulcan-gate/pkg/net/context"
+	"github.com/vulcan-frame/vulcan-gate/pkg/net/internal"
 	"github.com/vulcan-frame/vulcan-gate/pkg/net/internal/bufreader"
 	"github.com/vulcan-frame/vulcan-pkg-tool/sync"
 	"golang.org/x/sync/errgroup"
@@ -27,6 +29,14 @@ func Bind(bind string) Option {
 	}
 }
 
+// HandshakeKeyID sends the handshake in the key id frame of id, the handshake must be encrypted with the RSA key of id.
+// the client without it sends the legacy frame, which the gate decrypts with the legacy or the active key
+func HandshakeKeyID(id string) Option {
+	return func(s *Client) {
+		s.handshakeKeyID = id
+	}
+}
+
 type Client struct {
 	sync.Stoppable
 
@@ -36,6 +46,11 @@ type Client struct {
 	conn   *net.TCPConn
 	reader *bufreader.Reader
 
+	// handshake is the first pack sent, it is sent again after the challenge is answered
+	handshake      []byte
+	handshakeKeyID string
+	sendLock       gosync.Mutex
+
 	receivePackChan chan []byte
 }
 
@@ -120,10 +135,35 @@ func (c *Client) readPackLoop() error {
 		if err != nil {
 			return err
 		}
+		if internal.IsChallenge(pack) {
+			if err = c.answer(pack); err != nil {
+				return err
+			}
+			continue
+		}
 		c.receivePackChan <- pack
 	}
 }
 
+// answer solves the challenge sent before the handshake and sends the handshake again
+func (c *Client) answer(challenge []byte) error {
+	resp, err := internal.SolveChallenge(challenge)
+	if err != nil {
+		return err
+	}
+
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+
+	if err = c.write(resp); err != nil {
+		return err
+	}
+	if len(c.handshake) == 0 {
+		return nil
+	}
+	return c.write(c.handshake)
+}
+
 func (c *Client) read() (buf []byte, err error) {
 	lb, err := c.reader.ReadFull(vnet.PackLenSize)
 	if err != nil {
@@ -167,6 +207,17 @@ func (c *Client) write(pack []byte) (err error) {
 }
 
 func (c *Client) Send(pack []byte) (err error) {
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+
+	if c.handshake == nil {
+		if c.handshakeKeyID != "" {
+			if pack, err = vnet.HandshakeKeyIDFrame(c.handshakeKeyID, pack); err != nil {
+				return err
+			}
+		}
+		c.handshake = pack
+	}
 	return c.write(pack)
 }
 
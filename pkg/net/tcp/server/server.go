@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware"
@@ -21,6 +22,11 @@ import (
 
 var _ transport.Server = (*Server)(nil)
 
+var ErrWorkerNotFound = errors.New("worker not found")
+
+// acceptStuckTimeout is how long the accepts fail in a row before the accept loops are reported stuck
+const acceptStuckTimeout = time.Second * 30
+
 type Option func(o *Server)
 
 type WrapFunc func(ctx context.Context, color string, uid int64) error
@@ -31,6 +37,79 @@ func Bind(bind string) Option {
 	}
 }
 
+// MaxSessionAge limits how long a session lives no matter how many times the token is refreshed
+func MaxSessionAge(d time.Duration) Option {
+	return func(s *Server) {
+		s.conf.Worker.MaxSessionAge = d
+	}
+}
+
+// SessionGracePeriod is how long a session lives after the token expires or the max session age is reached
+func SessionGracePeriod(d time.Duration) Option {
+	return func(s *Server) {
+		s.conf.Worker.SessionGracePeriod = d
+	}
+}
+
+// HandshakeChallenge makes the client pass a cookie or proof-of-work challenge before the handshake.
+// in auto mode the challenge is required only when the in-flight handshakes exceed the threshold
+func HandshakeChallenge(mode conf.ChallengeMode, threshold, difficulty int) Option {
+	return func(s *Server) {
+		s.conf.Worker.ChallengeMode = mode
+		s.conf.Worker.ChallengeThreshold = threshold
+		s.conf.Worker.ChallengeDifficulty = difficulty
+	}
+}
+
+// Guard rejects the connections from the banned ips and is told about the failed handshakes
+func Guard(g vnet.Guard) Option {
+	return func(s *Server) {
+		s.guard = g
+	}
+}
+
+// Admission holds the handshaked sessions in the login queue until they are admitted
+func Admission(a vnet.Admission) Option {
+	return func(s *Server) {
+		s.admission = a
+	}
+}
+
+// TunnelIdleTimeout closes the auxiliary tunnels idle longer than d, 0 means never
+func TunnelIdleTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.conf.Worker.TunnelIdleTimeout = d
+	}
+}
+
+// MaxTunnelsPerType caps the open tunnels of each type per worker, the least recently used one is evicted
+func MaxTunnelsPerType(n int) Option {
+	return func(s *Server) {
+		s.conf.Worker.MaxTunnelsPerType = n
+	}
+}
+
+// TunnelFailureTTL makes the tunnel creation of the same type fail fast for d after a failure, 0 means never
+func TunnelFailureTTL(d time.Duration) Option {
+	return func(s *Server) {
+		s.conf.Worker.TunnelFailureTTL = d
+	}
+}
+
+// QueueTimeout disconnects the client waiting in the login queue longer than d
+func QueueTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.conf.Worker.QueueTimeout = d
+	}
+}
+
+// EnforceTokenExpiry logs the session out when its token expires and is not refreshed in the grace period
+func EnforceTokenExpiry(enable bool) Option {
+	return func(s *Server) {
+		s.conf.Worker.EnforceTokenExpiry = enable
+	}
+}
+
 func Referer(referer string) Option {
 	return func(s *Server) {
 		s.referer = referer
@@ -85,6 +164,9 @@ type Server struct {
 	workerSize int
 	listener   net.Listener
 	buckets    *internal.Buckets
+	challenger *internal.Challenger
+	guard      vnet.Guard
+	admission  vnet.Admission
 
 	handler     vnet.Service
 	readFilter  middleware.Middleware
@@ -92,6 +174,11 @@ type Server struct {
 
 	afterConnectFunc    WrapFunc
 	afterDisconnectFunc WrapFunc
+
+	listening     *atomic.Bool
+	acceptLoops   *atomic.Int64 // the running accept loops
+	failingSince  *atomic.Int64 // unix nano of the first accept failure since the last success, 0 is not failing
+	lastAcceptErr *atomic.Error
 }
 
 func NewServer(handler vnet.Service, opts ...Option) (*Server, error) {
@@ -107,13 +194,23 @@ func NewServer(handler vnet.Service, opts ...Option) (*Server, error) {
 		writeFilter: middleware.Chain(
 			recovery.Recovery(),
 		),
-		handler: handler,
+		handler:       handler,
+		listening:     atomic.NewBool(false),
+		acceptLoops:   atomic.NewInt64(0),
+		failingSince:  atomic.NewInt64(0),
+		lastAcceptErr: atomic.NewError(nil),
 	}
 
 	for _, o := range opts {
 		o(s)
 	}
 
+	challenger, err := internal.NewChallenger(s.conf.Worker)
+	if err != nil {
+		return nil, err
+	}
+
+	s.challenger = challenger
 	s.buckets = internal.NewBuckets(s.conf.Bucket)
 	s.workerSize = s.conf.Server.WorkerSize
 
@@ -143,11 +240,13 @@ func (s *Server) Start(ctx context.Context) error {
 	idGen := atomic.NewUint64(0)
 	for i := 0; i < s.workerSize; i++ {
 		workerID := i
+		s.acceptLoops.Inc()
 		sync.GoSafe(fmt.Sprintf("tcp.Server.acceptLoop.%d", workerID), func() error {
 			return s.acceptLoop(ctx, idGen)
 		})
 	}
 
+	s.listening.Store(true)
 	log.Infof("[tcp.Server] listening on %s", addr.String())
 	return nil
 }
@@ -172,6 +271,8 @@ func (s *Server) stop() {
 }
 
 func (s *Server) acceptLoop(ctx context.Context, idGen *atomic.Uint64) error {
+	defer s.acceptLoops.Dec()
+
 	for {
 		select {
 		case <-s.Stopping():
@@ -182,8 +283,12 @@ func (s *Server) acceptLoop(ctx context.Context, idGen *atomic.Uint64) error {
 			return ctx.Err()
 		default:
 			if err := s.accept(ctx, idGen); err != nil {
+				s.failingSince.CompareAndSwap(0, time.Now().UnixNano())
+				s.lastAcceptErr.Store(err)
 				log.Errorf("[tcp.Server] %+v", err)
+				continue
 			}
+			s.failingSince.Store(0)
 		}
 	}
 }
@@ -209,6 +314,14 @@ func (s *Server) accept(ctx context.Context, idGen *atomic.Uint64) error {
 }
 
 func (s *Server) serve(ctx context.Context, conn *net.TCPConn, wid uint64) error {
+	if s.guard != nil && !s.guard.Allow(vctx.RemoteIP(conn)) {
+		log.Debugf("[tcp.Server] connection rejected by guard. wid=%d remote=%s", wid, vctx.RemoteAddr(conn))
+		if err := conn.Close(); err != nil {
+			return errors.Wrapf(err, "close rejected conn failed")
+		}
+		return nil
+	}
+
 	if err := conn.SetKeepAlive(s.conf.Server.KeepAlive); err != nil {
 		return errors.Wrapf(err, "SetKeepAlive failed v=%v	", s.conf.Server.KeepAlive)
 	}
@@ -223,7 +336,7 @@ func (s *Server) serve(ctx context.Context, conn *net.TCPConn, wid uint64) error
 }
 
 func (s *Server) work(ctx context.Context, conn *net.TCPConn, wid uint64) (err error) {
-	w := internal.NewWorker(wid, conn, s.logger, s.conf.Worker, s.referer, s.readFilter, s.writeFilter, s.handler)
+	w := internal.NewWorker(wid, conn, s.logger, s.conf.Worker, s.referer, s.challenger, s.guard, s.admission, s.readFilter, s.writeFilter, s.handler)
 
 	defer func() {
 		if err != nil {
@@ -240,6 +353,9 @@ func (s *Server) work(ctx context.Context, conn *net.TCPConn, wid uint64) (err e
 	}()
 
 	if err = w.Start(ctx); err != nil {
+		if s.guard != nil && !errors.Is(err, internal.ErrAdmissionFailed) {
+			s.guard.OnHandshakeFailed(vctx.RemoteIP(conn), err)
+		}
 		return err
 	}
 	if err = s.putBucket(w); err != nil {
@@ -252,7 +368,6 @@ func (s *Server) work(ctx context.Context, conn *net.TCPConn, wid uint64) (err e
 			return err
 		}
 	}
-
 	return w.Run(ctx)
 }
 
@@ -278,6 +393,43 @@ func (s *Server) Disconnect(ctx context.Context, key uint64) error {
 	return nil
 }
 
+// Connections returns the count of the sessions served, the ones waiting in the login queue are not included
+func (s *Server) Connections() int {
+	return s.buckets.Len()
+}
+
+// Env returns the deploy env parsed from the flags and the env variables
+func (s *Server) Env() conf.Env {
+	return s.conf.Env
+}
+
+// Listening reports whether the server is started and takes the new connections
+func (s *Server) Listening() bool {
+	return s.listening.Load() && !s.IsStopping()
+}
+
+// Draining reports whether the server is stopping, the connected sessions are being closed
+func (s *Server) Draining() bool {
+	return s.IsStopping()
+}
+
+// CheckAccept returns an error when the accept loops are stuck: some of them exited,
+// or the accepts have failed in a row longer than acceptStuckTimeout. the server not started or stopping is not checked
+func (s *Server) CheckAccept() error {
+	if !s.listening.Load() || s.IsStopping() {
+		return nil
+	}
+	if n := s.acceptLoops.Load(); n < int64(s.workerSize) {
+		return errors.Errorf("accept loops exited. running=%d expected=%d", n, s.workerSize)
+	}
+	if since := s.failingSince.Load(); since > 0 {
+		if d := time.Since(time.Unix(0, since)); d > acceptStuckTimeout {
+			return errors.Errorf("accept failing for %s. last error: %v", d.Truncate(time.Second), s.lastAcceptErr.Load())
+		}
+	}
+	return nil
+}
+
 func (s *Server) WIDList() []uint64 {
 	ids := make([]uint64, 0, 1024)
 	s.buckets.Walk(func(w *internal.Worker) bool {
@@ -294,11 +446,25 @@ func (s *Server) Push(ctx context.Context, uid int64, pack []byte) error {
 
 	w := s.buckets.GetByUID(uid)
 	if w == nil {
-		return errors.Errorf("worker not found. uid=%d", uid)
+		return errors.Wrapf(ErrWorkerNotFound, "uid=%d", uid)
 	}
 	return w.Push(ctx, pack)
 }
 
+// PushWith pushes the packet built for the session of the uid with the options, it returns ErrWorkerNotFound if the uid is not held by the server
+func (s *Server) PushWith(ctx context.Context, uid int64, pack func(ss vnet.Session) ([]byte, error), opts vnet.PushOptions) error {
+	w := s.buckets.GetByUID(uid)
+	if w == nil {
+		return errors.Wrapf(ErrWorkerNotFound, "uid=%d", uid)
+	}
+
+	out, err := pack(w.Session())
+	if err != nil {
+		return err
+	}
+	return w.PushWith(ctx, out, opts)
+}
+
 func (s *Server) PushGroup(ctx context.Context, uids []int64, pack []byte) (err error) {
 	if len(pack) <= 0 {
 		return errors.New("push group msg len <= 0")
@@ -313,6 +479,79 @@ func (s *Server) PushGroup(ctx context.Context, uids []int64, pack []byte) (err
 	return
 }
 
+// Multicast pushes the packet built for each session of the uids held by the server.
+// the sc index is stamped when the packet is written, so pack may return the same packet for every session. it returns the uids not held by the server
+func (s *Server) Multicast(ctx context.Context, uids []int64, pack func(ss vnet.Session) ([]byte, error), opts vnet.PushOptions) (missing []int64, err error) {
+	for _, uid := range uids {
+		w := s.buckets.GetByUID(uid)
+		if w == nil {
+			missing = append(missing, uid)
+			continue
+		}
+
+		out, err0 := pack(w.Session())
+		if err0 == nil {
+			err0 = w.PushWith(ctx, out, opts)
+		}
+		if err0 != nil {
+			err = errors.WithMessagef(err0, " uid=%d", uid)
+		}
+	}
+	return
+}
+
+// UpdateTags updates the tags of the session of the uid held by the server
+func (s *Server) UpdateTags(uid int64, add, remove []string) error {
+	w := s.buckets.GetByUID(uid)
+	if w == nil {
+		return errors.Errorf("worker not found. uid=%d", uid)
+	}
+	w.Session().UpdateTags(add, remove)
+	return nil
+}
+
+// PushByTag pushes the packet built for each session which has the tag, it returns the count of the pushed sessions
+func (s *Server) PushByTag(ctx context.Context, tag string, pack func(ss vnet.Session) ([]byte, error), opts vnet.PushOptions) (count int, err error) {
+	return s.pushWorkers(ctx, s.buckets.GetByTag(tag), pack, opts)
+}
+
+// PushByTagExpr pushes the packet built for each session whose tags match the expression.
+// the sessions are looked up by the tag index if the expression requires a tag, otherwise all sessions are walked
+func (s *Server) PushByTagExpr(ctx context.Context, expr vnet.TagExpr, pack func(ss vnet.Session) ([]byte, error), opts vnet.PushOptions) (count int, err error) {
+	var candidates []*internal.Worker
+	if tag, ok := expr.Required(); ok {
+		candidates = s.buckets.GetByTag(tag)
+	} else {
+		s.buckets.Walk(func(w *internal.Worker) bool {
+			candidates = append(candidates, w)
+			return true
+		})
+	}
+
+	workers := candidates[:0]
+	for _, w := range candidates {
+		if expr.Match(w.Session().HasTag) {
+			workers = append(workers, w)
+		}
+	}
+	return s.pushWorkers(ctx, workers, pack, opts)
+}
+
+func (s *Server) pushWorkers(ctx context.Context, workers []*internal.Worker, pack func(ss vnet.Session) ([]byte, error), opts vnet.PushOptions) (count int, err error) {
+	for _, w := range workers {
+		out, err0 := pack(w.Session())
+		if err0 == nil {
+			err0 = w.PushWith(ctx, out, opts)
+		}
+		if err0 != nil {
+			err = errors.WithMessagef(err0, " uid=%d", w.UID())
+			continue
+		}
+		count++
+	}
+	return
+}
+
 func (s *Server) Broadcast(ctx context.Context, pack []byte) (err error) {
 	if len(pack) <= 0 {
 		return errors.New("broadcast msg len <= 0")
@@ -327,6 +566,36 @@ func (s *Server) Broadcast(ctx context.Context, pack []byte) (err error) {
 	return
 }
 
+// BroadcastWith pushes the packet built for each session matched by the filter, it returns the count of the matched and the pushed sessions
+func (s *Server) BroadcastWith(ctx context.Context, filter vnet.SessionFilter, pack func(ss vnet.Session) ([]byte, error), opts vnet.PushOptions) (matched, delivered int, err error) {
+	var workers []*internal.Worker
+	s.buckets.Walk(func(w *internal.Worker) bool {
+		if filter.Match(w.Session()) {
+			workers = append(workers, w)
+		}
+		return true
+	})
+
+	delivered, err = s.pushWorkers(ctx, workers, pack, opts)
+	return len(workers), delivered, err
+}
+
+// Logout sends the logout message to each session matched by the filter and closes them, it returns the count of the sessions
+func (s *Server) Logout(ctx context.Context, filter vnet.SessionFilter, code vnet.LogoutCode) int {
+	var workers []*internal.Worker
+	s.buckets.Walk(func(w *internal.Worker) bool {
+		if filter.Match(w.Session()) {
+			workers = append(workers, w)
+		}
+		return true
+	})
+
+	for _, w := range workers {
+		w.Logout(ctx, code)
+	}
+	return len(workers)
+}
+
 func (s *Server) Endpoint() (string, error) {
 	addr, err := ip.Extract(s.conf.Server.Bind, s.listener)
 	if err != nil {
@@ -2,13 +2,20 @@ package tunnel
 
 import (
 	"context"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/vulcan-frame/vulcan-pkg-tool/sync"
 )
 
+// ErrCreateFailed is returned without creating while the last creation of the same type and oid failed in the failure ttl
+var ErrCreateFailed = errors.New("tunnel creation failed recently")
+
 type Holder interface {
 	Pusher
 	Tunnel(ctx context.Context, key int32, oid int64) (Tunnel, error)
+	// CloseTunnel stops the auxiliary tunnel of the module and oid if it exists, the main tunnel is never closed by it
+	CloseTunnel(key int32, oid int64)
 }
 
 type Pusher interface {
@@ -20,6 +27,11 @@ type Worker interface {
 	sync.CountdownStopper
 	Holder
 	Pusher
+
+	// SetStopCountDownTime starts the countdown to close the worker when the main tunnel is gone
+	SetStopCountDownTime(now time.Time)
+	// Context returns the session context which carries the session metadata, it outlives the tunnels
+	Context() context.Context
 }
 
 type Tunnel interface {
@@ -28,6 +40,13 @@ type Tunnel interface {
 
 	Type() int32
 	Forward(ctx context.Context, msg ForwardMessage) error
+
+	// IsMain returns true if the worker can not live without the tunnel, the main tunnel is never reclaimed
+	IsMain() bool
+	// IsPinned returns true while the auxiliary tunnel is kept even if it is idle, e.g. the user is in the room
+	IsPinned() bool
+	// LastActive is the time of the last message forwarded or pushed through the tunnel
+	LastActive() time.Time
 }
 
 type ForwardMessage interface {